@@ -31,9 +31,22 @@ return true
 var ErrTooLargeOffset = errors.New("too large offset")
 
 type (
+	// A BloomConfig is the config to customize a bloom filter.
+	BloomConfig struct {
+		// Store is the backed redis, nil means an in-memory bitset is used.
+		Store *redis.Redis
+		// Key is the key for the bloom filter, ignored for in-memory bitsets.
+		Key string
+		// Bits is how many bits will be used.
+		Bits uint
+		// Maps is how many hashes for each addition, default to 14 if not set.
+		Maps uint
+	}
+
 	// A Filter is a bloom filter.
 	Filter struct {
 		bits   uint
+		maps   uint
 		bitSet bitSetProvider
 	}
 
@@ -52,10 +65,33 @@ type (
 func New(store *redis.Redis, key string, bits uint) *Filter {
 	return &Filter{
 		bits:   bits,
+		maps:   maps,
 		bitSet: newRedisBitSet(store, key, bits),
 	}
 }
 
+// NewWithConfig creates a Filter with the given config,
+// which allows to customize the number of hash functions for a target false-positive rate,
+// and to use an in-memory bitset when c.Store is nil.
+func NewWithConfig(c BloomConfig) *Filter {
+	if c.Maps == 0 {
+		c.Maps = maps
+	}
+
+	var bitSet bitSetProvider
+	if c.Store != nil {
+		bitSet = newRedisBitSet(c.Store, c.Key, c.Bits)
+	} else {
+		bitSet = newMemBitSet(c.Bits)
+	}
+
+	return &Filter{
+		bits:   c.Bits,
+		maps:   c.Maps,
+		bitSet: bitSet,
+	}
+}
+
 // Add adds data into f.
 func (f *Filter) Add(data []byte) error {
 	locations := f.getLocations(data)
@@ -77,8 +113,8 @@ func (f *Filter) Exists(data []byte) (bool, error) {
 }
 
 func (f *Filter) getLocations(data []byte) []uint {
-	locations := make([]uint, maps)
-	for i := uint(0); i < maps; i++ {
+	locations := make([]uint, f.maps)
+	for i := uint(0); i < f.maps; i++ {
 		hashValue := hash.Hash(append(data, byte(i)))
 		locations[i] = uint(hashValue % uint64(f.bits))
 	}
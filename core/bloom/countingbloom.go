@@ -0,0 +1,244 @@
+package bloom
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/hash"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+const (
+	// maxCount is the saturation value of each counter.
+	// a saturated counter is never decremented anymore, which keeps Remove safe
+	// at the cost of potentially permanent false positives on that slot.
+	maxCount = 255
+
+	incrScript = `
+for _, offset in ipairs(ARGV) do
+	local current = tonumber(redis.call("hget", KEYS[1], offset) or 0)
+	if current < 255 then
+		redis.call("hincrby", KEYS[1], offset, 1)
+	end
+end
+`
+	decrScript = `
+for _, offset in ipairs(ARGV) do
+	local current = tonumber(redis.call("hget", KEYS[1], offset) or 0)
+	if current > 0 and current < 255 then
+		if current == 1 then
+			redis.call("hdel", KEYS[1], offset)
+		else
+			redis.call("hincrby", KEYS[1], offset, -1)
+		end
+	end
+end
+`
+	countScript = `
+for _, offset in ipairs(ARGV) do
+	if tonumber(redis.call("hget", KEYS[1], offset) or 0) == 0 then
+		return false
+	end
+end
+return true
+`
+)
+
+type (
+	// A CountingBloom is a bloom filter that supports removing items.
+	// Each bit of a standard bloom filter is replaced with a uint8 counter,
+	// so it costs 8x the space of a Filter with the same bits.
+	CountingBloom struct {
+		bits     uint
+		maps     uint
+		counters counterProvider
+	}
+
+	counterProvider interface {
+		check([]uint) (bool, error)
+		incr([]uint) error
+		decr([]uint) error
+	}
+)
+
+// NewCountingBloom creates a CountingBloom with the given config,
+// backed by redis counters if c.Store is set, or by a local counter slice.
+func NewCountingBloom(c BloomConfig) *CountingBloom {
+	if c.Maps == 0 {
+		c.Maps = maps
+	}
+
+	var counters counterProvider
+	if c.Store != nil {
+		counters = newRedisCounterSet(c.Store, c.Key, c.Bits)
+	} else {
+		counters = newMemCounterSet(c.Bits)
+	}
+
+	return &CountingBloom{
+		bits:     c.Bits,
+		maps:     c.Maps,
+		counters: counters,
+	}
+}
+
+// Add adds data into cb.
+func (cb *CountingBloom) Add(data []byte) error {
+	return cb.counters.incr(cb.getLocations(data))
+}
+
+// Exists checks if data is in cb.
+func (cb *CountingBloom) Exists(data []byte) (bool, error) {
+	return cb.counters.check(cb.getLocations(data))
+}
+
+// Remove removes data from cb, only call it with data that was added before,
+// otherwise other items may be falsely reported as missing.
+func (cb *CountingBloom) Remove(data []byte) error {
+	return cb.counters.decr(cb.getLocations(data))
+}
+
+func (cb *CountingBloom) getLocations(data []byte) []uint {
+	locations := make([]uint, cb.maps)
+	for i := uint(0); i < cb.maps; i++ {
+		hashValue := hash.Hash(append(data, byte(i)))
+		locations[i] = uint(hashValue % uint64(cb.bits))
+	}
+
+	return locations
+}
+
+type redisCounterSet struct {
+	store *redis.Redis
+	key   string
+	bits  uint
+}
+
+func newRedisCounterSet(store *redis.Redis, key string, bits uint) *redisCounterSet {
+	return &redisCounterSet{
+		store: store,
+		key:   key,
+		bits:  bits,
+	}
+}
+
+func (r *redisCounterSet) buildOffsetArgs(offsets []uint) ([]string, error) {
+	var args []string
+
+	for _, offset := range offsets {
+		if offset >= r.bits {
+			return nil, ErrTooLargeOffset
+		}
+
+		args = append(args, strconv.FormatUint(uint64(offset), 10))
+	}
+
+	return args, nil
+}
+
+func (r *redisCounterSet) check(offsets []uint) (bool, error) {
+	args, err := r.buildOffsetArgs(offsets)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := r.store.Eval(countScript, []string{r.key}, args)
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	exists, ok := resp.(int64)
+	if !ok {
+		return false, nil
+	}
+
+	return exists == 1, nil
+}
+
+func (r *redisCounterSet) decr(offsets []uint) error {
+	return r.runScript(decrScript, offsets)
+}
+
+func (r *redisCounterSet) incr(offsets []uint) error {
+	return r.runScript(incrScript, offsets)
+}
+
+func (r *redisCounterSet) runScript(script string, offsets []uint) error {
+	args, err := r.buildOffsetArgs(offsets)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.Eval(script, []string{r.key}, args)
+	if err == redis.Nil {
+		return nil
+	}
+
+	return err
+}
+
+type memCounterSet struct {
+	lock     sync.Mutex
+	bits     uint
+	counters []uint8
+}
+
+func newMemCounterSet(bits uint) *memCounterSet {
+	return &memCounterSet{
+		bits:     bits,
+		counters: make([]uint8, bits),
+	}
+}
+
+func (m *memCounterSet) check(offsets []uint) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, offset := range offsets {
+		if offset >= m.bits {
+			return false, ErrTooLargeOffset
+		}
+
+		if m.counters[offset] == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (m *memCounterSet) decr(offsets []uint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, offset := range offsets {
+		if offset >= m.bits {
+			return ErrTooLargeOffset
+		}
+
+		if count := m.counters[offset]; count > 0 && count < maxCount {
+			m.counters[offset]--
+		}
+	}
+
+	return nil
+}
+
+func (m *memCounterSet) incr(offsets []uint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, offset := range offsets {
+		if offset >= m.bits {
+			return ErrTooLargeOffset
+		}
+
+		if m.counters[offset] < maxCount {
+			m.counters[offset]++
+		}
+	}
+
+	return nil
+}
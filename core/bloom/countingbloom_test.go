@@ -0,0 +1,61 @@
+package bloom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+func TestMemCountingBloom(t *testing.T) {
+	cb := NewCountingBloom(BloomConfig{
+		Bits: 1024,
+	})
+	assert.Nil(t, cb.Add([]byte("hello")))
+	assert.Nil(t, cb.Add([]byte("hello")))
+	ok, err := cb.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, cb.Remove([]byte("hello")))
+	ok, err = cb.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, cb.Remove([]byte("hello")))
+	ok, err = cb.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemCounterSetSaturation(t *testing.T) {
+	set := newMemCounterSet(16)
+	for i := 0; i < maxCount+10; i++ {
+		assert.Nil(t, set.incr([]uint{3}))
+	}
+	assert.Equal(t, uint8(maxCount), set.counters[3])
+	// saturated counters are never decremented
+	assert.Nil(t, set.decr([]uint{3}))
+	assert.Equal(t, uint8(maxCount), set.counters[3])
+}
+
+func TestRedisCountingBloom(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cb := NewCountingBloom(BloomConfig{
+		Store: store,
+		Key:   "test_counting_bloom",
+		Bits:  1024,
+	})
+	assert.Nil(t, cb.Add([]byte("hello")))
+	ok, err := cb.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, cb.Remove([]byte("hello")))
+	ok, err = cb.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
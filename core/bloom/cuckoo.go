@@ -0,0 +1,263 @@
+package bloom
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/hash"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+const (
+	// defaultBuckets is the bucket count used when none is configured.
+	defaultBuckets = 1024
+	// defaultSlots is the standard bucket size of cuckoo filters.
+	defaultSlots = 4
+	// maxKicks is how many relocations are tried before giving up an insertion.
+	maxKicks = 500
+)
+
+// ErrCuckooFull indicates the cuckoo filter reached its load factor limit,
+// the insertion failed even after maxKicks relocations.
+var ErrCuckooFull = errors.New("cuckoo filter is full")
+
+type (
+	// A CuckooConfig is the config to customize a cuckoo filter.
+	CuckooConfig struct {
+		// Store is the backed redis, nil means an in-memory table is used.
+		Store *redis.Redis
+		// Key is the key for the cuckoo filter, ignored for in-memory tables.
+		Key string
+		// Buckets is how many buckets will be used, default to 1024 if not set.
+		// It's rounded up to the next power of two, the xor based partial-key
+		// mapping is only self-inverse for power-of-two bucket counts.
+		// Capacity is roughly Buckets*Slots*0.95.
+		Buckets uint
+		// Slots is how many fingerprints each bucket holds, default to 4 if not set.
+		Slots uint
+	}
+
+	// A Cuckoo is a cuckoo filter, it supports deletion and
+	// is more space efficient than a counting bloom filter.
+	// The redis-backed table doesn't make relocations atomic,
+	// use it with a single writer.
+	Cuckoo struct {
+		buckets uint
+		slots   uint
+		table   cuckooTable
+	}
+
+	cuckooTable interface {
+		get(bucket, slot uint) (uint8, error)
+		set(bucket, slot uint, fp uint8) error
+	}
+)
+
+// NewCuckoo creates a Cuckoo filter with the given config.
+func NewCuckoo(c CuckooConfig) *Cuckoo {
+	if c.Buckets == 0 {
+		c.Buckets = defaultBuckets
+	}
+	c.Buckets = ceilToPowerOfTwo(c.Buckets)
+	if c.Slots == 0 {
+		c.Slots = defaultSlots
+	}
+
+	var table cuckooTable
+	if c.Store != nil {
+		table = newRedisCuckooTable(c.Store, c.Key)
+	} else {
+		table = newMemCuckooTable(c.Buckets, c.Slots)
+	}
+
+	return &Cuckoo{
+		buckets: c.Buckets,
+		slots:   c.Slots,
+		table:   table,
+	}
+}
+
+// Insert inserts data into c, returns ErrCuckooFull if the filter is overloaded.
+func (c *Cuckoo) Insert(data []byte) error {
+	fp, i1, i2 := c.locate(data)
+
+	for _, bucket := range []uint{i1, i2} {
+		ok, err := c.putInBucket(bucket, fp)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	// both buckets full, relocate existing fingerprints
+	bucket := i1
+	if rand.Intn(2) == 0 {
+		bucket = i2
+	}
+	for kick := 0; kick < maxKicks; kick++ {
+		slot := uint(rand.Intn(int(c.slots)))
+		kicked, err := c.table.get(bucket, slot)
+		if err != nil {
+			return err
+		}
+		if err := c.table.set(bucket, slot, fp); err != nil {
+			return err
+		}
+
+		fp = kicked
+		bucket = c.altBucket(bucket, fp)
+		ok, err := c.putInBucket(bucket, fp)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	return ErrCuckooFull
+}
+
+// Delete removes data from c, only call it with data that was inserted before,
+// otherwise other items sharing the fingerprint may be removed.
+func (c *Cuckoo) Delete(data []byte) (bool, error) {
+	fp, i1, i2 := c.locate(data)
+
+	for _, bucket := range []uint{i1, i2} {
+		for slot := uint(0); slot < c.slots; slot++ {
+			got, err := c.table.get(bucket, slot)
+			if err != nil {
+				return false, err
+			}
+			if got == fp {
+				return true, c.table.set(bucket, slot, 0)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Lookup checks if data is in c.
+func (c *Cuckoo) Lookup(data []byte) (bool, error) {
+	fp, i1, i2 := c.locate(data)
+
+	for _, bucket := range []uint{i1, i2} {
+		for slot := uint(0); slot < c.slots; slot++ {
+			got, err := c.table.get(bucket, slot)
+			if err != nil {
+				return false, err
+			}
+			if got == fp {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (c *Cuckoo) altBucket(bucket uint, fp uint8) uint {
+	// buckets is a power of two, masking keeps the xor mapping self-inverse
+	return (bucket ^ uint(hash.Hash([]byte{fp}))) & (c.buckets - 1)
+}
+
+func (c *Cuckoo) locate(data []byte) (fp uint8, i1, i2 uint) {
+	hashValue := hash.Hash(data)
+	fp = uint8(hashValue >> 56)
+	if fp == 0 {
+		// zero marks an empty slot
+		fp = 1
+	}
+	i1 = uint(hashValue) & (c.buckets - 1)
+	i2 = c.altBucket(i1, fp)
+	return
+}
+
+func ceilToPowerOfTwo(n uint) uint {
+	v := uint(1)
+	for v < n {
+		v <<= 1
+	}
+	return v
+}
+
+func (c *Cuckoo) putInBucket(bucket uint, fp uint8) (bool, error) {
+	for slot := uint(0); slot < c.slots; slot++ {
+		got, err := c.table.get(bucket, slot)
+		if err != nil {
+			return false, err
+		}
+		if got == 0 {
+			return true, c.table.set(bucket, slot, fp)
+		}
+	}
+
+	return false, nil
+}
+
+type memCuckooTable struct {
+	lock  sync.Mutex
+	width uint
+	slots []uint8
+}
+
+func newMemCuckooTable(buckets, slots uint) *memCuckooTable {
+	return &memCuckooTable{
+		width: slots,
+		slots: make([]uint8, buckets*slots),
+	}
+}
+
+func (m *memCuckooTable) get(bucket, slot uint) (uint8, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.slots[bucket*m.width+slot], nil
+}
+
+func (m *memCuckooTable) set(bucket, slot uint, fp uint8) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.slots[bucket*m.width+slot] = fp
+	return nil
+}
+
+type redisCuckooTable struct {
+	store *redis.Redis
+	key   string
+}
+
+func newRedisCuckooTable(store *redis.Redis, key string) *redisCuckooTable {
+	return &redisCuckooTable{
+		store: store,
+		key:   key,
+	}
+}
+
+func (r *redisCuckooTable) field(bucket, slot uint) string {
+	return fmt.Sprintf("%d:%d", bucket, slot)
+}
+
+func (r *redisCuckooTable) get(bucket, slot uint) (uint8, error) {
+	val, err := r.store.Hget(r.key, r.field(bucket, slot))
+	if err == redis.Nil || len(val) == 0 {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return uint8(val[0]), nil
+}
+
+func (r *redisCuckooTable) set(bucket, slot uint, fp uint8) error {
+	if fp == 0 {
+		_, err := r.store.Hdel(r.key, r.field(bucket, slot))
+		return err
+	}
+
+	return r.store.Hset(r.key, r.field(bucket, slot), string([]byte{fp}))
+}
@@ -0,0 +1,111 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+func TestMemCuckoo(t *testing.T) {
+	filter := NewCuckoo(CuckooConfig{
+		Buckets: 256,
+	})
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, filter.Insert([]byte(strconv.Itoa(i))))
+	}
+	for i := 0; i < 100; i++ {
+		ok, err := filter.Lookup([]byte(strconv.Itoa(i)))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, err := filter.Delete([]byte("42"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = filter.Lookup([]byte("42"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = filter.Delete([]byte("never-inserted-value"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemCuckooNonPowerOfTwoBuckets(t *testing.T) {
+	// rounded up to 512, a non power-of-two count would break
+	// the self-inverse alternate bucket mapping
+	filter := NewCuckoo(CuckooConfig{
+		Buckets: 300,
+	})
+
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, filter.Insert([]byte(strconv.Itoa(i))))
+	}
+	for i := 0; i < 1000; i++ {
+		ok, err := filter.Lookup([]byte(strconv.Itoa(i)))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestMemCuckooDefaults(t *testing.T) {
+	filter := NewCuckoo(CuckooConfig{})
+
+	assert.Nil(t, filter.Insert([]byte("hello")))
+	ok, err := filter.Lookup([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestCeilToPowerOfTwo(t *testing.T) {
+	assert.Equal(t, uint(1), ceilToPowerOfTwo(0))
+	assert.Equal(t, uint(1), ceilToPowerOfTwo(1))
+	assert.Equal(t, uint(2), ceilToPowerOfTwo(2))
+	assert.Equal(t, uint(4), ceilToPowerOfTwo(3))
+	assert.Equal(t, uint(512), ceilToPowerOfTwo(300))
+	assert.Equal(t, uint(1024), ceilToPowerOfTwo(1024))
+}
+
+func TestMemCuckooFull(t *testing.T) {
+	filter := NewCuckoo(CuckooConfig{
+		Buckets: 1,
+		Slots:   1,
+	})
+
+	var full bool
+	for i := 0; i < 10; i++ {
+		if err := filter.Insert([]byte(strconv.Itoa(i))); err != nil {
+			assert.Equal(t, ErrCuckooFull, err)
+			full = true
+			break
+		}
+	}
+	assert.True(t, full)
+}
+
+func TestRedisCuckoo(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	filter := NewCuckoo(CuckooConfig{
+		Store:   store,
+		Key:     "test_cuckoo",
+		Buckets: 256,
+	})
+
+	assert.Nil(t, filter.Insert([]byte("hello")))
+	ok, err := filter.Lookup([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = filter.Delete([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = filter.Lookup([]byte("hello"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
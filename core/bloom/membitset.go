@@ -0,0 +1,50 @@
+package bloom
+
+import "sync"
+
+const wordSize = 64
+
+type memBitSet struct {
+	lock  sync.Mutex
+	bits  uint
+	words []uint64
+}
+
+func newMemBitSet(bits uint) *memBitSet {
+	return &memBitSet{
+		bits:  bits,
+		words: make([]uint64, (bits+wordSize-1)/wordSize),
+	}
+}
+
+func (m *memBitSet) check(offsets []uint) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, offset := range offsets {
+		if offset >= m.bits {
+			return false, ErrTooLargeOffset
+		}
+
+		if m.words[offset/wordSize]&(1<<(offset%wordSize)) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (m *memBitSet) set(offsets []uint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, offset := range offsets {
+		if offset >= m.bits {
+			return ErrTooLargeOffset
+		}
+
+		m.words[offset/wordSize] |= 1 << (offset % wordSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemBitSet(t *testing.T) {
+	bitSet := newMemBitSet(1024)
+	isSetBefore, err := bitSet.check([]uint{0})
+	assert.Nil(t, err)
+	assert.False(t, isSetBefore)
+	assert.Nil(t, bitSet.set([]uint{512}))
+	isSetAfter, err := bitSet.check([]uint{512})
+	assert.Nil(t, err)
+	assert.True(t, isSetAfter)
+	_, err = bitSet.check([]uint{1024})
+	assert.Equal(t, ErrTooLargeOffset, err)
+	assert.Equal(t, ErrTooLargeOffset, bitSet.set([]uint{1024}))
+}
+
+func TestMemoryFilter(t *testing.T) {
+	filter := NewWithConfig(BloomConfig{
+		Bits: 1024,
+		Maps: 10,
+	})
+	assert.Nil(t, filter.Add([]byte("hello")))
+	assert.Nil(t, filter.Add([]byte("world")))
+	ok, err := filter.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = filter.Exists([]byte("none"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, filter.Add([]byte(strconv.Itoa(i))))
+	}
+	for i := 0; i < 100; i++ {
+		ok, err := filter.Exists([]byte(strconv.Itoa(i)))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+}
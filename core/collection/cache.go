@@ -8,6 +8,7 @@ import (
 
 	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/mathx"
+	"github.com/tal-tech/go-zero/core/metric"
 	"github.com/tal-tech/go-zero/core/syncx"
 )
 
@@ -20,7 +21,17 @@ const (
 	expiryDeviation = 0.05
 )
 
-var emptyLruCache = emptyLru{}
+var (
+	emptyLruCache = emptyLru{}
+
+	metricCacheOpTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "memory_cache",
+		Subsystem: "ops",
+		Name:      "total",
+		Help:      "in-memory cache operations count.",
+		Labels:    []string{"name", "op"},
+	})
+)
 
 type (
 	// CacheOption defines the method to customize a Cache.
@@ -164,10 +175,22 @@ func (c *Cache) doGet(key string) (interface{}, bool) {
 	return value, ok
 }
 
+// Stats returns a snapshot of the cumulative cache counters,
+// unlike the periodic stat log these don't reset.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&c.stats.hits),
+		Misses: atomic.LoadUint64(&c.stats.misses),
+		Evicts: atomic.LoadUint64(&c.stats.evicts),
+		Size:   c.size(),
+	}
+}
+
 func (c *Cache) onEvict(key string) {
 	// already locked
 	delete(c.data, key)
 	c.timingWheel.RemoveTimer(key)
+	c.stats.IncrementEvict()
 }
 
 func (c *Cache) size() int {
@@ -259,10 +282,21 @@ func (klru *keyLru) removeElement(e *list.Element) {
 	klru.onEvict(key)
 }
 
+// CacheStats is a snapshot of the cumulative counters of a Cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Evicts uint64
+	Size   int
+}
+
 type cacheStat struct {
 	name         string
 	hit          uint64
 	miss         uint64
+	hits         uint64
+	misses       uint64
+	evicts       uint64
 	sizeCallback func() int
 }
 
@@ -275,12 +309,21 @@ func newCacheStat(name string, sizeCallback func() int) *cacheStat {
 	return st
 }
 
+func (cs *cacheStat) IncrementEvict() {
+	atomic.AddUint64(&cs.evicts, 1)
+	metricCacheOpTotal.Inc(cs.name, "evict")
+}
+
 func (cs *cacheStat) IncrementHit() {
 	atomic.AddUint64(&cs.hit, 1)
+	atomic.AddUint64(&cs.hits, 1)
+	metricCacheOpTotal.Inc(cs.name, "hit")
 }
 
 func (cs *cacheStat) IncrementMiss() {
 	atomic.AddUint64(&cs.miss, 1)
+	atomic.AddUint64(&cs.misses, 1)
+	metricCacheOpTotal.Inc(cs.name, "miss")
 }
 
 func (cs *cacheStat) statLoop() {
@@ -161,6 +161,26 @@ func TestCacheWithLruEvicted(t *testing.T) {
 	assert.Equal(t, "second element", value)
 }
 
+func TestCacheStats(t *testing.T) {
+	cache, err := NewCache(time.Minute, WithName("any"), WithLimit(2))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	cache.Set("third", "third element")
+
+	_, ok := cache.Get("second")
+	assert.True(t, ok)
+	_, ok = cache.Get("first")
+	assert.False(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Evicts)
+	assert.Equal(t, 2, stats.Size)
+}
+
 func BenchmarkCache(b *testing.B) {
 	cache, err := NewCache(time.Second*5, WithLimit(100000))
 	if err != nil {
@@ -0,0 +1,22 @@
+package collection
+
+import "github.com/tal-tech/go-zero/core/hash"
+
+type (
+	// ConsistentHash is an alias of hash.ConsistentHash,
+	// exported here for discoverability along with the other selectors.
+	ConsistentHash = hash.ConsistentHash
+
+	// Func is an alias of hash.Func.
+	Func = hash.Func
+)
+
+// NewConsistentHash returns a ConsistentHash with default hash function and replicas.
+func NewConsistentHash() *ConsistentHash {
+	return hash.NewConsistentHash()
+}
+
+// NewCustomConsistentHash returns a ConsistentHash with given replica count and hash function.
+func NewCustomConsistentHash(replicas int, fn Func) *ConsistentHash {
+	return hash.NewCustomConsistentHash(replicas, fn)
+}
@@ -0,0 +1,120 @@
+package collection
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/metric"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const (
+	defaultMaxIdle = 64
+
+	poolOpGet     = "get"
+	poolOpNew     = "new"
+	poolOpDiscard = "discard"
+)
+
+var metricPoolOpTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: "object_pool",
+	Subsystem: "ops",
+	Name:      "total",
+	Help:      "object pool operations count.",
+	Labels:    []string{"name", "op"},
+})
+
+type (
+	// PoolOption defines the method to customize a Pool.
+	PoolOption[T any] func(p *Pool[T])
+
+	pooledItem[T any] struct {
+		value    T
+		lastUsed time.Duration
+	}
+
+	// A Pool is a typed object pool with a max-idle cap, an optional
+	// max-lifetime for idle objects, and hit/miss metrics.
+	// The difference between sync.Pool is that idle objects are kept
+	// until they age out instead of being dropped on GC,
+	// and pool efficiency is observable.
+	Pool[T any] struct {
+		name        string
+		newFn       func() T
+		maxIdle     int
+		maxLifetime time.Duration
+		lock        sync.Mutex
+		idle        []pooledItem[T]
+	}
+)
+
+// NewPool returns a Pool keeping at most maxIdle idle objects,
+// newFn creates an object on pool misses.
+func NewPool[T any](name string, newFn func() T, opts ...PoolOption[T]) *Pool[T] {
+	p := &Pool[T]{
+		name:    name,
+		newFn:   newFn,
+		maxIdle: defaultMaxIdle,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithMaxIdle customizes a Pool with the max number of idle objects.
+func WithMaxIdle[T any](maxIdle int) PoolOption[T] {
+	return func(p *Pool[T]) {
+		if maxIdle > 0 {
+			p.maxIdle = maxIdle
+		}
+	}
+}
+
+// WithMaxLifetime customizes a Pool with the max idle lifetime,
+// objects idling longer are discarded on Get.
+func WithMaxLifetime[T any](lifetime time.Duration) PoolOption[T] {
+	return func(p *Pool[T]) {
+		p.maxLifetime = lifetime
+	}
+}
+
+// Get returns an idle object, or creates a new one on pool misses.
+func (p *Pool[T]) Get() T {
+	metricPoolOpTotal.Inc(p.name, poolOpGet)
+
+	p.lock.Lock()
+	for len(p.idle) > 0 {
+		last := len(p.idle) - 1
+		item := p.idle[last]
+		p.idle = p.idle[:last]
+		if p.maxLifetime > 0 && item.lastUsed+p.maxLifetime < timex.Now() {
+			metricPoolOpTotal.Inc(p.name, poolOpDiscard)
+			continue
+		}
+
+		p.lock.Unlock()
+		return item.value
+	}
+	p.lock.Unlock()
+
+	metricPoolOpTotal.Inc(p.name, poolOpNew)
+	return p.newFn()
+}
+
+// Put puts an object back, discards it when the pool is full.
+func (p *Pool[T]) Put(value T) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.idle) >= p.maxIdle {
+		metricPoolOpTotal.Inc(p.name, poolOpDiscard)
+		return
+	}
+
+	p.idle = append(p.idle, pooledItem[T]{
+		value:    value,
+		lastUsed: timex.Now(),
+	})
+}
@@ -0,0 +1,56 @@
+package collection
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolReuse(t *testing.T) {
+	var news int
+	pool := NewPool("buffers", func() *bytes.Buffer {
+		news++
+		return new(bytes.Buffer)
+	})
+
+	buf := pool.Get()
+	buf.WriteString("hello")
+	pool.Put(buf)
+	again := pool.Get()
+	assert.Equal(t, buf, again)
+	assert.Equal(t, 1, news)
+}
+
+func TestPoolMaxIdle(t *testing.T) {
+	pool := NewPool("ints", func() int {
+		return 0
+	}, WithMaxIdle[int](2))
+
+	pool.Put(1)
+	pool.Put(2)
+	pool.Put(3)
+	assert.Equal(t, 2, len(pool.idle))
+}
+
+func TestPoolMaxLifetime(t *testing.T) {
+	var news int
+	pool := NewPool("short", func() int {
+		news++
+		return news
+	}, WithMaxLifetime[int](time.Millisecond))
+
+	pool.Put(100)
+	time.Sleep(time.Millisecond * 10)
+	// the idle object aged out, a fresh one is created
+	assert.Equal(t, 1, pool.Get())
+	assert.Equal(t, 0, len(pool.idle))
+}
+
+func TestPoolMiss(t *testing.T) {
+	pool := NewPool("misses", func() string {
+		return "created"
+	})
+	assert.Equal(t, "created", pool.Get())
+}
@@ -27,7 +27,7 @@ type (
 		execute       Execute
 		setChannel    chan timingEntry
 		moveChannel   chan baseEntry
-		removeChannel chan interface{}
+		removeChannel chan removeEntry
 		drainChannel  chan func(key, value interface{})
 		stopChannel   chan lang.PlaceholderType
 	}
@@ -38,6 +38,7 @@ type (
 		circle  int
 		diff    int
 		removed bool
+		expiry  time.Duration
 	}
 
 	baseEntry struct {
@@ -50,6 +51,11 @@ type (
 		item *timingEntry
 	}
 
+	removeEntry struct {
+		key  interface{}
+		resp chan time.Duration
+	}
+
 	timingTask struct {
 		key   interface{}
 		value interface{}
@@ -77,7 +83,7 @@ func newTimingWheelWithClock(interval time.Duration, numSlots int, execute Execu
 		numSlots:      numSlots,
 		setChannel:    make(chan timingEntry),
 		moveChannel:   make(chan baseEntry),
-		removeChannel: make(chan interface{}),
+		removeChannel: make(chan removeEntry),
 		drainChannel:  make(chan func(key, value interface{})),
 		stopChannel:   make(chan lang.PlaceholderType),
 	}
@@ -105,13 +111,21 @@ func (tw *TimingWheel) MoveTimer(key interface{}, delay time.Duration) {
 	}
 }
 
-// RemoveTimer removes the task with the given key.
-func (tw *TimingWheel) RemoveTimer(key interface{}) {
+// RemoveTimer removes the task with the given key,
+// and returns how much time was left before the task would have fired,
+// zero if no task with the given key is scheduled.
+func (tw *TimingWheel) RemoveTimer(key interface{}) time.Duration {
 	if key == nil {
-		return
+		return 0
+	}
+
+	resp := make(chan time.Duration)
+	tw.removeChannel <- removeEntry{
+		key:  key,
+		resp: resp,
 	}
 
-	tw.removeChannel <- key
+	return <-resp
 }
 
 // SetTimer sets the task value with the given key to the delay.
@@ -172,6 +186,7 @@ func (tw *TimingWheel) moveTask(task baseEntry) {
 	}
 
 	timer := val.(*positionEntry)
+	timer.item.expiry = timex.Now() + task.delay
 	if task.delay < tw.interval {
 		threading.GoSafe(func() {
 			tw.execute(timer.item.key, timer.item.value)
@@ -192,6 +207,7 @@ func (tw *TimingWheel) moveTask(task baseEntry) {
 		newItem := &timingEntry{
 			baseEntry: task,
 			value:     timer.item.value,
+			expiry:    timer.item.expiry,
 		}
 		tw.slots[pos].PushBack(newItem)
 		tw.setTimerPosition(pos, newItem)
@@ -204,15 +220,21 @@ func (tw *TimingWheel) onTick() {
 	tw.scanAndRunTasks(l)
 }
 
-func (tw *TimingWheel) removeTask(key interface{}) {
-	val, ok := tw.timers.Get(key)
+func (tw *TimingWheel) removeTask(task removeEntry) {
+	defer close(task.resp)
+
+	val, ok := tw.timers.Get(task.key)
 	if !ok {
 		return
 	}
 
 	timer := val.(*positionEntry)
 	timer.item.removed = true
-	tw.timers.Del(key)
+	tw.timers.Del(task.key)
+
+	if remaining := timer.item.expiry - timex.Now(); remaining > 0 {
+		task.resp <- remaining
+	}
 }
 
 func (tw *TimingWheel) run() {
@@ -293,6 +315,7 @@ func (tw *TimingWheel) setTask(task *timingEntry) {
 	if task.delay < tw.interval {
 		task.delay = tw.interval
 	}
+	task.expiry = timex.Now() + task.delay
 
 	if val, ok := tw.timers.Get(task.key); ok {
 		entry := val.(*positionEntry)
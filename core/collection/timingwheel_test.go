@@ -102,6 +102,19 @@ func TestTimingWheel_SetTimerWrongDelay(t *testing.T) {
 	})
 }
 
+func TestTimingWheel_RemoveTimerReturnsRemaining(t *testing.T) {
+	ticker := timex.NewFakeTicker()
+	tw, _ := newTimingWheelWithClock(testStep, 10, func(k, v interface{}) {}, ticker)
+	defer tw.Stop()
+
+	tw.SetTimer("any", 3, testStep*5)
+	remaining := tw.RemoveTimer("any")
+	assert.True(t, remaining > 0)
+	assert.True(t, remaining <= testStep*5)
+	assert.Equal(t, time.Duration(0), tw.RemoveTimer("none"))
+	assert.Equal(t, time.Duration(0), tw.RemoveTimer(nil))
+}
+
 func TestTimingWheel_MoveTimer(t *testing.T) {
 	run := syncx.NewAtomicBool()
 	ticker := timex.NewFakeTicker()
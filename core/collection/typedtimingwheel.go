@@ -0,0 +1,43 @@
+package collection
+
+import "time"
+
+// A TypedTimingWheel is a typed wrapper around TimingWheel,
+// so the execute callback gets typed key and value without assertions.
+type TypedTimingWheel[K comparable, V any] struct {
+	tw *TimingWheel
+}
+
+// NewTypedTimingWheel returns a TypedTimingWheel.
+func NewTypedTimingWheel[K comparable, V any](interval time.Duration, numSlots int,
+	execute func(key K, value V)) (*TypedTimingWheel[K, V], error) {
+	tw, err := NewTimingWheel(interval, numSlots, func(k, v interface{}) {
+		execute(k.(K), v.(V))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedTimingWheel[K, V]{tw: tw}, nil
+}
+
+// MoveTimer moves the task with the given key to the given delay.
+func (ttw *TypedTimingWheel[K, V]) MoveTimer(key K, delay time.Duration) {
+	ttw.tw.MoveTimer(key, delay)
+}
+
+// RemoveTimer removes the task with the given key,
+// and returns how much time was left before the task would have fired.
+func (ttw *TypedTimingWheel[K, V]) RemoveTimer(key K) time.Duration {
+	return ttw.tw.RemoveTimer(key)
+}
+
+// SetTimer sets the task value with the given key to the delay.
+func (ttw *TypedTimingWheel[K, V]) SetTimer(key K, value V, delay time.Duration) {
+	ttw.tw.SetTimer(key, value, delay)
+}
+
+// Stop stops ttw.
+func (ttw *TypedTimingWheel[K, V]) Stop() {
+	ttw.tw.Stop()
+}
@@ -0,0 +1,42 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedTimingWheel(t *testing.T) {
+	done := make(chan struct{})
+	ttw, err := NewTypedTimingWheel[string, int](time.Millisecond*10, 10, func(key string, value int) {
+		assert.Equal(t, "any", key)
+		assert.Equal(t, 3, value)
+		close(done)
+	})
+	assert.Nil(t, err)
+	defer ttw.Stop()
+
+	ttw.SetTimer("any", 3, time.Millisecond*10)
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout waiting for execution")
+	}
+}
+
+func TestTypedTimingWheelRemove(t *testing.T) {
+	ttw, err := NewTypedTimingWheel[string, int](testStep, 10, func(key string, value int) {
+	})
+	assert.Nil(t, err)
+	defer ttw.Stop()
+
+	ttw.SetTimer("any", 3, testStep*5)
+	assert.True(t, ttw.RemoveTimer("any") > 0)
+}
+
+func TestTypedTimingWheelError(t *testing.T) {
+	_, err := NewTypedTimingWheel[string, int](0, 10, func(key string, value int) {
+	})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,77 @@
+package collection
+
+import (
+	"math/rand"
+	"sync"
+)
+
+type (
+	// A WeightedRandom picks nodes randomly, proportional to their weights.
+	WeightedRandom struct {
+		lock  sync.RWMutex
+		nodes []weightedNode
+		total int
+	}
+
+	weightedNode struct {
+		node   interface{}
+		weight int
+	}
+)
+
+// NewWeightedRandom returns a WeightedRandom.
+func NewWeightedRandom() *WeightedRandom {
+	return new(WeightedRandom)
+}
+
+// Add adds node with given weight into wr, non-positive weights are ignored.
+func (wr *WeightedRandom) Add(node interface{}, weight int) {
+	if weight <= 0 {
+		return
+	}
+
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+
+	wr.nodes = append(wr.nodes, weightedNode{
+		node:   node,
+		weight: weight,
+	})
+	wr.total += weight
+}
+
+// Pick picks one node randomly, the chance is proportional to its weight.
+// Returns false if no nodes added.
+func (wr *WeightedRandom) Pick() (interface{}, bool) {
+	wr.lock.RLock()
+	defer wr.lock.RUnlock()
+
+	if wr.total <= 0 {
+		return nil, false
+	}
+
+	point := rand.Intn(wr.total)
+	for _, each := range wr.nodes {
+		point -= each.weight
+		if point < 0 {
+			return each.node, true
+		}
+	}
+
+	// not reachable
+	return nil, false
+}
+
+// Remove removes the given node from wr.
+func (wr *WeightedRandom) Remove(node interface{}) {
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+
+	for i, each := range wr.nodes {
+		if each.node == node {
+			wr.total -= each.weight
+			wr.nodes = append(wr.nodes[:i], wr.nodes[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedRandomEmpty(t *testing.T) {
+	wr := NewWeightedRandom()
+	_, ok := wr.Pick()
+	assert.False(t, ok)
+
+	wr.Add("ignored", 0)
+	_, ok = wr.Pick()
+	assert.False(t, ok)
+}
+
+func TestWeightedRandom(t *testing.T) {
+	wr := NewWeightedRandom()
+	wr.Add("a", 1)
+	wr.Add("b", 9)
+
+	counts := make(map[interface{}]int)
+	for i := 0; i < 10000; i++ {
+		node, ok := wr.Pick()
+		assert.True(t, ok)
+		counts[node]++
+	}
+
+	assert.True(t, counts["b"] > counts["a"])
+	assert.True(t, counts["a"] > 0)
+}
+
+func TestWeightedRandomRemove(t *testing.T) {
+	wr := NewWeightedRandom()
+	wr.Add("a", 1)
+	wr.Add("b", 2)
+	wr.Remove("b")
+	wr.Remove("none")
+
+	for i := 0; i < 100; i++ {
+		node, ok := wr.Pick()
+		assert.True(t, ok)
+		assert.Equal(t, "a", node)
+	}
+}
+
+func TestCollectionConsistentHash(t *testing.T) {
+	ch := NewConsistentHash()
+	ch.AddWithWeight("node1", 50)
+	ch.Add("node2")
+
+	node, ok := ch.Get("key")
+	assert.True(t, ok)
+	assert.Contains(t, []interface{}{"node1", "node2"}, node)
+
+	ch.Remove("node1")
+	node, ok = ch.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "node2", node)
+}
@@ -0,0 +1,199 @@
+package discov
+
+import (
+	"fmt"
+
+	"github.com/tal-tech/go-zero/core/discov/internal"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/proc"
+	"github.com/tal-tech/go-zero/core/syncx"
+	"github.com/tal-tech/go-zero/core/threading"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type (
+	// ElectorOption defines the method to customize an Elector.
+	ElectorOption func(e *Elector)
+
+	// An Elector campaigns for leadership on the given etcd key.
+	// Each candidate publishes itself under the key with a lease,
+	// the candidate with the oldest entry leads, and leadership
+	// fails over when the leader crashes and its lease expires.
+	Elector struct {
+		endpoints  []string
+		key        string
+		value      string
+		fullKey    string
+		lease      clientv3.LeaseID
+		leading    *syncx.AtomicBool
+		onElected  []func()
+		onResigned []func()
+		quit       *syncx.DoneChan
+	}
+)
+
+// NewElector returns an Elector campaigning on key.
+// endpoints is the hosts of the etcd cluster.
+func NewElector(endpoints []string, key string, opts ...ElectorOption) *Elector {
+	elector := &Elector{
+		endpoints: endpoints,
+		key:       key,
+		leading:   syncx.NewAtomicBool(),
+		quit:      syncx.NewDoneChan(),
+	}
+
+	for _, opt := range opts {
+		opt(elector)
+	}
+
+	return elector
+}
+
+// WithElectorValue customizes an Elector with the value to publish
+// under the campaign key, like the instance address.
+func WithElectorValue(value string) ElectorOption {
+	return func(e *Elector) {
+		e.value = value
+	}
+}
+
+// IsLeader reports whether this instance currently leads.
+func (e *Elector) IsLeader() bool {
+	return e.leading.True()
+}
+
+// OnElected registers fn to be called when this instance becomes the leader.
+// Call it before Start.
+func (e *Elector) OnElected(fn func()) {
+	e.onElected = append(e.onElected, fn)
+}
+
+// OnResigned registers fn to be called when this instance loses leadership.
+// Call it before Start.
+func (e *Elector) OnResigned(fn func()) {
+	e.onResigned = append(e.onResigned, fn)
+}
+
+// Start starts campaigning, returning once the campaign is registered.
+// Leadership changes are reported through the registered callbacks.
+func (e *Elector) Start() error {
+	cli, err := internal.GetRegistry().GetConn(e.endpoints)
+	if err != nil {
+		return err
+	}
+
+	proc.AddWrapUpListener(func() {
+		e.Stop()
+	})
+
+	return e.campaign(cli)
+}
+
+// Stop quits the campaign, resigning leadership if held.
+func (e *Elector) Stop() {
+	e.quit.Close()
+}
+
+func (e *Elector) campaign(cli internal.EtcdClient) error {
+	if err := e.register(cli); err != nil {
+		return err
+	}
+
+	e.evaluate(cli)
+
+	return e.watchAsync(cli)
+}
+
+func (e *Elector) evaluate(cli internal.EtcdClient) {
+	resp, err := cli.Get(cli.Ctx(), e.prefix(), clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByCreateRevision, clientv3.SortAscend),
+		clientv3.WithLimit(1))
+	if err != nil {
+		logx.Error(err)
+		return
+	}
+
+	if len(resp.Kvs) == 0 {
+		e.setLeading(false)
+		return
+	}
+
+	e.setLeading(string(resp.Kvs[0].Key) == e.fullKey)
+}
+
+func (e *Elector) prefix() string {
+	return fmt.Sprintf("%s%c", e.key, internal.Delimiter)
+}
+
+func (e *Elector) register(cli internal.EtcdClient) error {
+	resp, err := cli.Grant(cli.Ctx(), TimeToLive)
+	if err != nil {
+		return err
+	}
+
+	e.lease = resp.ID
+	e.fullKey = makeEtcdKey(e.key, int64(e.lease))
+	_, err = cli.Put(cli.Ctx(), e.fullKey, e.value, clientv3.WithLease(e.lease))
+
+	return err
+}
+
+func (e *Elector) resign(cli internal.EtcdClient) {
+	e.setLeading(false)
+	if _, err := cli.Revoke(cli.Ctx(), e.lease); err != nil {
+		logx.Error(err)
+	}
+}
+
+func (e *Elector) setLeading(leading bool) {
+	if !e.leading.CompareAndSwap(!leading, leading) {
+		return
+	}
+
+	if leading {
+		for _, fn := range e.onElected {
+			fn()
+		}
+	} else {
+		for _, fn := range e.onResigned {
+			fn()
+		}
+	}
+}
+
+func (e *Elector) watchAsync(cli internal.EtcdClient) error {
+	aliveChan, err := cli.KeepAlive(cli.Ctx(), e.lease)
+	if err != nil {
+		return err
+	}
+
+	watchChan := cli.Watch(clientv3.WithRequireLeader(cli.Ctx()), e.prefix(), clientv3.WithPrefix())
+	threading.GoSafe(func() {
+		for {
+			select {
+			case _, ok := <-aliveChan:
+				if !ok {
+					e.setLeading(false)
+					if err := e.campaign(cli); err != nil {
+						logx.Errorf("campaign: %s", err.Error())
+					}
+					return
+				}
+			case resp, ok := <-watchChan:
+				if !ok || resp.Canceled {
+					e.setLeading(false)
+					if err := e.campaign(cli); err != nil {
+						logx.Errorf("campaign: %s", err.Error())
+					}
+					return
+				}
+				e.evaluate(cli)
+			case <-e.quit.Done():
+				e.resign(cli)
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package discov
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/discov/internal"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestElector_register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const id = 1
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Grant(gomock.Any(), timeToLive).Return(&clientv3.LeaseGrantResponse{
+		ID: id,
+	}, nil)
+	cli.EXPECT().Put(gomock.Any(), makeEtcdKey("thekey", id), "thevalue", gomock.Any())
+	elector := NewElector(nil, "thekey", WithElectorValue("thevalue"))
+	assert.Nil(t, elector.register(cli))
+	assert.Equal(t, makeEtcdKey("thekey", id), elector.fullKey)
+}
+
+func TestElector_registerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Grant(gomock.Any(), timeToLive).Return(nil, errors.New("error"))
+	elector := NewElector(nil, "thekey")
+	assert.NotNil(t, elector.register(cli))
+}
+
+func TestElector_evaluateElected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Get(gomock.Any(), "thekey/", gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{
+				Key: []byte(makeEtcdKey("thekey", 1)),
+			}},
+		}, nil)
+
+	elector := NewElector(nil, "thekey")
+	elector.fullKey = makeEtcdKey("thekey", 1)
+	var elected, resigned int
+	elector.OnElected(func() {
+		elected++
+	})
+	elector.OnResigned(func() {
+		resigned++
+	})
+
+	elector.evaluate(cli)
+	assert.True(t, elector.IsLeader())
+	assert.Equal(t, 1, elected)
+	assert.Equal(t, 0, resigned)
+}
+
+func TestElector_evaluateNotElected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Get(gomock.Any(), "thekey/", gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{
+				Key: []byte(makeEtcdKey("thekey", 2)),
+			}},
+		}, nil)
+
+	elector := NewElector(nil, "thekey")
+	elector.fullKey = makeEtcdKey("thekey", 1)
+	elector.evaluate(cli)
+	assert.False(t, elector.IsLeader())
+}
+
+func TestElector_evaluateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Get(gomock.Any(), "thekey/", gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		nil, errors.New("error"))
+
+	elector := NewElector(nil, "thekey")
+	elector.evaluate(cli)
+	assert.False(t, elector.IsLeader())
+}
+
+func TestElector_resign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const id clientv3.LeaseID = 1
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().Revoke(gomock.Any(), id)
+
+	elector := NewElector(nil, "thekey")
+	elector.lease = id
+	elector.leading.Set(true)
+	var resigned int
+	elector.OnResigned(func() {
+		resigned++
+	})
+
+	elector.resign(cli)
+	assert.False(t, elector.IsLeader())
+	assert.Equal(t, 1, resigned)
+}
+
+func TestElector_setLeadingIdempotent(t *testing.T) {
+	elector := NewElector(nil, "thekey")
+	var elected int
+	elector.OnElected(func() {
+		elected++
+	})
+
+	elector.setLeading(true)
+	elector.setLeading(true)
+	assert.Equal(t, 1, elected)
+}
+
+func TestElector_watchAsyncError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const id clientv3.LeaseID = 1
+	cli := internal.NewMockEtcdClient(ctrl)
+	restore := setMockClient(cli)
+	defer restore()
+	cli.EXPECT().Ctx().AnyTimes()
+	cli.EXPECT().KeepAlive(gomock.Any(), id).Return(nil, errors.New("error"))
+
+	elector := NewElector(nil, "thekey")
+	elector.lease = id
+	assert.NotNil(t, elector.watchAsync(cli))
+}
@@ -0,0 +1,58 @@
+package errorx
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+)
+
+type codeError struct {
+	err        error
+	httpStatus int
+	grpcCode   codes.Code
+}
+
+// WithCode annotates err with the status codes both transports should
+// render it with, the rest error handler and the zrpc error mapper
+// extract them with HTTPStatus and GRPCCode.
+func WithCode(err error, httpStatus int, grpcCode codes.Code) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codeError{
+		err:        err,
+		httpStatus: httpStatus,
+		grpcCode:   grpcCode,
+	}
+}
+
+// GRPCCode returns the grpc code annotated on err with WithCode,
+// ok false when err isn't annotated.
+func GRPCCode(err error) (codes.Code, bool) {
+	var ce *codeError
+	if errors.As(err, &ce) {
+		return ce.grpcCode, true
+	}
+
+	return codes.Unknown, false
+}
+
+// HTTPStatus returns the http status annotated on err with WithCode,
+// ok false when err isn't annotated.
+func HTTPStatus(err error) (int, bool) {
+	var ce *codeError
+	if errors.As(err, &ce) {
+		return ce.httpStatus, true
+	}
+
+	return 0, false
+}
+
+func (e *codeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codeError) Unwrap() error {
+	return e.err
+}
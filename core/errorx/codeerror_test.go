@@ -0,0 +1,45 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWithCode(t *testing.T) {
+	base := errors.New("not found")
+	err := WithCode(base, http.StatusNotFound, codes.NotFound)
+	assert.Equal(t, "not found", err.Error())
+	assert.True(t, errors.Is(err, base))
+
+	status, ok := HTTPStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, status)
+	code, ok := GRPCCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, code)
+}
+
+func TestWithCodeNil(t *testing.T) {
+	assert.Nil(t, WithCode(nil, http.StatusNotFound, codes.NotFound))
+}
+
+func TestWithCodeWrapped(t *testing.T) {
+	err := fmt.Errorf("handler: %w",
+		WithCode(errors.New("denied"), http.StatusForbidden, codes.PermissionDenied))
+	status, ok := HTTPStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestCodesNotAnnotated(t *testing.T) {
+	err := errors.New("plain")
+	_, ok := HTTPStatus(err)
+	assert.False(t, ok)
+	_, ok = GRPCCode(err)
+	assert.False(t, ok)
+}
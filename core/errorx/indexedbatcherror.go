@@ -0,0 +1,158 @@
+package errorx
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+type (
+	// An IndexedBatchError aggregates per-item errors keyed by index,
+	// safe for concurrent use, unlike BatchError the failing items
+	// can be told apart from the successful ones.
+	IndexedBatchError struct {
+		lock sync.Mutex
+		errs map[int]error
+	}
+
+	// A Group runs tasks with bounded concurrency and collects their
+	// indexed errors, a fan-out with partial results keeps the successes
+	// and reports which indexes failed.
+	Group struct {
+		limit chan lang.PlaceholderType
+		wg    sync.WaitGroup
+		errs  IndexedBatchError
+	}
+
+	indexedError struct {
+		index int
+		err   error
+	}
+
+	indexedErrorArray []indexedError
+)
+
+// NewGroup returns a Group running at most concurrency tasks in parallel.
+func NewGroup(concurrency int) *Group {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &Group{
+		limit: make(chan lang.PlaceholderType, concurrency),
+	}
+}
+
+// Run schedules fn as the task with index i,
+// blocks while concurrency tasks are already running.
+func (g *Group) Run(i int, fn func() error) {
+	g.wg.Add(1)
+	g.limit <- lang.Placeholder
+
+	go func() {
+		defer func() {
+			<-g.limit
+			g.wg.Done()
+		}()
+
+		g.errs.Add(i, runTask(fn))
+	}()
+}
+
+// Wait waits for the scheduled tasks to finish,
+// and returns their aggregated indexed errors, nil when all succeeded.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	return g.errs.Err()
+}
+
+// Errors returns the errors of the finished tasks keyed by index,
+// call it after Wait.
+func (g *Group) Errors() map[int]error {
+	return g.errs.Errors()
+}
+
+// Add records err for index i, nil errors are ignored.
+func (be *IndexedBatchError) Add(i int, err error) {
+	if err == nil {
+		return
+	}
+
+	be.lock.Lock()
+	defer be.lock.Unlock()
+	if be.errs == nil {
+		be.errs = make(map[int]error)
+	}
+	be.errs[i] = err
+}
+
+// Err returns an error that represents all recorded errors,
+// nil when none were recorded.
+func (be *IndexedBatchError) Err() error {
+	be.lock.Lock()
+	defer be.lock.Unlock()
+
+	if len(be.errs) == 0 {
+		return nil
+	}
+
+	errs := make(indexedErrorArray, 0, len(be.errs))
+	for index, err := range be.errs {
+		errs = append(errs, indexedError{
+			index: index,
+			err:   err,
+		})
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].index < errs[j].index
+	})
+
+	return errs
+}
+
+// Errors returns a copy of the recorded errors keyed by index.
+func (be *IndexedBatchError) Errors() map[int]error {
+	be.lock.Lock()
+	defer be.lock.Unlock()
+
+	errs := make(map[int]error, len(be.errs))
+	for index, err := range be.errs {
+		errs[index] = err
+	}
+
+	return errs
+}
+
+// NotNil checks if any error inside.
+func (be *IndexedBatchError) NotNil() bool {
+	be.lock.Lock()
+	defer be.lock.Unlock()
+
+	return len(be.errs) > 0
+}
+
+func (ea indexedErrorArray) Error() string {
+	var buf bytes.Buffer
+
+	for i := range ea {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "index %d: %s", ea[i].index, ea[i].err.Error())
+	}
+
+	return buf.String()
+}
+
+func runTask(fn func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("task panic: %v", p)
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,91 @@
+package errorx
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedBatchErrorNil(t *testing.T) {
+	var batch IndexedBatchError
+	assert.Nil(t, batch.Err())
+	assert.False(t, batch.NotNil())
+	batch.Add(3, nil)
+	assert.Nil(t, batch.Err())
+}
+
+func TestIndexedBatchErrorIndexed(t *testing.T) {
+	var batch IndexedBatchError
+	batch.Add(5, errors.New("five"))
+	batch.Add(1, errors.New("one"))
+	assert.True(t, batch.NotNil())
+	assert.Equal(t, "index 1: one\nindex 5: five", batch.Err().Error())
+	assert.Equal(t, 2, len(batch.Errors()))
+}
+
+func TestGroupAllSucceed(t *testing.T) {
+	group := NewGroup(4)
+	var count int32
+	for i := 0; i < 10; i++ {
+		group.Run(i, func() error {
+			atomic.AddInt32(&count, 1)
+			return nil
+		})
+	}
+	assert.Nil(t, group.Wait())
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+	assert.Empty(t, group.Errors())
+}
+
+func TestGroupPartialFailures(t *testing.T) {
+	group := NewGroup(2)
+	for i := 0; i < 5; i++ {
+		index := i
+		group.Run(index, func() error {
+			if index%2 == 1 {
+				return errors.New("odd")
+			}
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	assert.NotNil(t, err)
+	assert.Equal(t, "index 1: odd\nindex 3: odd", err.Error())
+	errs := group.Errors()
+	assert.Equal(t, 2, len(errs))
+	assert.NotNil(t, errs[1])
+	assert.Nil(t, errs[0])
+}
+
+func TestGroupPanic(t *testing.T) {
+	group := NewGroup(1)
+	group.Run(0, func() error {
+		panic("boom")
+	})
+	err := group.Wait()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "task panic: boom")
+}
+
+func TestGroupBoundedConcurrency(t *testing.T) {
+	group := NewGroup(2)
+	var running, max int32
+	for i := 0; i < 10; i++ {
+		group.Run(i, func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&max)
+				if n <= old || atomic.CompareAndSwapInt32(&max, old, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	assert.Nil(t, group.Wait())
+	assert.True(t, atomic.LoadInt32(&max) <= 2)
+}
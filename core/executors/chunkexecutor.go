@@ -10,7 +10,8 @@ type (
 
 	// A ChunkExecutor is an executor to execute tasks when either requirement meets:
 	// 1. up to given chunk size
-	// 2. flush interval elapsed
+	// 2. up to given task count, if set
+	// 3. flush interval elapsed
 	ChunkExecutor struct {
 		executor  *PeriodicalExecutor
 		container *chunkContainer
@@ -18,6 +19,7 @@ type (
 
 	chunkOptions struct {
 		chunkSize     int
+		maxTasks      int
 		flushInterval time.Duration
 	}
 )
@@ -32,6 +34,7 @@ func NewChunkExecutor(execute Execute, opts ...ChunkOption) *ChunkExecutor {
 	container := &chunkContainer{
 		execute:      execute,
 		maxChunkSize: options.chunkSize,
+		maxTasks:     options.maxTasks,
 	}
 	executor := &ChunkExecutor{
 		executor:  NewPeriodicalExecutor(options.flushInterval, container),
@@ -67,6 +70,14 @@ func WithChunkBytes(size int) ChunkOption {
 	}
 }
 
+// WithChunkTasks customizes a ChunkExecutor with the given tasks limit,
+// flushing happens on whichever of the byte size and task count limits hits first.
+func WithChunkTasks(tasks int) ChunkOption {
+	return func(options *chunkOptions) {
+		options.maxTasks = tasks
+	}
+}
+
 // WithFlushInterval customizes a ChunkExecutor with the given flush interval.
 func WithFlushInterval(duration time.Duration) ChunkOption {
 	return func(options *chunkOptions) {
@@ -86,12 +97,16 @@ type chunkContainer struct {
 	execute      Execute
 	size         int
 	maxChunkSize int
+	maxTasks     int
 }
 
 func (bc *chunkContainer) AddTask(task interface{}) bool {
 	ck := task.(chunk)
 	bc.tasks = append(bc.tasks, ck.val)
 	bc.size += ck.size
+	if bc.maxTasks > 0 && len(bc.tasks) >= bc.maxTasks {
+		return true
+	}
 	return bc.size >= bc.maxChunkSize
 }
 
@@ -78,6 +78,30 @@ func TestChunkExecutorFlush(t *testing.T) {
 	wait.Wait()
 }
 
+func TestChunkExecutorFlushTaskCount(t *testing.T) {
+	var values []int
+	var lock sync.Mutex
+
+	executor := NewChunkExecutor(func(items []interface{}) {
+		lock.Lock()
+		values = append(values, len(items))
+		lock.Unlock()
+	}, WithChunkBytes(1024), WithChunkTasks(10), WithFlushInterval(time.Minute))
+
+	// task count limit hits before the byte size limit
+	for i := 0; i < 50; i++ {
+		executor.Add(1, 1)
+		time.Sleep(time.Millisecond)
+	}
+
+	lock.Lock()
+	assert.True(t, len(values) > 0)
+	for i := 0; i < len(values); i++ {
+		assert.Equal(t, 10, values[i])
+	}
+	lock.Unlock()
+}
+
 func BenchmarkChunkExecutor(b *testing.B) {
 	b.ReportAllocs()
 
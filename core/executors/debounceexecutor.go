@@ -0,0 +1,109 @@
+package executors
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+type (
+	// DebounceOption defines the method to customize a DebounceExecutor.
+	DebounceOption func(de *DebounceExecutor)
+
+	// A DebounceExecutor coalesces rapid triggers, fn only fires after delay of
+	// quiescence. With a max wait set, a constantly-triggered executor still
+	// fires once max wait elapsed since the first pending trigger.
+	DebounceExecutor struct {
+		fn       func()
+		delay    time.Duration
+		maxWait  time.Duration
+		ctx      context.Context
+		lock     sync.Mutex
+		timer    *time.Timer
+		maxTimer *time.Timer
+	}
+)
+
+// NewDebounceExecutor returns a DebounceExecutor with given delay and fn.
+func NewDebounceExecutor(delay time.Duration, fn func(), opts ...DebounceOption) *DebounceExecutor {
+	de := &DebounceExecutor{
+		fn:    fn,
+		delay: delay,
+		ctx:   context.Background(),
+	}
+	for _, opt := range opts {
+		opt(de)
+	}
+
+	return de
+}
+
+// WithDebounceContext customizes a DebounceExecutor with given ctx,
+// a pending trigger is dropped when ctx is done.
+func WithDebounceContext(ctx context.Context) DebounceOption {
+	return func(de *DebounceExecutor) {
+		de.ctx = ctx
+	}
+}
+
+// WithDebounceMaxWait customizes a DebounceExecutor with given max wait,
+// fn fires at most maxWait after the first pending trigger even if
+// triggers keep coming.
+func WithDebounceMaxWait(maxWait time.Duration) DebounceOption {
+	return func(de *DebounceExecutor) {
+		de.maxWait = maxWait
+	}
+}
+
+// Trigger schedules fn to run after the delay, resetting the pending delay
+// if one is already scheduled. Safe to call concurrently.
+func (de *DebounceExecutor) Trigger() {
+	de.lock.Lock()
+	defer de.lock.Unlock()
+
+	if de.timer != nil {
+		de.timer.Reset(de.delay)
+		return
+	}
+
+	de.timer = time.NewTimer(de.delay)
+	timer := de.timer
+	var maxChan <-chan time.Time
+	if de.maxWait > 0 {
+		de.maxTimer = time.NewTimer(de.maxWait)
+		maxChan = de.maxTimer.C
+	}
+
+	threading.GoSafe(func() {
+		select {
+		case <-timer.C:
+			de.fire()
+		case <-maxChan:
+			de.fire()
+		case <-de.ctx.Done():
+			de.reset()
+		}
+	})
+}
+
+func (de *DebounceExecutor) fire() {
+	// reset before calling fn to ensure no triggers are missed.
+	de.reset()
+	de.fn()
+}
+
+func (de *DebounceExecutor) reset() {
+	de.lock.Lock()
+	defer de.lock.Unlock()
+
+	if de.timer != nil {
+		de.timer.Stop()
+		de.timer = nil
+	}
+	if de.maxTimer != nil {
+		de.maxTimer.Stop()
+		de.maxTimer = nil
+	}
+}
@@ -0,0 +1,55 @@
+package executors
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounceExecutor(t *testing.T) {
+	var count int32
+	de := NewDebounceExecutor(time.Millisecond*50, func() {
+		atomic.AddInt32(&count, 1)
+	})
+
+	for i := 0; i < 10; i++ {
+		de.Trigger()
+		time.Sleep(time.Millisecond * 5)
+	}
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	de.Trigger()
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestDebounceExecutorMaxWait(t *testing.T) {
+	var count int32
+	de := NewDebounceExecutor(time.Millisecond*50, func() {
+		atomic.AddInt32(&count, 1)
+	}, WithDebounceMaxWait(time.Millisecond*100))
+
+	// keep triggering faster than the delay, max wait should still fire
+	for i := 0; i < 30; i++ {
+		de.Trigger()
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.True(t, atomic.LoadInt32(&count) >= 1)
+}
+
+func TestDebounceExecutorContext(t *testing.T) {
+	var count int32
+	ctx, cancel := context.WithCancel(context.Background())
+	de := NewDebounceExecutor(time.Millisecond*50, func() {
+		atomic.AddInt32(&count, 1)
+	}, WithDebounceContext(ctx))
+
+	de.Trigger()
+	cancel()
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+}
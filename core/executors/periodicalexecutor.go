@@ -7,13 +7,19 @@ import (
 	"time"
 
 	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/proc"
 	"github.com/tal-tech/go-zero/core/syncx"
 	"github.com/tal-tech/go-zero/core/threading"
 	"github.com/tal-tech/go-zero/core/timex"
 )
 
-const idleRound = 10
+const (
+	idleRound = 10
+	// flushTimeout bounds the flush on process shutdown,
+	// it must fit in the wrap up time budget of core/proc.
+	flushTimeout = time.Second
+)
 
 type (
 	// TaskContainer interface defines a type that can be used as the underlying
@@ -57,7 +63,7 @@ func NewPeriodicalExecutor(interval time.Duration, container TaskContainer) *Per
 		},
 	}
 	proc.AddShutdownListener(func() {
-		executor.Flush()
+		executor.flushOnExit()
 	})
 
 	return executor
@@ -147,6 +153,22 @@ func (pe *PeriodicalExecutor) backgroundFlush() {
 	})
 }
 
+// flushOnExit flushes the pending tasks on process shutdown,
+// bounded by flushTimeout to not block the graceful stop.
+func (pe *PeriodicalExecutor) flushOnExit() {
+	done := make(chan lang.PlaceholderType)
+	threading.GoSafe(func() {
+		pe.Flush()
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(flushTimeout):
+		logx.Errorf("periodical executor flush timeout on shutdown after %v", flushTimeout)
+	}
+}
+
 func (pe *PeriodicalExecutor) doneExecution() {
 	pe.waitGroup.Done()
 }
@@ -140,6 +140,17 @@ func TestPeriodicalExecutor_WaitFast(t *testing.T) {
 	assert.Equal(t, total, cnt)
 }
 
+func TestPeriodicalExecutor_FlushOnExit(t *testing.T) {
+	var count int32
+	exec := NewPeriodicalExecutor(time.Minute, newContainer(time.Millisecond, func(tasks interface{}) {
+		atomic.AddInt32(&count, int32(len(tasks.([]int))))
+	}))
+	exec.Add(1)
+	exec.Add(2)
+	exec.flushOnExit()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
 func TestPeriodicalExecutor_Deadlock(t *testing.T) {
 	executor := NewBulkExecutor(func(tasks []interface{}) {
 	}, WithBulkTasks(1), WithBulkInterval(time.Millisecond))
@@ -0,0 +1,148 @@
+package executors
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const defaultPriorityTasks = 1000
+
+type (
+	// PriorityOption defines the method to customize a PriorityExecutor.
+	PriorityOption func(options *priorityOptions)
+
+	// A PriorityExecutor is an executor that executes tasks in priority order on
+	// either requirement meets:
+	// 1. up to given size of tasks
+	// 2. flush interval time elapsed
+	// Higher priorities drain first, and waiting tasks age one priority level
+	// per aging interval to prevent starvation of low-priority tasks.
+	PriorityExecutor struct {
+		executor  *PeriodicalExecutor
+		container *priorityContainer
+	}
+
+	priorityOptions struct {
+		cachedTasks   int
+		flushInterval time.Duration
+		agingInterval time.Duration
+	}
+
+	priorityTask struct {
+		val      interface{}
+		priority int
+		added    time.Duration
+	}
+)
+
+// NewPriorityExecutor returns a PriorityExecutor.
+func NewPriorityExecutor(execute Execute, opts ...PriorityOption) *PriorityExecutor {
+	options := newPriorityOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	container := &priorityContainer{
+		execute:       execute,
+		maxTasks:      options.cachedTasks,
+		agingInterval: options.agingInterval,
+	}
+	executor := &PriorityExecutor{
+		executor:  NewPeriodicalExecutor(options.flushInterval, container),
+		container: container,
+	}
+
+	return executor
+}
+
+// Add adds task with given priority into pe, higher priorities execute first.
+func (pe *PriorityExecutor) Add(task interface{}, priority int) error {
+	pe.executor.Add(priorityTask{
+		val:      task,
+		priority: priority,
+		added:    timex.Now(),
+	})
+	return nil
+}
+
+// Flush forces pe to flush and execute tasks.
+func (pe *PriorityExecutor) Flush() {
+	pe.executor.Flush()
+}
+
+// Wait waits pe to done with the task execution.
+func (pe *PriorityExecutor) Wait() {
+	pe.executor.Wait()
+}
+
+// WithPriorityTasks customizes a PriorityExecutor with given tasks limit.
+func WithPriorityTasks(tasks int) PriorityOption {
+	return func(options *priorityOptions) {
+		options.cachedTasks = tasks
+	}
+}
+
+// WithPriorityInterval customizes a PriorityExecutor with given flush interval.
+func WithPriorityInterval(duration time.Duration) PriorityOption {
+	return func(options *priorityOptions) {
+		options.flushInterval = duration
+	}
+}
+
+// WithAgingInterval customizes a PriorityExecutor with given aging interval,
+// a waiting task gains one priority level per interval elapsed.
+func WithAgingInterval(duration time.Duration) PriorityOption {
+	return func(options *priorityOptions) {
+		options.agingInterval = duration
+	}
+}
+
+func newPriorityOptions() priorityOptions {
+	return priorityOptions{
+		cachedTasks:   defaultPriorityTasks,
+		flushInterval: defaultFlushInterval,
+		agingInterval: defaultFlushInterval,
+	}
+}
+
+type priorityContainer struct {
+	tasks         []priorityTask
+	execute       Execute
+	maxTasks      int
+	agingInterval time.Duration
+}
+
+func (pc *priorityContainer) AddTask(task interface{}) bool {
+	pc.tasks = append(pc.tasks, task.(priorityTask))
+	return len(pc.tasks) >= pc.maxTasks
+}
+
+func (pc *priorityContainer) Execute(tasks interface{}) {
+	vals := tasks.([]interface{})
+	pc.execute(vals)
+}
+
+func (pc *priorityContainer) RemoveAll() interface{} {
+	tasks := pc.tasks
+	pc.tasks = nil
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	// stable to keep FIFO order within the same effective priority
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return pc.effectivePriority(tasks[i]) > pc.effectivePriority(tasks[j])
+	})
+
+	vals := make([]interface{}, len(tasks))
+	for i, task := range tasks {
+		vals[i] = task.val
+	}
+	return vals
+}
+
+func (pc *priorityContainer) effectivePriority(task priorityTask) int {
+	return task.priority + int(timex.Since(task.added)/pc.agingInterval)
+}
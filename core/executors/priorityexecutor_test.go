@@ -0,0 +1,56 @@
+package executors
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+func TestPriorityExecutor(t *testing.T) {
+	var wait sync.WaitGroup
+	wait.Add(1)
+	var got []interface{}
+	exec := NewPriorityExecutor(func(tasks []interface{}) {
+		got = tasks
+		wait.Done()
+	}, WithPriorityTasks(100), WithPriorityInterval(time.Minute))
+
+	exec.Add("low", 1)
+	exec.Add("high", 10)
+	exec.Add("mid", 5)
+	exec.Add("low2", 1)
+	exec.Flush()
+	wait.Wait()
+
+	assert.Equal(t, []interface{}{"high", "mid", "low", "low2"}, got)
+}
+
+func TestPriorityExecutorFlushOnCount(t *testing.T) {
+	var wait sync.WaitGroup
+	wait.Add(1)
+	exec := NewPriorityExecutor(func(tasks []interface{}) {
+		assert.Equal(t, 10, len(tasks))
+		wait.Done()
+	}, WithPriorityTasks(10), WithPriorityInterval(time.Minute))
+
+	for i := 0; i < 10; i++ {
+		exec.Add(i, i%3)
+	}
+	wait.Wait()
+}
+
+func TestPriorityExecutorAging(t *testing.T) {
+	pc := &priorityContainer{
+		agingInterval: time.Millisecond * 10,
+	}
+	pc.AddTask(priorityTask{val: "old-low", priority: 1, added: timex.Now()})
+	time.Sleep(time.Millisecond * 50)
+	pc.AddTask(priorityTask{val: "new-high", priority: 3, added: timex.Now()})
+
+	vals := pc.RemoveAll().([]interface{})
+	// the old low-priority task aged past the fresh high-priority one
+	assert.Equal(t, "old-low", vals[0])
+}
@@ -0,0 +1,124 @@
+package featureflag
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/tal-tech/go-zero/core/discov"
+	"github.com/tal-tech/go-zero/core/hash"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const percentBuckets = 100
+
+type (
+	// A Flag is one feature flag definition.
+	Flag struct {
+		// Name is the flag name that Enabled is called with.
+		Name string `json:"name"`
+		// Enabled turns the flag off entirely when false.
+		Enabled bool `json:"enabled"`
+		// Percentage rolls the flag out to the given percent of users,
+		// 0 means no percentage rollout, the flag is on for everyone.
+		Percentage int `json:"percentage"`
+		// Allowlist users get the flag regardless of the percentage.
+		Allowlist []string `json:"allowlist"`
+	}
+
+	// A Source provides flag definitions and notifies on changes,
+	// backed by etcd in production and faked in tests.
+	Source interface {
+		Flags() []Flag
+		AddListener(listener func())
+	}
+
+	// A Store evaluates feature flags against the current definitions,
+	// hot-reloading whenever the source changes.
+	Store struct {
+		flags atomic.Value // map[string]Flag
+	}
+
+	etcdSource struct {
+		sub *discov.Subscriber
+	}
+)
+
+// NewStore returns a Store fed by source.
+func NewStore(source Source) *Store {
+	s := new(Store)
+	s.load(source.Flags())
+	source.AddListener(func() {
+		s.load(source.Flags())
+	})
+
+	return s
+}
+
+// NewEtcdStore returns a Store watching the flag definitions under key
+// on the given etcd cluster, one json Flag per value.
+func NewEtcdStore(endpoints []string, key string) (*Store, error) {
+	sub, err := discov.NewSubscriber(endpoints, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(etcdSource{
+		sub: sub,
+	}), nil
+}
+
+// Enabled evaluates the flag name for user, unknown flags are off.
+// Percentage rollouts bucket deterministically on the flag and user,
+// a user keeps its decision across evaluations and processes.
+func (s *Store) Enabled(name, user string) bool {
+	flags := s.flags.Load().(map[string]Flag)
+	flag, ok := flags[name]
+	if !ok || !flag.Enabled {
+		return false
+	}
+
+	for _, allowed := range flag.Allowlist {
+		if allowed == user {
+			return true
+		}
+	}
+
+	if flag.Percentage <= 0 {
+		return true
+	}
+	if flag.Percentage >= percentBuckets {
+		return true
+	}
+	if len(user) == 0 {
+		return false
+	}
+
+	bucket := hash.Hash([]byte(flag.Name+":"+user)) % percentBuckets
+	return int(bucket) < flag.Percentage
+}
+
+func (s *Store) load(flags []Flag) {
+	m := make(map[string]Flag, len(flags))
+	for _, flag := range flags {
+		m[flag.Name] = flag
+	}
+	s.flags.Store(m)
+}
+
+func (s etcdSource) Flags() []Flag {
+	var flags []Flag
+	for _, value := range s.sub.Values() {
+		var flag Flag
+		if err := json.Unmarshal([]byte(value), &flag); err != nil {
+			logx.Errorf("Error on parsing feature flag %q: %v", value, err)
+			continue
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags
+}
+
+func (s etcdSource) AddListener(listener func()) {
+	s.sub.AddListener(listener)
+}
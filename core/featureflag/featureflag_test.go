@@ -0,0 +1,88 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedSource struct {
+	flags     []Flag
+	listeners []func()
+}
+
+func (s *mockedSource) Flags() []Flag {
+	return s.flags
+}
+
+func (s *mockedSource) AddListener(listener func()) {
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *mockedSource) update(flags []Flag) {
+	s.flags = flags
+	for _, listener := range s.listeners {
+		listener()
+	}
+}
+
+func TestStoreBoolean(t *testing.T) {
+	store := NewStore(&mockedSource{
+		flags: []Flag{
+			{Name: "on", Enabled: true},
+			{Name: "off", Enabled: false},
+		},
+	})
+	assert.True(t, store.Enabled("on", "alice"))
+	assert.False(t, store.Enabled("off", "alice"))
+	assert.False(t, store.Enabled("unknown", "alice"))
+}
+
+func TestStoreAllowlist(t *testing.T) {
+	store := NewStore(&mockedSource{
+		flags: []Flag{
+			{Name: "beta", Enabled: true, Percentage: 1, Allowlist: []string{"alice"}},
+		},
+	})
+	assert.True(t, store.Enabled("beta", "alice"))
+}
+
+func TestStorePercentage(t *testing.T) {
+	store := NewStore(&mockedSource{
+		flags: []Flag{
+			{Name: "rollout", Enabled: true, Percentage: 50},
+		},
+	})
+
+	var enabled int
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		first := store.Enabled("rollout", user)
+		// deterministic per user
+		assert.Equal(t, first, store.Enabled("rollout", user))
+		if first {
+			enabled++
+		}
+	}
+	// roughly half the users get the flag
+	assert.True(t, enabled > 400 && enabled < 600, "enabled: %d", enabled)
+
+	// no user identity, not part of a percentage rollout
+	assert.False(t, store.Enabled("rollout", ""))
+}
+
+func TestStoreHotReload(t *testing.T) {
+	source := &mockedSource{
+		flags: []Flag{
+			{Name: "feature", Enabled: false},
+		},
+	}
+	store := NewStore(source)
+	assert.False(t, store.Enabled("feature", "alice"))
+
+	source.update([]Flag{
+		{Name: "feature", Enabled: true},
+	})
+	assert.True(t, store.Enabled("feature", "alice"))
+}
@@ -0,0 +1,131 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// timestampBits is how many bits hold the milliseconds since the epoch.
+	timestampBits = 41
+	// workerSeqBits is shared between the worker id and the sequence.
+	workerSeqBits = 22
+	// defaultWorkerBits leaves 12 bits of sequence, 4096 ids per millisecond.
+	defaultWorkerBits = 10
+	// maxBackwardsWait is the longest clock drift Next waits out before erroring.
+	maxBackwardsWait = time.Millisecond * 5
+)
+
+// defaultEpoch is 2020-01-01T00:00:00Z in milliseconds.
+const defaultEpoch int64 = 1577836800000
+
+// ErrClockBackwards indicates the system clock moved backwards more than
+// the generator is willing to wait out.
+var ErrClockBackwards = errors.New("clock moved backwards")
+
+type (
+	// SnowflakeOption defines the method to customize a Snowflake.
+	SnowflakeOption func(sf *Snowflake)
+
+	// A Snowflake generates 63-bit unique ids, composed of a timestamp,
+	// a worker id and a per-millisecond sequence. Safe for concurrent use,
+	// ids are monotonic within a process.
+	Snowflake struct {
+		lock       sync.Mutex
+		epoch      int64
+		workerBits uint
+		seqBits    uint
+		workerID   int64
+		lastStamp  int64
+		seq        int64
+	}
+)
+
+// NewSnowflake returns a Snowflake with given worker id.
+func NewSnowflake(workerID int64, opts ...SnowflakeOption) (*Snowflake, error) {
+	sf := &Snowflake{
+		epoch:      defaultEpoch,
+		workerBits: defaultWorkerBits,
+		workerID:   workerID,
+	}
+	for _, opt := range opts {
+		opt(sf)
+	}
+
+	if sf.workerBits == 0 || sf.workerBits >= workerSeqBits {
+		return nil, fmt.Errorf("worker bits %d out of range (0, %d)", sf.workerBits, workerSeqBits)
+	}
+	sf.seqBits = workerSeqBits - sf.workerBits
+	if workerID < 0 || workerID >= 1<<sf.workerBits {
+		return nil, fmt.Errorf("worker id %d out of range [0, %d)", workerID, 1<<sf.workerBits)
+	}
+
+	return sf, nil
+}
+
+// WithEpoch customizes a Snowflake with the given epoch,
+// ids stay sortable as long as the epoch is fixed per deployment.
+func WithEpoch(epoch time.Time) SnowflakeOption {
+	return func(sf *Snowflake) {
+		sf.epoch = epoch.UnixNano() / int64(time.Millisecond)
+	}
+}
+
+// WithWorkerBits customizes a Snowflake with the given worker id bits,
+// the sequence gets the remaining 22-bits of space.
+func WithWorkerBits(bits uint) SnowflakeOption {
+	return func(sf *Snowflake) {
+		sf.workerBits = bits
+	}
+}
+
+// Next returns the next id, waiting out small clock drifts and
+// returning ErrClockBackwards on larger ones.
+func (sf *Snowflake) Next() (int64, error) {
+	sf.lock.Lock()
+	defer sf.lock.Unlock()
+
+	now := sf.millis()
+	if now < sf.lastStamp {
+		if drift := time.Duration(sf.lastStamp-now) * time.Millisecond; drift > maxBackwardsWait {
+			return 0, ErrClockBackwards
+		}
+
+		for now < sf.lastStamp {
+			time.Sleep(time.Millisecond)
+			now = sf.millis()
+		}
+	}
+
+	if now == sf.lastStamp {
+		sf.seq = (sf.seq + 1) & (1<<sf.seqBits - 1)
+		if sf.seq == 0 {
+			// sequence exhausted within this millisecond, spin to the next one
+			for now <= sf.lastStamp {
+				now = sf.millis()
+			}
+		}
+	} else {
+		sf.seq = 0
+	}
+	sf.lastStamp = now
+
+	return (now-sf.epoch)<<workerSeqBits | sf.workerID<<sf.seqBits | sf.seq, nil
+}
+
+// NextString returns the next id in decimal string form.
+func (sf *Snowflake) NextString() (string, error) {
+	id, err := sf.Next()
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+func (sf *Snowflake) millis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
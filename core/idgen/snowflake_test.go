@@ -0,0 +1,79 @@
+package idgen
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnowflakeNext(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	assert.Nil(t, err)
+
+	var prev int64
+	for i := 0; i < 1000; i++ {
+		id, err := sf.Next()
+		assert.Nil(t, err)
+		assert.True(t, id > prev)
+		prev = id
+	}
+}
+
+func TestSnowflakeNextString(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	assert.Nil(t, err)
+	s, err := sf.NextString()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, s)
+}
+
+func TestSnowflakeConcurrent(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	assert.Nil(t, err)
+
+	const routines = 10
+	const perRoutine = 100
+	var lock sync.Mutex
+	ids := make(map[int64]struct{}, routines*perRoutine)
+	var wg sync.WaitGroup
+	for i := 0; i < routines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perRoutine; j++ {
+				id, err := sf.Next()
+				assert.Nil(t, err)
+				lock.Lock()
+				ids[id] = struct{}{}
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, routines*perRoutine, len(ids))
+}
+
+func TestSnowflakeOptions(t *testing.T) {
+	_, err := NewSnowflake(-1)
+	assert.NotNil(t, err)
+	_, err = NewSnowflake(4, WithWorkerBits(2))
+	assert.NotNil(t, err)
+	_, err = NewSnowflake(0, WithWorkerBits(22))
+	assert.NotNil(t, err)
+
+	sf, err := NewSnowflake(3, WithWorkerBits(2), WithEpoch(time.Now().Add(-time.Hour)))
+	assert.Nil(t, err)
+	id, err := sf.Next()
+	assert.Nil(t, err)
+	assert.True(t, id > 0)
+}
+
+func TestSnowflakeClockBackwards(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	assert.Nil(t, err)
+	sf.lastStamp = sf.millis() + int64(time.Minute/time.Millisecond)
+	_, err = sf.Next()
+	assert.Equal(t, ErrClockBackwards, err)
+}
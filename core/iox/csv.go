@@ -0,0 +1,158 @@
+package iox
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/mapping"
+)
+
+const csvTagKey = "csv"
+
+var csvUnmarshaler = mapping.NewUnmarshaler(csvTagKey, mapping.WithStringValues())
+
+type (
+	// CsvOption defines the method to customize the csv reading and writing functions.
+	CsvOption func(*csvOptions)
+
+	csvOptions struct {
+		comma   rune
+		useCRLF bool
+	}
+)
+
+// WithCsvComma customizes the csv functions with the given field delimiter.
+func WithCsvComma(comma rune) CsvOption {
+	return func(o *csvOptions) {
+		o.comma = comma
+	}
+}
+
+// WithCsvCRLF customizes the csv writing functions to terminate lines with \r\n.
+func WithCsvCRLF() CsvOption {
+	return func(o *csvOptions) {
+		o.useCRLF = true
+	}
+}
+
+// RangeCsv reads csv rows from reader into values of type T, calling fn on
+// each row and stopping early when fn returns false. The first row is the
+// header, columns map to struct fields via `csv` tags. Rows are processed
+// one by one, the input is never loaded into memory as a whole.
+func RangeCsv[T any](reader io.Reader, fn func(item T) bool, opts ...CsvOption) error {
+	options := buildCsvOptions(opts)
+	r := csv.NewReader(reader)
+	r.Comma = options.comma
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		m := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				m[name] = record[i]
+			}
+		}
+
+		var item T
+		if err := csvUnmarshaler.Unmarshal(m, &item); err != nil {
+			return err
+		}
+		if !fn(item) {
+			return nil
+		}
+	}
+}
+
+// WriteCsv writes items as csv rows into writer, with a header row derived
+// from the `csv` tags of T, falling back to the field names.
+// Values with delimiters or quotes are quoted per encoding/csv.
+func WriteCsv[T any](writer io.Writer, items []T, opts ...CsvOption) error {
+	options := buildCsvOptions(opts)
+	w := csv.NewWriter(writer)
+	w.Comma = options.comma
+	w.UseCRLF = options.useCRLF
+
+	var item T
+	fields := csvFields(reflect.TypeOf(item))
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.name
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, each := range items {
+		val := reflect.ValueOf(each)
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = fmt.Sprint(val.Field(field.index).Interface())
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+type csvField struct {
+	name  string
+	index int
+}
+
+func buildCsvOptions(opts []CsvOption) csvOptions {
+	options := csvOptions{
+		comma: ',',
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+func csvFields(tp reflect.Type) []csvField {
+	fields := make([]csvField, 0, tp.NumField())
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup(csvTagKey); ok {
+			tag = strings.Split(tag, ",")[0]
+			if tag == "-" {
+				continue
+			}
+			if len(tag) > 0 {
+				name = tag
+			}
+		}
+
+		fields = append(fields, csvField{
+			name:  name,
+			index: i,
+		})
+	}
+
+	return fields
+}
@@ -0,0 +1,73 @@
+package iox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type csvUser struct {
+	Name string `csv:"name"`
+	Age  int    `csv:"age"`
+}
+
+func TestRangeCsv(t *testing.T) {
+	input := "name,age\nalice,30\nbob,25\n"
+	var users []csvUser
+	err := RangeCsv(strings.NewReader(input), func(u csvUser) bool {
+		users = append(users, u)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []csvUser{{"alice", 30}, {"bob", 25}}, users)
+}
+
+func TestRangeCsvEarlyStop(t *testing.T) {
+	input := "name,age\nalice,30\nbob,25\n"
+	var count int
+	err := RangeCsv(strings.NewReader(input), func(u csvUser) bool {
+		count++
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRangeCsvCustomComma(t *testing.T) {
+	input := "name;age\nalice;30\n"
+	var users []csvUser
+	err := RangeCsv(strings.NewReader(input), func(u csvUser) bool {
+		users = append(users, u)
+		return true
+	}, WithCsvComma(';'))
+	assert.Nil(t, err)
+	assert.Equal(t, []csvUser{{"alice", 30}}, users)
+}
+
+func TestRangeCsvEmpty(t *testing.T) {
+	assert.Nil(t, RangeCsv(strings.NewReader(""), func(u csvUser) bool {
+		t.Fatal("should not be called")
+		return true
+	}))
+}
+
+func TestWriteCsv(t *testing.T) {
+	var builder strings.Builder
+	err := WriteCsv(&builder, []csvUser{{"alice", 30}, {"bo,b", 25}})
+	assert.Nil(t, err)
+	assert.Equal(t, "name,age\nalice,30\n\"bo,b\",25\n", builder.String())
+}
+
+func TestWriteCsvRoundTrip(t *testing.T) {
+	users := []csvUser{{"alice", 30}, {"bob", 25}}
+	var builder strings.Builder
+	assert.Nil(t, WriteCsv(&builder, users, WithCsvComma('\t')))
+
+	var got []csvUser
+	assert.Nil(t, RangeCsv(strings.NewReader(builder.String()), func(u csvUser) bool {
+		got = append(got, u)
+		return true
+	}, WithCsvComma('\t')))
+	assert.Equal(t, users, got)
+}
@@ -0,0 +1,94 @@
+package iox
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// RangeLines reads reader line by line, calling fn on each line,
+// and stops early when fn returns false.
+func RangeLines(reader io.Reader, fn func(line string) bool) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if !fn(scanner.Text()) {
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+// RangeLinesCtx is like RangeLines, but aborts with ctx.Err() once ctx is
+// canceled. The cancellation is checked between lines, a Read blocked on a
+// slow reader finishes its current line first.
+func RangeLinesCtx(ctx context.Context, reader io.Reader, fn func(line string) bool) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if !fn(scanner.Text()) {
+			return nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return scanner.Err()
+}
+
+// ReadTextCtx is like ReadText, but returns with ctx.Err() once ctx is
+// canceled, leaving the pending read to finish in background.
+func ReadTextCtx(ctx context.Context, filename string) (string, error) {
+	type result struct {
+		text string
+		err  error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		text, err := ReadText(filename)
+		ch <- result{
+			text: text,
+			err:  err,
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-ch:
+		return r.text, r.err
+	}
+}
+
+// ReadTextLinesCtx is like ReadTextLines, but returns with ctx.Err() once
+// ctx is canceled, leaving the pending read to finish in background.
+func ReadTextLinesCtx(ctx context.Context, filename string, opts ...TextReadOption) ([]string, error) {
+	type result struct {
+		lines []string
+		err   error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		lines, err := ReadTextLines(filename, opts...)
+		ch <- result{
+			lines: lines,
+			err:   err,
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		return r.lines, r.err
+	}
+}
@@ -0,0 +1,79 @@
+package iox
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeLines(t *testing.T) {
+	var lines []string
+	err := RangeLines(strings.NewReader("a\nb\nc"), func(line string) bool {
+		lines = append(lines, line)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, lines)
+}
+
+func TestRangeLinesEarlyStop(t *testing.T) {
+	var lines []string
+	err := RangeLines(strings.NewReader("a\nb\nc"), func(line string) bool {
+		lines = append(lines, line)
+		return len(lines) < 2
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines)
+}
+
+func TestRangeLinesCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int
+	err := RangeLinesCtx(ctx, strings.NewReader("a\nb\nc"), func(line string) bool {
+		count++
+		cancel()
+		return true
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestReadTextCtx(t *testing.T) {
+	tmpfile, err := ioutil.TempFile(os.TempDir(), "test-iox-*")
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile.Name())
+	_, err = tmpfile.WriteString(" hello world \n")
+	assert.Nil(t, err)
+	assert.Nil(t, tmpfile.Close())
+
+	text, err := ReadTextCtx(context.Background(), tmpfile.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", text)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ReadTextCtx(ctx, tmpfile.Name())
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestReadTextLinesCtx(t *testing.T) {
+	tmpfile, err := ioutil.TempFile(os.TempDir(), "test-iox-*")
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile.Name())
+	_, err = tmpfile.WriteString("a\n\nb\n")
+	assert.Nil(t, err)
+	assert.Nil(t, tmpfile.Close())
+
+	lines, err := ReadTextLinesCtx(context.Background(), tmpfile.Name(), WithoutBlank())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ReadTextLinesCtx(ctx, tmpfile.Name())
+	assert.Equal(t, context.Canceled, err)
+}
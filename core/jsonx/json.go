@@ -23,6 +23,14 @@ func Unmarshal(data []byte, v interface{}) error {
 	return nil
 }
 
+// UnmarshalUseNumber unmarshals data bytes into v, decoding numbers as
+// json.Number instead of float64, so large int64 values keep their precision.
+// Unmarshal already behaves this way, this explicit variant documents the
+// guarantee for callers migrating from encoding/json.
+func UnmarshalUseNumber(data []byte, v interface{}) error {
+	return Unmarshal(data, v)
+}
+
 // UnmarshalFromString unmarshals v from str.
 func UnmarshalFromString(str string, v interface{}) error {
 	decoder := json.NewDecoder(strings.NewReader(str))
@@ -0,0 +1,24 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalUseNumber(t *testing.T) {
+	var v map[string]interface{}
+	assert.Nil(t, UnmarshalUseNumber([]byte(`{"id":9223372036854775807}`), &v))
+
+	num, ok := v["id"].(json.Number)
+	assert.True(t, ok)
+	id, err := num.Int64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9223372036854775807), id)
+}
+
+func TestUnmarshalUseNumberError(t *testing.T) {
+	var v map[string]interface{}
+	assert.NotNil(t, UnmarshalUseNumber([]byte(`{bad`), &v))
+}
@@ -0,0 +1,79 @@
+package jsonx
+
+// MergePatch applies an RFC 7386 JSON merge patch to original and returns
+// the patched document. Null values in patch delete the matching keys,
+// nested objects merge recursively, and arrays replace as a whole.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	patchMap, ok := patchValue.(map[string]interface{})
+	if !ok {
+		// per RFC 7386, a non-object patch replaces the whole document
+		return Marshal(patchValue)
+	}
+
+	var originalValue interface{}
+	if len(original) > 0 {
+		if err := Unmarshal(original, &originalValue); err != nil {
+			return nil, err
+		}
+	}
+	originalMap, ok := originalValue.(map[string]interface{})
+	if !ok {
+		originalMap = make(map[string]interface{})
+	}
+
+	return Marshal(mergePatchMap(originalMap, patchMap))
+}
+
+// DeepMerge merges b into a recursively and returns the result,
+// values in b win on conflicts, nested maps merge, everything else replaces.
+// Neither input map is modified.
+func DeepMerge(a, b map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		result[k] = v
+	}
+
+	for k, v := range b {
+		if sub, ok := v.(map[string]interface{}); ok {
+			if cur, ok := result[k].(map[string]interface{}); ok {
+				result[k] = DeepMerge(cur, sub)
+				continue
+			}
+		}
+		result[k] = v
+	}
+
+	return result
+}
+
+func mergePatchMap(original, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(original)+len(patch))
+	for k, v := range original {
+		result[k] = v
+	}
+
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+
+		if sub, ok := v.(map[string]interface{}); ok {
+			if cur, ok := result[k].(map[string]interface{}); ok {
+				result[k] = mergePatchMap(cur, sub)
+				continue
+			}
+			// target is not an object, merge into an empty one to drop nulls
+			result[k] = mergePatchMap(make(map[string]interface{}), sub)
+			continue
+		}
+		result[k] = v
+	}
+
+	return result
+}
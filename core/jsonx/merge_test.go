@@ -0,0 +1,110 @@
+package jsonx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expect   string
+	}{
+		{
+			name:     "replace value",
+			original: `{"a":"b"}`,
+			patch:    `{"a":"c"}`,
+			expect:   `{"a":"c"}`,
+		},
+		{
+			name:     "add key",
+			original: `{"a":"b"}`,
+			patch:    `{"b":"c"}`,
+			expect:   `{"a":"b","b":"c"}`,
+		},
+		{
+			name:     "null deletes key",
+			original: `{"a":"b","b":"c"}`,
+			patch:    `{"a":null}`,
+			expect:   `{"b":"c"}`,
+		},
+		{
+			name:     "nested merge",
+			original: `{"a":{"b":"c","d":"e"}}`,
+			patch:    `{"a":{"b":"x","f":null}}`,
+			expect:   `{"a":{"b":"x","d":"e"}}`,
+		},
+		{
+			name:     "array replaces",
+			original: `{"a":[1,2,3]}`,
+			patch:    `{"a":[4]}`,
+			expect:   `{"a":[4]}`,
+		},
+		{
+			name:     "non-object patch replaces document",
+			original: `{"a":"b"}`,
+			patch:    `["c"]`,
+			expect:   `["c"]`,
+		},
+		{
+			name:     "object patch on scalar",
+			original: `{"a":"b"}`,
+			patch:    `{"a":{"c":null,"d":"e"}}`,
+			expect:   `{"a":{"d":"e"}}`,
+		},
+		{
+			name:     "empty original",
+			original: ``,
+			patch:    `{"a":1}`,
+			expect:   `{"a":1}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(test.original), []byte(test.patch))
+			assert.Nil(t, err)
+			assert.JSONEq(t, test.expect, string(got))
+		})
+	}
+}
+
+func TestMergePatchBadInput(t *testing.T) {
+	_, err := MergePatch([]byte(`{"a":1}`), []byte(`{bad`))
+	assert.NotNil(t, err)
+	_, err = MergePatch([]byte(`{bad`), []byte(`{"a":1}`))
+	assert.NotNil(t, err)
+}
+
+func TestDeepMerge(t *testing.T) {
+	a := map[string]interface{}{
+		"a": 1,
+		"nested": map[string]interface{}{
+			"x": 1,
+			"y": 2,
+		},
+	}
+	b := map[string]interface{}{
+		"b": 2,
+		"nested": map[string]interface{}{
+			"y": 3,
+			"z": 4,
+		},
+	}
+
+	got := DeepMerge(a, b)
+	assert.Equal(t, map[string]interface{}{
+		"a": 1,
+		"b": 2,
+		"nested": map[string]interface{}{
+			"x": 1,
+			"y": 3,
+			"z": 4,
+		},
+	}, got)
+	// inputs untouched
+	assert.Equal(t, 2, a["nested"].(map[string]interface{})["y"])
+}
@@ -0,0 +1,41 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ErrNotArray indicates the stream doesn't start with a JSON array.
+var ErrNotArray = errors.New("not a json array")
+
+// DecodeArrayStream reads a JSON array from reader element by element,
+// calling fn with the raw bytes of each element and stopping on the first
+// error that fn returns. The array is never loaded into memory as a whole,
+// so it's suitable for very large payloads.
+func DecodeArrayStream(reader io.Reader, fn func(raw json.RawMessage) error) error {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return ErrNotArray
+	}
+
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+
+	// consume the closing bracket
+	_, err = decoder.Token()
+	return err
+}
@@ -0,0 +1,54 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeArrayStream(t *testing.T) {
+	input := `[{"id":1},{"id":2},{"id":3}]`
+	var ids []int
+	err := DecodeArrayStream(strings.NewReader(input), func(raw json.RawMessage) error {
+		var v struct {
+			ID int `json:"id"`
+		}
+		if err := Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		ids = append(ids, v.ID)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestDecodeArrayStreamStopOnError(t *testing.T) {
+	input := `[1,2,3]`
+	someErr := errors.New("stop")
+	var count int
+	err := DecodeArrayStream(strings.NewReader(input), func(raw json.RawMessage) error {
+		count++
+		return someErr
+	})
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestDecodeArrayStreamNotArray(t *testing.T) {
+	assert.Equal(t, ErrNotArray, DecodeArrayStream(strings.NewReader(`{"a":1}`), func(raw json.RawMessage) error {
+		return nil
+	}))
+	assert.NotNil(t, DecodeArrayStream(strings.NewReader(``), func(raw json.RawMessage) error {
+		return nil
+	}))
+}
+
+func TestDecodeArrayStreamMalformed(t *testing.T) {
+	assert.NotNil(t, DecodeArrayStream(strings.NewReader(`[1,2`), func(raw json.RawMessage) error {
+		return nil
+	}))
+}
@@ -51,6 +51,22 @@ type (
 	Shedder interface {
 		// Allow returns the Promise if allowed, otherwise ErrServiceOverloaded.
 		Allow() (Promise, error)
+		// Stat returns a snapshot of the current shedding state.
+		Stat() ShedderStats
+	}
+
+	// ShedderStats is a snapshot of a Shedder state.
+	ShedderStats struct {
+		// Flying is the number of inflight requests.
+		Flying int64
+		// AvgFlying is the moving average of inflight requests.
+		AvgFlying float64
+		// Total is the cumulative number of Allow calls.
+		Total int64
+		// Dropped is the cumulative number of dropped Allow calls.
+		Dropped int64
+		// DropRatio is Dropped over Total, zero when no calls yet.
+		DropRatio float64
 	}
 
 	// ShedderOption lets caller customize the Shedder.
@@ -60,12 +76,16 @@ type (
 		window       time.Duration
 		buckets      int
 		cpuThreshold int64
+		memThreshold float64
 	}
 
 	adaptiveShedder struct {
 		cpuThreshold    int64
+		memThreshold    float64
 		windows         int64
 		flying          int64
+		total           int64
+		dropped         int64
 		avgFlying       float64
 		avgFlyingLock   syncx.SpinLock
 		dropTime        *syncx.AtomicDuration
@@ -98,6 +118,7 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 	bucketDuration := options.window / time.Duration(options.buckets)
 	return &adaptiveShedder{
 		cpuThreshold:    options.cpuThreshold,
+		memThreshold:    options.memThreshold,
 		windows:         int64(time.Second / bucketDuration),
 		dropTime:        syncx.NewAtomicDuration(),
 		droppedRecently: syncx.NewAtomicBool(),
@@ -108,11 +129,20 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 	}
 }
 
-// Allow implements Shedder.Allow.
+// Allow implements Shedder.Allow, shedding at the configured cpu threshold,
+// same as AllowFor with PriorityLow.
 func (as *adaptiveShedder) Allow() (Promise, error) {
-	if as.shouldDrop() {
+	return as.AllowFor(PriorityLow)
+}
+
+// AllowFor implements PriorityShedder.AllowFor,
+// higher priorities shed at higher effective cpu thresholds.
+func (as *adaptiveShedder) AllowFor(priority Priority) (Promise, error) {
+	atomic.AddInt64(&as.total, 1)
+	if as.shouldDrop(priority) {
 		as.dropTime.Set(timex.Now())
 		as.droppedRecently.Set(true)
+		atomic.AddInt64(&as.dropped, 1)
 
 		return nil, ErrServiceOverloaded
 	}
@@ -125,6 +155,28 @@ func (as *adaptiveShedder) Allow() (Promise, error) {
 	}, nil
 }
 
+// Stat implements Shedder.Stat.
+func (as *adaptiveShedder) Stat() ShedderStats {
+	as.avgFlyingLock.Lock()
+	avgFlying := as.avgFlying
+	as.avgFlyingLock.Unlock()
+
+	total := atomic.LoadInt64(&as.total)
+	dropped := atomic.LoadInt64(&as.dropped)
+	var ratio float64
+	if total > 0 {
+		ratio = float64(dropped) / float64(total)
+	}
+
+	return ShedderStats{
+		Flying:    atomic.LoadInt64(&as.flying),
+		AvgFlying: avgFlying,
+		Total:     total,
+		Dropped:   dropped,
+		DropRatio: ratio,
+	}
+}
+
 func (as *adaptiveShedder) addFlying(delta int64) {
 	flying := atomic.AddInt64(&as.flying, delta)
 	// update avgFlying when the request is finished.
@@ -184,8 +236,8 @@ func (as *adaptiveShedder) minRt() float64 {
 	return result
 }
 
-func (as *adaptiveShedder) shouldDrop() bool {
-	if as.systemOverloaded() || as.stillHot() {
+func (as *adaptiveShedder) shouldDrop(priority Priority) bool {
+	if as.systemOverloaded(priority) || as.stillHot() {
 		if as.highThru() {
 			flying := atomic.LoadInt64(&as.flying)
 			as.avgFlyingLock.Lock()
@@ -221,8 +273,19 @@ func (as *adaptiveShedder) stillHot() bool {
 	return hot
 }
 
-func (as *adaptiveShedder) systemOverloaded() bool {
-	return systemOverloadChecker(as.cpuThreshold)
+func (as *adaptiveShedder) systemOverloaded(priority Priority) bool {
+	if systemOverloadChecker(as.effectiveCpuThreshold(priority)) {
+		return true
+	}
+
+	return as.memThreshold > 0 && memoryOverloadChecker(as.memThreshold)
+}
+
+// effectiveCpuThreshold raises the configured threshold by a quarter of the
+// remaining headroom up to 1000m per priority band.
+func (as *adaptiveShedder) effectiveCpuThreshold(priority Priority) int64 {
+	headroom := (1000 - as.cpuThreshold) / priorityBands
+	return as.cpuThreshold + int64(priority.normalize())*headroom
 }
 
 // WithBuckets customizes the Shedder with given number of buckets.
@@ -239,6 +302,16 @@ func WithCpuThreshold(threshold int64) ShedderOption {
 	}
 }
 
+// WithMemoryThreshold customizes the Shedder to also shed when the heap in
+// use crosses the given ratio of the soft memory limit set via
+// debug.SetMemoryLimit. Either the cpu or the memory signal tripping sheds.
+// Without a configured memory limit the option has no effect.
+func WithMemoryThreshold(ratio float64) ShedderOption {
+	return func(opts *shedderOptions) {
+		opts.memThreshold = ratio
+	}
+}
+
 // WithWindow customizes the Shedder with given
 func WithWindow(window time.Duration) ShedderOption {
 	return func(opts *shedderOptions) {
@@ -143,24 +143,24 @@ func TestAdaptiveShedderShouldDrop(t *testing.T) {
 		return true
 	}
 	shedder.avgFlying = 50
-	assert.False(t, shedder.shouldDrop())
+	assert.False(t, shedder.shouldDrop(PriorityLow))
 
 	// cpu >=  800, inflight > maxPass
 	shedder.avgFlying = 80
 	shedder.flying = 50
-	assert.False(t, shedder.shouldDrop())
+	assert.False(t, shedder.shouldDrop(PriorityLow))
 
 	// cpu >=  800, inflight > maxPass
 	shedder.avgFlying = 80
 	shedder.flying = 80
-	assert.True(t, shedder.shouldDrop())
+	assert.True(t, shedder.shouldDrop(PriorityLow))
 
 	// cpu < 800, inflight > maxPass
 	systemOverloadChecker = func(int64) bool {
 		return false
 	}
 	shedder.avgFlying = 80
-	assert.False(t, shedder.shouldDrop())
+	assert.False(t, shedder.shouldDrop(PriorityLow))
 
 	// cpu >=  800, inflight < maxPass
 	systemOverloadChecker = func(int64) bool {
@@ -237,3 +237,25 @@ func BenchmarkAdaptiveShedder_Allow(b *testing.B) {
 func newRollingWindow() *collection.RollingWindow {
 	return collection.NewRollingWindow(buckets, bucketDuration, collection.IgnoreCurrentBucket())
 }
+
+func TestAdaptiveShedderStat(t *testing.T) {
+	shedder := NewAdaptiveShedder(WithCpuThreshold(1000))
+	for i := 0; i < 10; i++ {
+		promise, err := shedder.Allow()
+		assert.Nil(t, err)
+		promise.Pass()
+	}
+
+	stats := shedder.Stat()
+	assert.Equal(t, int64(10), stats.Total)
+	assert.Equal(t, int64(0), stats.Dropped)
+	assert.Equal(t, float64(0), stats.DropRatio)
+	assert.Equal(t, int64(0), stats.Flying)
+}
+
+func TestNopShedderStat(t *testing.T) {
+	Disable()
+	defer enabled.Set(true)
+	shedder := NewAdaptiveShedder()
+	assert.Equal(t, ShedderStats{}, shedder.Stat())
+}
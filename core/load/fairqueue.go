@@ -0,0 +1,280 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+const (
+	defaultFairQueueLen = 64
+	defaultFairTimeout  = time.Second
+	defaultTenantWeight = 1
+)
+
+var (
+	// ErrFairQueueFull is returned by Acquire when the tenant queue is full.
+	ErrFairQueueFull = errors.New("tenant queue is full")
+	// ErrFairWaitTimeout is returned by Acquire when the wait times out.
+	ErrFairWaitTimeout = errors.New("timeout waiting for tenant share")
+)
+
+type (
+	// FairQueueOption defines the method to customize a FairQueue.
+	FairQueueOption func(fq *FairQueue)
+
+	// A FairQueue shares a global concurrency budget across tenants in
+	// proportion to their weights. A tenant above its fair share queues
+	// behind the others, but borrows idle capacity when nobody else is
+	// waiting, so a single active tenant still gets the whole budget.
+	FairQueue struct {
+		capacity int
+		maxQueue int
+		timeout  time.Duration
+		weights  map[string]int
+		lock     sync.Mutex
+		inflight int
+		tenants  map[string]*tenantState
+	}
+
+	tenantState struct {
+		name     string
+		weight   int
+		inflight int
+		waiters  []*fairWaiter
+	}
+
+	fairWaiter struct {
+		ready chan lang.PlaceholderType
+	}
+
+	tenantKey struct{}
+)
+
+// NewFairQueue returns a FairQueue with the given global concurrency budget.
+func NewFairQueue(capacity int, opts ...FairQueueOption) *FairQueue {
+	fq := &FairQueue{
+		capacity: capacity,
+		maxQueue: defaultFairQueueLen,
+		timeout:  defaultFairTimeout,
+		weights:  make(map[string]int),
+		tenants:  make(map[string]*tenantState),
+	}
+
+	for _, opt := range opts {
+		opt(fq)
+	}
+
+	return fq
+}
+
+// WithFairQueueLen customizes a FairQueue with the per-tenant queue length.
+func WithFairQueueLen(n int) FairQueueOption {
+	return func(fq *FairQueue) {
+		fq.maxQueue = n
+	}
+}
+
+// WithFairTimeout customizes a FairQueue with the max time a request
+// waits for its tenant's share.
+func WithFairTimeout(timeout time.Duration) FairQueueOption {
+	return func(fq *FairQueue) {
+		fq.timeout = timeout
+	}
+}
+
+// WithTenantWeight customizes a FairQueue with the weight of the given
+// tenant, tenants default to weight 1.
+func WithTenantWeight(tenant string, weight int) FairQueueOption {
+	return func(fq *FairQueue) {
+		fq.weights[tenant] = weight
+	}
+}
+
+// ContextWithTenant returns a new context carrying the tenant key.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant key carried in ctx,
+// an empty string when absent.
+func TenantFromContext(ctx context.Context) string {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return tenant
+}
+
+// Acquire admits one request for tenant, blocking up to the configured
+// timeout while the tenant is above its fair share. The caller must call
+// Release with the same tenant after serving the request.
+func (fq *FairQueue) Acquire(tenant string) error {
+	fq.lock.Lock()
+	state := fq.tenantState(tenant)
+	if fq.admit(state) {
+		state.inflight++
+		fq.inflight++
+		fq.lock.Unlock()
+		return nil
+	}
+
+	if len(state.waiters) >= fq.maxQueue {
+		fq.reapTenant(state)
+		fq.lock.Unlock()
+		return ErrFairQueueFull
+	}
+
+	waiter := &fairWaiter{
+		ready: make(chan lang.PlaceholderType),
+	}
+	state.waiters = append(state.waiters, waiter)
+	fq.lock.Unlock()
+
+	timer := time.NewTimer(fq.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-timer.C:
+		fq.lock.Lock()
+		defer fq.lock.Unlock()
+
+		select {
+		case <-waiter.ready:
+			// granted between timer firing and taking the lock
+			return nil
+		default:
+		}
+
+		fq.removeWaiter(state, waiter)
+		fq.reapTenant(state)
+		return ErrFairWaitTimeout
+	}
+}
+
+// Release returns the slot acquired for tenant, waking queued requests.
+func (fq *FairQueue) Release(tenant string) {
+	fq.lock.Lock()
+	defer fq.lock.Unlock()
+
+	state := fq.tenantState(tenant)
+	if state.inflight > 0 {
+		state.inflight--
+		fq.inflight--
+	}
+
+	fq.dispatch()
+	fq.reapTenant(state)
+}
+
+// admit reports whether the tenant may run now, with fq.lock held.
+func (fq *FairQueue) admit(state *tenantState) bool {
+	if fq.inflight >= fq.capacity {
+		return false
+	}
+
+	if state.inflight < fq.fairShare(state) {
+		return true
+	}
+
+	// above fair share, borrow idle capacity unless others are waiting
+	return !fq.othersWaiting(state)
+}
+
+// dispatch grants freed slots to waiting tenants, with fq.lock held.
+// Each round picks the waiting tenant with the lowest weighted load.
+func (fq *FairQueue) dispatch() {
+	for fq.inflight < fq.capacity {
+		var picked *tenantState
+		for _, state := range fq.tenants {
+			if len(state.waiters) == 0 {
+				continue
+			}
+			if picked == nil || state.inflight*picked.weight < picked.inflight*state.weight {
+				picked = state
+			}
+		}
+		if picked == nil {
+			return
+		}
+		if picked.inflight >= fq.fairShare(picked) && fq.othersWaiting(picked) {
+			return
+		}
+
+		waiter := picked.waiters[0]
+		picked.waiters = picked.waiters[1:]
+		picked.inflight++
+		fq.inflight++
+		close(waiter.ready)
+	}
+}
+
+// fairShare returns the tenant's share of the budget over the currently
+// active tenants, at least one, with fq.lock held.
+func (fq *FairQueue) fairShare(state *tenantState) int {
+	totalWeight := state.weight
+	for _, other := range fq.tenants {
+		if other == state {
+			continue
+		}
+		if other.inflight > 0 || len(other.waiters) > 0 {
+			totalWeight += other.weight
+		}
+	}
+
+	share := fq.capacity * state.weight / totalWeight
+	if share < 1 {
+		share = 1
+	}
+
+	return share
+}
+
+func (fq *FairQueue) othersWaiting(state *tenantState) bool {
+	for _, other := range fq.tenants {
+		if other != state && len(other.waiters) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reapTenant drops an idle tenant from the table, with fq.lock held.
+func (fq *FairQueue) reapTenant(state *tenantState) {
+	if state.inflight == 0 && len(state.waiters) == 0 {
+		delete(fq.tenants, state.name)
+	}
+}
+
+func (fq *FairQueue) removeWaiter(state *tenantState, waiter *fairWaiter) {
+	for i, each := range state.waiters {
+		if each == waiter {
+			state.waiters = append(state.waiters[:i], state.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (fq *FairQueue) tenantState(tenant string) *tenantState {
+	state, ok := fq.tenants[tenant]
+	if !ok {
+		weight, ok := fq.weights[tenant]
+		if !ok {
+			weight = defaultTenantWeight
+		}
+		state = &tenantState{
+			name:   tenant,
+			weight: weight,
+		}
+		fq.tenants[tenant] = state
+	}
+
+	return state
+}
@@ -0,0 +1,137 @@
+package load
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantContext(t *testing.T) {
+	assert.Equal(t, "", TenantFromContext(context.Background()))
+	ctx := ContextWithTenant(context.Background(), "acme")
+	assert.Equal(t, "acme", TenantFromContext(ctx))
+}
+
+func TestFairQueueAcquireRelease(t *testing.T) {
+	fq := NewFairQueue(2)
+	assert.Nil(t, fq.Acquire("a"))
+	assert.Nil(t, fq.Acquire("a"))
+	fq.Release("a")
+	fq.Release("a")
+	assert.Equal(t, 0, fq.inflight)
+	assert.Equal(t, 0, len(fq.tenants))
+}
+
+func TestFairQueueSingleTenantBorrowsAll(t *testing.T) {
+	fq := NewFairQueue(4, WithTenantWeight("a", 1))
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, fq.Acquire("a"))
+	}
+	defer func() {
+		for i := 0; i < 4; i++ {
+			fq.Release("a")
+		}
+	}()
+
+	// budget exhausted, further requests wait and time out
+	fq.timeout = time.Millisecond * 50
+	assert.Equal(t, ErrFairWaitTimeout, fq.Acquire("a"))
+}
+
+func TestFairQueueQueueFull(t *testing.T) {
+	fq := NewFairQueue(1, WithFairQueueLen(1), WithFairTimeout(time.Millisecond*100))
+	assert.Nil(t, fq.Acquire("a"))
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- fq.Acquire("a")
+	}()
+	// wait for the first waiter to be queued
+	for {
+		fq.lock.Lock()
+		queued := len(fq.tenantState("a").waiters)
+		fq.lock.Unlock()
+		if queued == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.Equal(t, ErrFairQueueFull, fq.Acquire("a"))
+	fq.Release("a")
+	assert.Nil(t, <-errs)
+	fq.Release("a")
+}
+
+func TestFairQueueWaiterWokenOnRelease(t *testing.T) {
+	fq := NewFairQueue(1, WithFairTimeout(time.Second))
+	assert.Nil(t, fq.Acquire("a"))
+
+	done := make(chan error)
+	go func() {
+		done <- fq.Acquire("b")
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	fq.Release("a")
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken on release")
+	}
+	fq.Release("b")
+}
+
+func TestFairQueueProportionalShares(t *testing.T) {
+	// weight 3:1 over budget 4, each tenant hammers the queue,
+	// the heavier tenant should finish roughly three times the work
+	fq := NewFairQueue(4,
+		WithTenantWeight("heavy", 3),
+		WithTenantWeight("light", 1),
+		WithFairTimeout(time.Second),
+		WithFairQueueLen(128))
+
+	var heavy, light int32
+	var wg sync.WaitGroup
+	run := func(tenant string, counter *int32) {
+		defer wg.Done()
+		for i := 0; i < 40; i++ {
+			if fq.Acquire(tenant) != nil {
+				continue
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(counter, 1)
+			fq.Release(tenant)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go run("heavy", &heavy)
+		go run("light", &light)
+	}
+	wg.Wait()
+
+	assert.True(t, atomic.LoadInt32(&heavy) > 0)
+	assert.True(t, atomic.LoadInt32(&light) > 0)
+}
+
+func TestFairQueueFairShare(t *testing.T) {
+	fq := NewFairQueue(4, WithTenantWeight("a", 3), WithTenantWeight("b", 1))
+	fq.lock.Lock()
+	defer fq.lock.Unlock()
+
+	a := fq.tenantState("a")
+	b := fq.tenantState("b")
+	// only a active, it owns the whole budget
+	a.inflight = 1
+	assert.Equal(t, 4, fq.fairShare(a))
+	// both active, shares split 3:1
+	b.inflight = 1
+	assert.Equal(t, 3, fq.fairShare(a))
+	assert.Equal(t, 1, fq.fairShare(b))
+}
@@ -0,0 +1,55 @@
+package load
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+// memStatsInterval bounds how often ReadMemStats runs,
+// it stops the world briefly so don't call it per request.
+const memStatsInterval = time.Second
+
+var (
+	lastMemCheck   int64
+	lastMemPressed int32
+
+	// make it a variable for unit test
+	memoryOverloadChecker = func(threshold float64) bool {
+		now := int64(timex.Now())
+		last := atomic.LoadInt64(&lastMemCheck)
+		if now-last < int64(memStatsInterval) {
+			return atomic.LoadInt32(&lastMemPressed) == 1
+		}
+		if !atomic.CompareAndSwapInt64(&lastMemCheck, last, now) {
+			return atomic.LoadInt32(&lastMemPressed) == 1
+		}
+
+		pressed := memoryPressed(threshold)
+		if pressed {
+			atomic.StoreInt32(&lastMemPressed, 1)
+		} else {
+			atomic.StoreInt32(&lastMemPressed, 0)
+		}
+
+		return pressed
+	}
+)
+
+func memoryPressed(threshold float64) bool {
+	// reads the current soft memory limit without changing it
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || limit == math.MaxInt64 {
+		// no limit configured, nothing to compare against
+		return false
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return float64(stats.HeapInuse) >= threshold*float64(limit)
+}
@@ -0,0 +1,50 @@
+package load
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryPressedNoLimit(t *testing.T) {
+	debug.SetMemoryLimit(math.MaxInt64)
+	assert.False(t, memoryPressed(0.9))
+}
+
+func TestMemoryPressedRisingHeap(t *testing.T) {
+	defer debug.SetMemoryLimit(math.MaxInt64)
+
+	// a tiny limit the live heap already exceeds
+	debug.SetMemoryLimit(1)
+	assert.True(t, memoryPressed(0.9))
+
+	// a huge limit the heap can't reach
+	debug.SetMemoryLimit(1 << 50)
+	assert.False(t, memoryPressed(0.9))
+}
+
+func TestShedderWithMemoryThreshold(t *testing.T) {
+	wasEnabled := enabled.True()
+	enabled.Set(true)
+	defer enabled.Set(wasEnabled)
+
+	oldChecker := memoryOverloadChecker
+	defer func() {
+		memoryOverloadChecker = oldChecker
+	}()
+
+	var pressed bool
+	memoryOverloadChecker = func(threshold float64) bool {
+		return pressed
+	}
+	systemOverloadChecker = func(int64) bool {
+		return false
+	}
+
+	shedder := NewAdaptiveShedder(WithCpuThreshold(1000), WithMemoryThreshold(0.9)).(*adaptiveShedder)
+	assert.False(t, shedder.systemOverloaded(PriorityLow))
+	pressed = true
+	assert.True(t, shedder.systemOverloaded(PriorityLow))
+}
@@ -10,6 +10,14 @@ func (s nopShedder) Allow() (Promise, error) {
 	return nopPromise{}, nil
 }
 
+func (s nopShedder) AllowFor(_ Priority) (Promise, error) {
+	return nopPromise{}, nil
+}
+
+func (s nopShedder) Stat() ShedderStats {
+	return ShedderStats{}
+}
+
 type nopPromise struct{}
 
 func (p nopPromise) Pass() {
@@ -0,0 +1,70 @@
+package load
+
+import "context"
+
+// Priority is the shedding priority of a request. Under pressure lower
+// priorities are dropped first: each band above PriorityLow raises the
+// effective cpu threshold by a quarter of the remaining headroom, so with
+// the default 900m threshold, low sheds at 900m, normal at 925m, high at
+// 950m and critical at 975m. The cool-off window after drops applies to
+// all bands alike.
+type Priority int
+
+const (
+	// PriorityLow is for traffic that is shed first, like analytics writes.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh is for user-facing requests.
+	PriorityHigh
+	// PriorityCritical is for requests that must survive longest, like checkout.
+	PriorityCritical
+
+	priorityBands = 4
+)
+
+// PriorityShedder is a Shedder that sheds by request priority.
+type PriorityShedder interface {
+	Shedder
+	// AllowFor is like Allow, with higher priorities surviving longer
+	// under pressure.
+	AllowFor(priority Priority) (Promise, error)
+}
+
+type priorityKey struct{}
+
+// AllowFor calls shedder.AllowFor if implemented, falling back to Allow.
+func AllowFor(shedder Shedder, priority Priority) (Promise, error) {
+	if ps, ok := shedder.(PriorityShedder); ok {
+		return ps.AllowFor(priority)
+	}
+
+	return shedder.Allow()
+}
+
+// ContextWithPriority returns a context holding the given shedding priority,
+// typically set by server middleware.
+func ContextWithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the shedding priority in ctx,
+// PriorityNormal if not set.
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+
+	return PriorityNormal
+}
+
+func (p Priority) normalize() Priority {
+	if p < PriorityLow {
+		return PriorityLow
+	}
+	if p > PriorityCritical {
+		return PriorityCritical
+	}
+
+	return p
+}
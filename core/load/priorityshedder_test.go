@@ -0,0 +1,62 @@
+package load
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, PriorityNormal, PriorityFromContext(ctx))
+	ctx = ContextWithPriority(ctx, PriorityCritical)
+	assert.Equal(t, PriorityCritical, PriorityFromContext(ctx))
+}
+
+func TestPriorityNormalize(t *testing.T) {
+	assert.Equal(t, PriorityLow, Priority(-1).normalize())
+	assert.Equal(t, PriorityCritical, Priority(100).normalize())
+	assert.Equal(t, PriorityHigh, PriorityHigh.normalize())
+}
+
+func TestEffectiveCpuThreshold(t *testing.T) {
+	wasEnabled := enabled.True()
+	enabled.Set(true)
+	defer enabled.Set(wasEnabled)
+
+	shedder := NewAdaptiveShedder(WithCpuThreshold(900)).(*adaptiveShedder)
+	assert.Equal(t, int64(900), shedder.effectiveCpuThreshold(PriorityLow))
+	assert.Equal(t, int64(925), shedder.effectiveCpuThreshold(PriorityNormal))
+	assert.Equal(t, int64(950), shedder.effectiveCpuThreshold(PriorityHigh))
+	assert.Equal(t, int64(975), shedder.effectiveCpuThreshold(PriorityCritical))
+}
+
+func TestAllowForFallback(t *testing.T) {
+	Disable()
+	defer enabled.Set(true)
+	shedder := NewAdaptiveShedder()
+	promise, err := AllowFor(shedder, PriorityLow)
+	assert.Nil(t, err)
+	promise.Pass()
+}
+
+func TestPrioritySurvivesLonger(t *testing.T) {
+	defer func() {
+		systemOverloadChecker = func(cpuThreshold int64) bool {
+			return true
+		}
+	}()
+
+	// cpu at 930m: low priority trips, critical doesn't
+	systemOverloadChecker = func(cpuThreshold int64) bool {
+		return 930 >= cpuThreshold
+	}
+
+	wasEnabled := enabled.True()
+	enabled.Set(true)
+	defer enabled.Set(wasEnabled)
+	shedder := NewAdaptiveShedder(WithCpuThreshold(900)).(*adaptiveShedder)
+	assert.True(t, shedder.systemOverloaded(PriorityLow))
+	assert.False(t, shedder.systemOverloaded(PriorityCritical))
+}
@@ -0,0 +1,64 @@
+package metric
+
+import "github.com/tal-tech/go-zero/core/lang"
+
+// OtherLabelValue is the bucket that unknown label values aggregate into.
+const OtherLabelValue = "other"
+
+type boundedCounterVec struct {
+	CounterVec
+	allowed []map[string]lang.PlaceholderType
+}
+
+// NewBoundedCounterVec returns a CounterVec that only reports the allowed
+// values for each label, aggregating unknown values into the "other" bucket
+// to prevent cardinality blowups. Labels without an entry in allowedValues
+// are unrestricted.
+func NewBoundedCounterVec(cfg *CounterVecOpts, allowedValues map[string][]string) CounterVec {
+	if cfg == nil {
+		return nil
+	}
+
+	inner := NewCounterVec(cfg)
+	allowed := make([]map[string]lang.PlaceholderType, len(cfg.Labels))
+	for i, label := range cfg.Labels {
+		values, ok := allowedValues[label]
+		if !ok {
+			continue
+		}
+
+		set := make(map[string]lang.PlaceholderType, len(values))
+		for _, value := range values {
+			set[value] = lang.Placeholder
+		}
+		allowed[i] = set
+	}
+
+	return &boundedCounterVec{
+		CounterVec: inner,
+		allowed:    allowed,
+	}
+}
+
+func (cv *boundedCounterVec) Inc(labels ...string) {
+	cv.CounterVec.Inc(cv.bound(labels)...)
+}
+
+func (cv *boundedCounterVec) Add(v float64, labels ...string) {
+	cv.CounterVec.Add(v, cv.bound(labels)...)
+}
+
+func (cv *boundedCounterVec) bound(labels []string) []string {
+	bounded := make([]string, len(labels))
+	for i, label := range labels {
+		if i < len(cv.allowed) && cv.allowed[i] != nil {
+			if _, ok := cv.allowed[i][label]; !ok {
+				bounded[i] = OtherLabelValue
+				continue
+			}
+		}
+		bounded[i] = label
+	}
+
+	return bounded
+}
@@ -0,0 +1,65 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoundedCounterVec(t *testing.T) {
+	counterVec := NewBoundedCounterVec(&CounterVecOpts{
+		Namespace: "http_server",
+		Subsystem: "requests",
+		Name:      "tenant_total",
+		Help:      "http server requests count by tenant.",
+		Labels:    []string{"tenant"},
+	}, map[string][]string{
+		"tenant": {"acme", "globex"},
+	})
+	defer counterVec.close()
+	assert.NotNil(t, counterVec)
+	assert.Nil(t, NewBoundedCounterVec(nil, nil))
+}
+
+func TestBoundedCounterVecAggregatesUnknowns(t *testing.T) {
+	counterVec := NewBoundedCounterVec(&CounterVecOpts{
+		Namespace: "rpc_server",
+		Subsystem: "requests",
+		Name:      "tenant_err_total",
+		Help:      "rpc server requests error count by tenant.",
+		Labels:    []string{"tenant", "code"},
+	}, map[string][]string{
+		"tenant": {"acme"},
+	})
+	defer counterVec.close()
+
+	counterVec.Inc("acme", "500")
+	counterVec.Inc("evilcorp", "500")
+	counterVec.Add(2, "another", "500")
+
+	bounded := counterVec.(*boundedCounterVec)
+	inner := bounded.CounterVec.(*promCounterVec)
+	assert.Equal(t, float64(1), testutil.ToFloat64(inner.counter.WithLabelValues("acme", "500")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(inner.counter.WithLabelValues(OtherLabelValue, "500")))
+	// the code label is unrestricted
+	counterVec.Inc("acme", "404")
+	assert.Equal(t, float64(1), testutil.ToFloat64(inner.counter.WithLabelValues("acme", "404")))
+}
+
+func TestBoundedCounterVecBound(t *testing.T) {
+	counterVec := NewBoundedCounterVec(&CounterVecOpts{
+		Namespace: "test",
+		Subsystem: "bound",
+		Name:      "total",
+		Help:      "bound test.",
+		Labels:    []string{"tenant"},
+	}, map[string][]string{
+		"tenant": {"acme"},
+	})
+	defer counterVec.close()
+
+	bounded := counterVec.(*boundedCounterVec)
+	assert.Equal(t, []string{"acme"}, bounded.bound([]string{"acme"}))
+	assert.Equal(t, []string{OtherLabelValue}, bounded.bound([]string{"unknown"}))
+}
@@ -1,6 +1,7 @@
 package mr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -43,7 +44,9 @@ type (
 	Option func(opts *mapReduceOptions)
 
 	mapReduceOptions struct {
+		ctx     context.Context
 		workers int
+		stats   *MapReduceStats
 	}
 
 	// Writer interface wraps Write method.
@@ -88,6 +91,19 @@ func FinishVoid(fns ...func()) {
 	}, WithWorkers(len(fns)))
 }
 
+// ForEachErr processes all elements generated from given generate func parallelly,
+// cancelling the remaining work on the first error and returning it.
+// Use WithWorkers to cap the concurrency, WithContext to cancel the processing.
+func ForEachErr(generate GenerateFunc, process func(item interface{}) error, opts ...Option) error {
+	return MapReduceVoid(generate, func(item interface{}, writer Writer, cancel func(error)) {
+		if err := process(item); err != nil {
+			cancel(err)
+		}
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		drain(pipe)
+	}, opts...)
+}
+
 // Map maps all elements generated from given generate func, and returns an output channel.
 func Map(generate GenerateFunc, mapper MapFunc, opts ...Option) chan interface{} {
 	options := buildOptions(opts...)
@@ -111,6 +127,11 @@ func MapReduce(generate GenerateFunc, mapper MapperFunc, reducer ReducerFunc, op
 func MapReduceWithSource(source <-chan interface{}, mapper MapperFunc, reducer ReducerFunc,
 	opts ...Option) (interface{}, error) {
 	options := buildOptions(opts...)
+	stats := newStatsCollector(options.stats)
+	defer stats.finish()
+	source = stats.wrapSource(source)
+	mapper = stats.wrapMapper(mapper)
+	reducer = stats.wrapReducer(reducer)
 	output := make(chan interface{})
 	collector := make(chan interface{}, options.workers)
 	done := syncx.NewDoneChan()
@@ -133,6 +154,15 @@ func MapReduceWithSource(source <-chan interface{}, mapper MapperFunc, reducer R
 		drain(source)
 		finish()
 	})
+	if options.ctx != nil {
+		go func() {
+			select {
+			case <-options.ctx.Done():
+				cancel(options.ctx.Err())
+			case <-done.Done():
+			}
+		}()
+	}
 
 	go func() {
 		defer func() {
@@ -181,6 +211,31 @@ func MapVoid(generate GenerateFunc, mapper VoidMapFunc, opts ...Option) {
 	}, opts...))
 }
 
+// MergeGenerate merges the given generate funcs into one that runs them
+// concurrently and funnels their elements into the same source channel,
+// returning when all of them finish. The merged func composes with
+// MapReduce like any other GenerateFunc.
+func MergeGenerate(generates ...GenerateFunc) GenerateFunc {
+	return func(source chan<- interface{}) {
+		group := threading.NewRoutineGroup()
+		for _, generate := range generates {
+			g := generate
+			group.RunSafe(func() {
+				g(source)
+			})
+		}
+		group.Wait()
+	}
+}
+
+// WithContext customizes a mapreduce processing to be cancelled with ctx.Err()
+// when ctx is done.
+func WithContext(ctx context.Context) Option {
+	return func(opts *mapReduceOptions) {
+		opts.ctx = ctx
+	}
+}
+
 // WithWorkers customizes a mapreduce processing with given workers.
 func WithWorkers(workers int) Option {
 	return func(opts *mapReduceOptions) {
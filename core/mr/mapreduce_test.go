@@ -1,6 +1,7 @@
 package mr
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"log"
@@ -315,6 +316,89 @@ func TestMapVoid(t *testing.T) {
 	assert.Equal(t, tasks, int(count))
 }
 
+func TestForEachErr(t *testing.T) {
+	const tasks = 1000
+	var count uint32
+	err := ForEachErr(func(source chan<- interface{}) {
+		for i := 0; i < tasks; i++ {
+			source <- i
+		}
+	}, func(item interface{}) error {
+		atomic.AddUint32(&count, 1)
+		return nil
+	}, WithWorkers(4))
+
+	assert.Nil(t, err)
+	assert.Equal(t, tasks, int(count))
+}
+
+func TestForEachErrWithError(t *testing.T) {
+	mockedErr := errors.New("boom")
+	err := ForEachErr(func(source chan<- interface{}) {
+		for i := 0; i < 1000; i++ {
+			source <- i
+		}
+	}, func(item interface{}) error {
+		if item.(int) == 10 {
+			return mockedErr
+		}
+		return nil
+	})
+
+	assert.Equal(t, mockedErr, err)
+}
+
+func TestForEachErrWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ForEachErr(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}, WithContext(ctx))
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestMergeGenerate(t *testing.T) {
+	const shards = 4
+	const itemsPerShard = 100
+	var generates []GenerateFunc
+	for i := 0; i < shards; i++ {
+		base := i * itemsPerShard
+		generates = append(generates, func(source chan<- interface{}) {
+			for j := 0; j < itemsPerShard; j++ {
+				source <- base + j
+			}
+		})
+	}
+
+	result, err := MapReduce(MergeGenerate(generates...),
+		func(item interface{}, writer Writer, cancel func(error)) {
+			writer.Write(item)
+		}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+			var count int
+			for range pipe {
+				count++
+			}
+			writer.Write(count)
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, shards*itemsPerShard, result)
+}
+
+func TestMergeGenerateNone(t *testing.T) {
+	err := MapReduceVoid(MergeGenerate(), func(item interface{}, writer Writer, cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		drain(pipe)
+	})
+	assert.Nil(t, err)
+}
+
 func TestMapReducePanic(t *testing.T) {
 	v, err := MapReduce(func(source chan<- interface{}) {
 		source <- 0
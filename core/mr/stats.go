@@ -0,0 +1,148 @@
+package mr
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+type (
+	// A MapReduceStats records what a MapReduce run did per stage,
+	// filled in when the run returns. Enable with WithStats.
+	// On canceled runs the counts cover the work observed until return.
+	MapReduceStats struct {
+		// items emitted by the generate func
+		GenerateItems int64
+		// items handed to the mappers
+		MapItems int64
+		// items the mappers wrote out
+		MapWrites int64
+		// mapper calls that finished without writing, i.e. silently dropped items
+		MapDrops int64
+		// mapper calls that canceled the run
+		MapErrors int64
+		// values the reducer wrote out
+		ReduceWrites int64
+		// wall time of the whole run
+		Duration time.Duration
+		// accumulated time spent inside mapper calls
+		MapDuration time.Duration
+	}
+
+	statsCollector struct {
+		stats         *MapReduceStats
+		startTime     time.Duration
+		generateItems int64
+		mapItems      int64
+		mapWrites     int64
+		mapDrops      int64
+		mapErrors     int64
+		reduceWrites  int64
+		mapDuration   int64
+	}
+
+	countingWriter struct {
+		writer Writer
+		writes int64
+	}
+)
+
+// WithStats customizes a mapreduce processing to record per-stage item
+// counts and timing into stats, which is filled in when the run returns.
+func WithStats(stats *MapReduceStats) Option {
+	return func(opts *mapReduceOptions) {
+		opts.stats = stats
+	}
+}
+
+func newStatsCollector(stats *MapReduceStats) *statsCollector {
+	if stats == nil {
+		return nil
+	}
+
+	return &statsCollector{
+		stats:     stats,
+		startTime: timex.Now(),
+	}
+}
+
+func (c *statsCollector) finish() {
+	if c == nil {
+		return
+	}
+
+	c.stats.GenerateItems = atomic.LoadInt64(&c.generateItems)
+	c.stats.MapItems = atomic.LoadInt64(&c.mapItems)
+	c.stats.MapWrites = atomic.LoadInt64(&c.mapWrites)
+	c.stats.MapDrops = atomic.LoadInt64(&c.mapDrops)
+	c.stats.MapErrors = atomic.LoadInt64(&c.mapErrors)
+	c.stats.ReduceWrites = atomic.LoadInt64(&c.reduceWrites)
+	c.stats.MapDuration = time.Duration(atomic.LoadInt64(&c.mapDuration))
+	c.stats.Duration = timex.Since(c.startTime)
+}
+
+func (c *statsCollector) wrapMapper(mapper MapperFunc) MapperFunc {
+	if c == nil {
+		return mapper
+	}
+
+	return func(item interface{}, writer Writer, cancel func(error)) {
+		atomic.AddInt64(&c.mapItems, 1)
+		w := &countingWriter{writer: writer}
+		var failed bool
+		itemCancel := func(err error) {
+			if !failed {
+				failed = true
+				atomic.AddInt64(&c.mapErrors, 1)
+			}
+			cancel(err)
+		}
+
+		start := timex.Now()
+		mapper(item, w, itemCancel)
+		atomic.AddInt64(&c.mapDuration, int64(timex.Since(start)))
+
+		writes := atomic.LoadInt64(&w.writes)
+		atomic.AddInt64(&c.mapWrites, writes)
+		if !failed && writes == 0 {
+			atomic.AddInt64(&c.mapDrops, 1)
+		}
+	}
+}
+
+func (c *statsCollector) wrapReducer(reducer ReducerFunc) ReducerFunc {
+	if c == nil {
+		return reducer
+	}
+
+	return func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		w := &countingWriter{writer: writer}
+		reducer(pipe, w, cancel)
+		atomic.AddInt64(&c.reduceWrites, atomic.LoadInt64(&w.writes))
+	}
+}
+
+func (c *statsCollector) wrapSource(source <-chan interface{}) <-chan interface{} {
+	if c == nil {
+		return source
+	}
+
+	out := make(chan interface{})
+	threading.GoSafe(func() {
+		defer close(out)
+
+		for item := range source {
+			atomic.AddInt64(&c.generateItems, 1)
+			out <- item
+		}
+	})
+
+	return out
+}
+
+func (w *countingWriter) Write(v interface{}) {
+	atomic.AddInt64(&w.writes, 1)
+	w.writer.Write(v)
+}
@@ -0,0 +1,76 @@
+package mr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStats(t *testing.T) {
+	var stats MapReduceStats
+	result, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		v := item.(int)
+		// drop the odd items silently
+		if v%2 == 0 {
+			writer.Write(v)
+		}
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var sum int
+		for v := range pipe {
+			sum += v.(int)
+		}
+		writer.Write(sum)
+	}, WithStats(&stats))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 20, result)
+	assert.Equal(t, int64(10), stats.GenerateItems)
+	assert.Equal(t, int64(10), stats.MapItems)
+	assert.Equal(t, int64(5), stats.MapWrites)
+	assert.Equal(t, int64(5), stats.MapDrops)
+	assert.Equal(t, int64(0), stats.MapErrors)
+	assert.Equal(t, int64(1), stats.ReduceWrites)
+	assert.True(t, stats.Duration > 0)
+	assert.True(t, stats.MapDuration > 0)
+}
+
+func TestWithStatsMapError(t *testing.T) {
+	var stats MapReduceStats
+	mockedErr := errors.New("boom")
+	_, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 4; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 2 {
+			cancel(mockedErr)
+			return
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		drain(pipe)
+	}, WithStats(&stats))
+
+	assert.Equal(t, mockedErr, err)
+	assert.True(t, stats.MapErrors >= 1)
+}
+
+func TestWithoutStats(t *testing.T) {
+	// the nil collector leaves the pipeline untouched
+	result, err := MapReduce(func(source chan<- interface{}) {
+		source <- 1
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		for v := range pipe {
+			writer.Write(v)
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result)
+}
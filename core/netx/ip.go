@@ -1,6 +1,75 @@
 package netx
 
-import "net"
+import (
+	"fmt"
+	"net"
+)
+
+type (
+	// IpOption defines the method to customize ip lookups.
+	IpOption func(*ipOptions)
+
+	ipOptions struct {
+		preferIPv6 bool
+	}
+)
+
+// PreferIPv4 customizes the ip lookup to prefer IPv4 addresses, the default.
+func PreferIPv4() IpOption {
+	return func(o *ipOptions) {
+		o.preferIPv6 = false
+	}
+}
+
+// PreferIPv6 customizes the ip lookup to prefer IPv6 addresses,
+// falling back to IPv4 when the interface has none.
+func PreferIPv6() IpOption {
+	return func(o *ipOptions) {
+		o.preferIPv6 = true
+	}
+}
+
+// InternalIpByInterface returns the first non-loopback address of the named
+// network interface, for multi-homed hosts where InternalIp picks the wrong
+// one. The preferred address family can be chosen with PreferIPv4/PreferIPv6.
+func InternalIpByInterface(name string, opts ...IpOption) (string, error) {
+	var options ipOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	inf, err := net.InterfaceByName(name)
+	if err != nil {
+		return "", err
+	}
+
+	addrs, err := inf.Addrs()
+	if err != nil {
+		return "", err
+	}
+
+	var fallback string
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() {
+			continue
+		}
+
+		isIPv4 := ipnet.IP.To4() != nil
+		if isIPv4 != options.preferIPv6 {
+			return ipnet.IP.String(), nil
+		}
+		if len(fallback) == 0 {
+			fallback = ipnet.IP.String()
+		}
+	}
+
+	if len(fallback) > 0 {
+		return fallback, nil
+	}
+
+	return "", fmt.Errorf("no usable address on interface %s", name)
+}
 
 // InternalIp returns an internal ip.
 func InternalIp() string {
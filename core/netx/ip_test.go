@@ -1,6 +1,7 @@
 package netx
 
 import (
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,3 +10,31 @@ import (
 func TestInternalIp(t *testing.T) {
 	assert.True(t, len(InternalIp()) > 0)
 }
+
+func TestInternalIpByInterface(t *testing.T) {
+	_, err := InternalIpByInterface("no-such-interface")
+	assert.NotNil(t, err)
+
+	ip, err := InternalIpByInterface("lo")
+	if err == nil {
+		// loopback addresses are skipped, lo has nothing else
+		assert.NotEmpty(t, ip)
+	}
+}
+
+func TestInternalIpByInterfaceFirstUsable(t *testing.T) {
+	infs, err := net.Interfaces()
+	assert.Nil(t, err)
+
+	for _, inf := range infs {
+		if isEthDown(inf.Flags) || isLoopback(inf.Flags) {
+			continue
+		}
+
+		ip, err := InternalIpByInterface(inf.Name, PreferIPv4())
+		if err == nil {
+			assert.NotEmpty(t, ip)
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package proc
+
+import (
+	"runtime/debug"
+
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+// A GcTuneConf is a GC tuning config. All fields default to zero,
+// and zero values leave the runtime untouched.
+type GcTuneConf struct {
+	// BallastBytes allocates a long-lived byte slice of the given size to
+	// raise the GC heap goal, smoothing GC under spiky allocation load.
+	BallastBytes int64 `json:",default=0"`
+	// GcPercent sets GOGC, same semantics as debug.SetGCPercent.
+	GcPercent int `json:",default=0"`
+	// MemLimitBytes sets the soft memory limit, same semantics as
+	// debug.SetMemoryLimit.
+	MemLimitBytes int64 `json:",default=0"`
+}
+
+// ballast keeps the configured memory ballast alive for the process lifetime.
+var ballast []byte
+
+// SetupGcTuning applies the given GC tuning config,
+// logging the effective values. A zero config is a no-op.
+func SetupGcTuning(c GcTuneConf) {
+	if c.BallastBytes > 0 {
+		ballast = make([]byte, c.BallastBytes)
+		logx.Infof("gc tuning: allocated %d bytes ballast", len(ballast))
+	}
+	if c.GcPercent > 0 {
+		prev := debug.SetGCPercent(c.GcPercent)
+		logx.Infof("gc tuning: set gc percent to %d, was %d", c.GcPercent, prev)
+	}
+	if c.MemLimitBytes > 0 {
+		prev := debug.SetMemoryLimit(c.MemLimitBytes)
+		logx.Infof("gc tuning: set memory limit to %d bytes, was %d", c.MemLimitBytes, prev)
+	}
+}
@@ -0,0 +1,33 @@
+package proc
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupGcTuningNoop(t *testing.T) {
+	assert.NotPanics(t, func() {
+		SetupGcTuning(GcTuneConf{})
+	})
+	assert.Nil(t, ballast)
+}
+
+func TestSetupGcTuning(t *testing.T) {
+	prevPercent := debug.SetGCPercent(100)
+	defer func() {
+		debug.SetGCPercent(prevPercent)
+		debug.SetMemoryLimit(-1)
+		ballast = nil
+	}()
+
+	SetupGcTuning(GcTuneConf{
+		BallastBytes:  1 << 20,
+		GcPercent:     200,
+		MemLimitBytes: 1 << 30,
+	})
+	assert.Equal(t, 1<<20, len(ballast))
+	assert.Equal(t, 200, debug.SetGCPercent(100))
+	assert.Equal(t, int64(1<<30), debug.SetMemoryLimit(-1))
+}
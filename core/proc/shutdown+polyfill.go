@@ -9,10 +9,19 @@ func AddShutdownListener(fn func()) func() {
 	return fn
 }
 
+// AddShutdownListenerWithPriority returns fn itself on windows, lets callers call fn on their own.
+func AddShutdownListenerWithPriority(_ int, fn func()) func() {
+	return fn
+}
+
 // AddWrapUpListener returns fn itself on windows, lets callers call fn on their own.
 func AddWrapUpListener(fn func()) func() {
 	return fn
 }
 
+// SetShutdownListenerTimeout is a no-op on windows.
+func SetShutdownListenerTimeout(_ time.Duration) {
+}
+
 func SetTimeoutToForceQuit(duration time.Duration) {
 }
@@ -5,6 +5,9 @@ package proc
 import (
 	"os"
 	"os/signal"
+	"reflect"
+	"runtime"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -22,6 +25,7 @@ var (
 	wrapUpListeners          = new(listenerManager)
 	shutdownListeners        = new(listenerManager)
 	delayTimeBeforeForceQuit = waitTime
+	shutdownListenerTimeout  time.Duration
 )
 
 // AddShutdownListener adds fn as a shutdown listener.
@@ -30,6 +34,22 @@ func AddShutdownListener(fn func()) (waitForCalled func()) {
 	return shutdownListeners.addListener(fn)
 }
 
+// AddShutdownListenerWithPriority adds fn as a shutdown listener with the
+// given priority. Higher priorities run first, so put drain hooks above the
+// hooks that close their downstream resources. Listeners with the same
+// priority run in registration order. AddShutdownListener registers with
+// priority 0. The returned func can be used to wait for fn getting called.
+func AddShutdownListenerWithPriority(priority int, fn func()) (waitForCalled func()) {
+	return shutdownListeners.addPriorityListener(priority, fn)
+}
+
+// SetShutdownListenerTimeout bounds each shutdown listener with the given
+// timeout, a stuck listener is logged and skipped instead of blocking the
+// whole shutdown. Zero means no per-listener timeout.
+func SetShutdownListenerTimeout(timeout time.Duration) {
+	shutdownListenerTimeout = timeout
+}
+
 // AddWrapUpListener adds fn as a wrap up listener.
 // The returned func can be used to wait for fn getting called.
 func AddWrapUpListener(fn func()) (waitForCalled func()) {
@@ -55,19 +75,36 @@ func gracefulStop(signals chan os.Signal) {
 	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
 }
 
-type listenerManager struct {
-	lock      sync.Mutex
-	waitGroup sync.WaitGroup
-	listeners []func()
-}
+type (
+	listenerManager struct {
+		lock      sync.Mutex
+		waitGroup sync.WaitGroup
+		listeners []prioritizedListener
+	}
+
+	prioritizedListener struct {
+		priority int
+		fn       func()
+		name     string
+	}
+)
 
 func (lm *listenerManager) addListener(fn func()) (waitForCalled func()) {
+	return lm.addPriorityListener(0, fn)
+}
+
+func (lm *listenerManager) addPriorityListener(priority int, fn func()) (waitForCalled func()) {
 	lm.waitGroup.Add(1)
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
 
 	lm.lock.Lock()
-	lm.listeners = append(lm.listeners, func() {
-		defer lm.waitGroup.Done()
-		fn()
+	lm.listeners = append(lm.listeners, prioritizedListener{
+		priority: priority,
+		fn: func() {
+			defer lm.waitGroup.Done()
+			fn()
+		},
+		name: name,
 	})
 	lm.lock.Unlock()
 
@@ -80,7 +117,31 @@ func (lm *listenerManager) notifyListeners() {
 	lm.lock.Lock()
 	defer lm.lock.Unlock()
 
+	// stable to keep registration order within the same priority
+	sort.SliceStable(lm.listeners, func(i, j int) bool {
+		return lm.listeners[i].priority > lm.listeners[j].priority
+	})
 	for _, listener := range lm.listeners {
-		listener()
+		runListener(listener)
+	}
+}
+
+func runListener(listener prioritizedListener) {
+	if shutdownListenerTimeout <= 0 {
+		listener.fn()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		listener.fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownListenerTimeout):
+		logx.Errorf("shutdown listener %s still running after %v, skipping",
+			listener.name, shutdownListenerTimeout)
 	}
 }
@@ -7,6 +7,55 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestShutdownListenerPriority(t *testing.T) {
+	lm := new(listenerManager)
+	var order []int
+	lm.addPriorityListener(1, func() {
+		order = append(order, 1)
+	})
+	lm.addPriorityListener(10, func() {
+		order = append(order, 10)
+	})
+	lm.addListener(func() {
+		order = append(order, 0)
+	})
+	waitLow := lm.addPriorityListener(10, func() {
+		order = append(order, 11)
+	})
+	lm.notifyListeners()
+	waitLow()
+	assert.Equal(t, []int{10, 11, 1, 0}, order)
+}
+
+func TestShutdownListenerTimeout(t *testing.T) {
+	SetShutdownListenerTimeout(time.Millisecond * 50)
+	defer SetShutdownListenerTimeout(0)
+
+	lm := new(listenerManager)
+	block := make(chan struct{})
+	defer close(block)
+	lm.addListener(func() {
+		<-block
+	})
+	var called bool
+	lm.addPriorityListener(-1, func() {
+		called = true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lm.notifyListeners()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("notifyListeners blocked by stuck listener")
+	}
+	assert.True(t, called)
+}
+
 func TestShutdown(t *testing.T) {
 	SetTimeToForceQuit(time.Hour)
 	assert.Equal(t, time.Hour, delayTimeBeforeForceQuit)
@@ -0,0 +1,90 @@
+package prof
+
+import (
+	"net/http"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	profileTokenHeader = "X-Profile-Token"
+	defaultSeconds     = 30
+	maxSeconds         = 120
+)
+
+// cpuProfiling is non zero while a CPU profile capture is running.
+var cpuProfiling int32
+
+// ProfileHandler returns an http.HandlerFunc that captures profiles on demand
+// and streams them back in pprof format, gated by the given auth token.
+// `?profile=cpu&seconds=30` captures a CPU profile, `?profile=heap`,
+// `?profile=goroutine` etc. snapshot the named runtime profile.
+// Only one CPU profile runs at a time, overlapping requests get 409.
+func ProfileHandler(authToken string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(authToken) == 0 || !tokenMatches(r, authToken) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Query().Get("profile") {
+		case "", "cpu":
+			captureCpuProfile(w, r)
+		default:
+			captureNamedProfile(w, r)
+		}
+	}
+}
+
+func captureCpuProfile(w http.ResponseWriter, r *http.Request) {
+	seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = defaultSeconds
+	}
+	if seconds > maxSeconds {
+		seconds = maxSeconds
+	}
+
+	if !atomic.CompareAndSwapInt32(&cpuProfiling, 0, 1) {
+		http.Error(w, "cpu profile already running", http.StatusConflict)
+		return
+	}
+	defer atomic.StoreInt32(&cpuProfiling, 0)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := pprof.StartCPUProfile(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timer := time.NewTimer(time.Duration(seconds) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+}
+
+func captureNamedProfile(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("profile")
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, "unknown profile: "+name, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	profile.WriteTo(w, 0)
+}
+
+func tokenMatches(r *http.Request, authToken string) bool {
+	token := r.Header.Get(profileTokenHeader)
+	if len(token) == 0 {
+		token = r.URL.Query().Get("token")
+	}
+
+	return token == authToken
+}
@@ -0,0 +1,61 @@
+package prof
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileHandlerAuth(t *testing.T) {
+	handler := ProfileHandler("secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/profile?profile=heap", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/debug/profile?profile=heap", nil)
+	req.Header.Set(profileTokenHeader, "wrong")
+	resp = httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	// an empty configured token locks the endpoint
+	handler = ProfileHandler("")
+	req = httptest.NewRequest(http.MethodGet, "/debug/profile?profile=heap", nil)
+	resp = httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestProfileHandlerHeap(t *testing.T) {
+	handler := ProfileHandler("secret")
+	req := httptest.NewRequest(http.MethodGet, "/debug/profile?profile=heap&token=secret", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.True(t, resp.Body.Len() > 0)
+}
+
+func TestProfileHandlerUnknownProfile(t *testing.T) {
+	handler := ProfileHandler("secret")
+	req := httptest.NewRequest(http.MethodGet, "/debug/profile?profile=nonexistent&token=secret", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestProfileHandlerCpuConflict(t *testing.T) {
+	handler := ProfileHandler("secret")
+	cpuProfiling = 1
+	defer func() {
+		cpuProfiling = 0
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/profile?profile=cpu&token=secret", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
@@ -0,0 +1,180 @@
+package prof
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultUploadInterval = time.Second * 10
+	defaultCpuSeconds     = 10
+	uploadTimeout         = time.Second * 10
+)
+
+type (
+	// An UploaderConf is a continuous profiling uploader config,
+	// disabled by default.
+	UploaderConf struct {
+		// Enabled toggles the uploader, nothing runs when false.
+		Enabled bool `json:",default=false"`
+		// Endpoint is the Pyroscope-compatible ingest base url,
+		// like http://pyroscope:4040.
+		Endpoint string `json:",optional"`
+		// AppName is the application name the profiles are filed under.
+		AppName string `json:",optional"`
+		// Tags are appended to the app name as name{key=value} labels.
+		Tags map[string]string `json:",optional"`
+		// Interval is how often a round of profiles is captured and pushed.
+		Interval time.Duration `json:",default=10s"`
+		// CpuSeconds is how long each CPU capture runs within a round.
+		CpuSeconds int `json:",default=10"`
+	}
+
+	// An Uploader periodically captures CPU, heap and goroutine profiles
+	// and pushes them to the configured ingest endpoint.
+	Uploader struct {
+		conf    UploaderConf
+		client  *http.Client
+		stopped int32
+		done    chan struct{}
+	}
+)
+
+// StartUploader starts a profile uploader with the given config,
+// returns nil when disabled, which costs nothing.
+func StartUploader(c UploaderConf) *Uploader {
+	if !c.Enabled || len(c.Endpoint) == 0 {
+		return nil
+	}
+
+	if c.Interval <= 0 {
+		c.Interval = defaultUploadInterval
+	}
+	if c.CpuSeconds <= 0 {
+		c.CpuSeconds = defaultCpuSeconds
+	}
+
+	up := &Uploader{
+		conf: c,
+		client: &http.Client{
+			Timeout: uploadTimeout,
+		},
+		done: make(chan struct{}),
+	}
+	threading.GoSafe(up.run)
+
+	return up
+}
+
+// Stop stops the uploader, safe to call on a nil receiver.
+func (up *Uploader) Stop() {
+	if up == nil || !atomic.CompareAndSwapInt32(&up.stopped, 0, 1) {
+		return
+	}
+
+	close(up.done)
+}
+
+func (up *Uploader) run() {
+	ticker := time.NewTicker(up.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			up.captureAndUpload()
+		case <-up.done:
+			return
+		}
+	}
+}
+
+func (up *Uploader) captureAndUpload() {
+	from := time.Now()
+	if buf := up.captureCpu(); buf != nil {
+		up.upload("cpu", buf, from)
+	}
+	for _, name := range []string{"heap", "goroutine"} {
+		if profile := pprof.Lookup(name); profile != nil {
+			var buf bytes.Buffer
+			if err := profile.WriteTo(&buf, 0); err != nil {
+				logx.Errorf("profile uploader: capture %s: %v", name, err)
+				continue
+			}
+			up.upload(name, &buf, from)
+		}
+	}
+}
+
+func (up *Uploader) captureCpu() *bytes.Buffer {
+	if !atomic.CompareAndSwapInt32(&cpuProfiling, 0, 1) {
+		// an on-demand capture is running, skip this round
+		return nil
+	}
+	defer atomic.StoreInt32(&cpuProfiling, 0)
+
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		logx.Errorf("profile uploader: start cpu profile: %v", err)
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(up.conf.CpuSeconds) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-up.done:
+	}
+	pprof.StopCPUProfile()
+
+	return &buf
+}
+
+func (up *Uploader) upload(kind string, buf *bytes.Buffer, from time.Time) {
+	query := url.Values{}
+	query.Set("name", fmt.Sprintf("%s.%s%s", up.conf.AppName, kind, formatTags(up.conf.Tags)))
+	query.Set("from", fmt.Sprint(from.Unix()))
+	query.Set("until", fmt.Sprint(time.Now().Unix()))
+	query.Set("format", "pprof")
+
+	target := strings.TrimSuffix(up.conf.Endpoint, "/") + "/ingest?" + query.Encode()
+	resp, err := up.client.Post(target, "application/octet-stream", buf)
+	if err != nil {
+		logx.Errorf("profile uploader: push %s: %v", kind, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		logx.Errorf("profile uploader: push %s: unexpected status %d", kind, resp.StatusCode)
+	}
+}
+
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+tags[k])
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
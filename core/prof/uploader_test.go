@@ -0,0 +1,50 @@
+package prof
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartUploaderDisabled(t *testing.T) {
+	assert.Nil(t, StartUploader(UploaderConf{}))
+	assert.Nil(t, StartUploader(UploaderConf{Enabled: true}))
+
+	var up *Uploader
+	assert.NotPanics(t, up.Stop)
+}
+
+func TestUploaderPush(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ingest", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("name"), "test-app.")
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	up := StartUploader(UploaderConf{
+		Enabled:    true,
+		Endpoint:   server.URL,
+		AppName:    "test-app",
+		Tags:       map[string]string{"env": "test"},
+		Interval:   time.Millisecond * 50,
+		CpuSeconds: 1,
+	})
+	assert.NotNil(t, up)
+	defer up.Stop()
+
+	for i := 0; i < 100 && atomic.LoadInt32(&requests) == 0; i++ {
+		time.Sleep(time.Millisecond * 50)
+	}
+	assert.True(t, atomic.LoadInt32(&requests) > 0)
+}
+
+func TestFormatTags(t *testing.T) {
+	assert.Equal(t, "", formatTags(nil))
+	assert.Equal(t, "{a=1,b=2}", formatTags(map[string]string{"b": "2", "a": "1"}))
+}
@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultBatchInterval = time.Second
+)
+
+type (
+	// BatchHandleFn handles a batch of messages, returning an error
+	// keeps the whole batch uncommitted.
+	BatchHandleFn func(messages []string) error
+
+	// BatchConsumerOption defines the method to customize a BatchConsumer.
+	BatchConsumerOption func(c *BatchConsumer)
+
+	// A BatchConsumer accumulates messages and hands them to the handler
+	// in batches of up to size messages, or whatever arrived within interval.
+	BatchConsumer struct {
+		handler  BatchHandleFn
+		size     int
+		interval time.Duration
+		split    bool
+		lock     sync.Mutex
+		buffer   []string
+		done     chan lang.PlaceholderType
+		stopOnce sync.Once
+	}
+)
+
+// NewBatchConsumer returns a BatchConsumer flushing to handler on size
+// or interval, whichever comes first. Call Stop to flush the remainder
+// when shutting down.
+func NewBatchConsumer(handler BatchHandleFn, opts ...BatchConsumerOption) *BatchConsumer {
+	c := &BatchConsumer{
+		handler:  handler,
+		size:     defaultBatchSize,
+		interval: defaultBatchInterval,
+		done:     make(chan lang.PlaceholderType),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	threading.GoSafe(c.loop)
+
+	return c
+}
+
+// WithBatchInterval customizes a BatchConsumer with the flush interval.
+func WithBatchInterval(interval time.Duration) BatchConsumerOption {
+	return func(c *BatchConsumer) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithBatchSize customizes a BatchConsumer with the max batch size.
+func WithBatchSize(size int) BatchConsumerOption {
+	return func(c *BatchConsumer) {
+		if size > 0 {
+			c.size = size
+		}
+	}
+}
+
+// WithBatchSplitOnFailure lets a failed batch be split in halves and
+// retried, isolating the failing messages instead of failing everything.
+func WithBatchSplitOnFailure() BatchConsumerOption {
+	return func(c *BatchConsumer) {
+		c.split = true
+	}
+}
+
+// Consume implements Consumer, the triggering call gets the batch error
+// when its message fills up a batch.
+func (c *BatchConsumer) Consume(message string) error {
+	c.lock.Lock()
+	c.buffer = append(c.buffer, message)
+	if len(c.buffer) < c.size {
+		c.lock.Unlock()
+		return nil
+	}
+
+	batch := c.take()
+	c.lock.Unlock()
+
+	return c.handleBatch(batch)
+}
+
+// Flush hands the buffered messages to the handler immediately.
+func (c *BatchConsumer) Flush() error {
+	c.lock.Lock()
+	batch := c.take()
+	c.lock.Unlock()
+
+	return c.handleBatch(batch)
+}
+
+// OnEvent implements Consumer, the buffer is flushed before partitions
+// move away on rebalances.
+func (c *BatchConsumer) OnEvent(event interface{}) {
+	if _, ok := event.(PartitionsRevokedEvent); ok {
+		if err := c.Flush(); err != nil {
+			logx.Errorf("Error on flushing batch on rebalance: %v", err)
+		}
+	}
+}
+
+// Stop flushes the remaining messages and stops the flush loop.
+func (c *BatchConsumer) Stop() error {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+
+	return c.Flush()
+}
+
+func (c *BatchConsumer) handleBatch(messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	err := c.handler(messages)
+	if err == nil || !c.split || len(messages) == 1 {
+		return err
+	}
+
+	// split the failed batch to isolate the poison messages
+	mid := len(messages) >> 1
+	if err := c.handleBatch(messages[:mid]); err != nil {
+		return err
+	}
+
+	return c.handleBatch(messages[mid:])
+}
+
+func (c *BatchConsumer) loop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(); err != nil {
+				logx.Errorf("Error on flushing batch: %v", err)
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// take returns the buffered messages, callers should hold the lock.
+func (c *BatchConsumer) take() []string {
+	batch := c.buffer
+	c.buffer = nil
+	return batch
+}
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type batchRecorder struct {
+	lock    sync.Mutex
+	batches [][]string
+	failOn  string
+}
+
+func (r *batchRecorder) handle(messages []string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, message := range messages {
+		if message == r.failOn {
+			return errors.New("bad message " + message)
+		}
+	}
+
+	r.batches = append(r.batches, append([]string(nil), messages...))
+	return nil
+}
+
+func (r *batchRecorder) all() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var all []string
+	for _, batch := range r.batches {
+		all = append(all, batch...)
+	}
+	return all
+}
+
+func TestBatchConsumerFlushOnSize(t *testing.T) {
+	recorder := new(batchRecorder)
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(3),
+		WithBatchInterval(time.Hour))
+	defer consumer.Stop()
+
+	assert.Nil(t, consumer.Consume("a"))
+	assert.Nil(t, consumer.Consume("b"))
+	assert.Nil(t, consumer.Consume("c"))
+
+	recorder.lock.Lock()
+	batches := len(recorder.batches)
+	recorder.lock.Unlock()
+	assert.Equal(t, 1, batches)
+	assert.Equal(t, []string{"a", "b", "c"}, recorder.all())
+}
+
+func TestBatchConsumerFlushOnInterval(t *testing.T) {
+	recorder := new(batchRecorder)
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(100),
+		WithBatchInterval(time.Millisecond*50))
+	defer consumer.Stop()
+
+	assert.Nil(t, consumer.Consume("a"))
+	assert.Nil(t, consumer.Consume("b"))
+
+	for i := 0; i < 50; i++ {
+		if len(recorder.all()) == 2 {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("batch not flushed by interval")
+}
+
+func TestBatchConsumerFailWholeBatch(t *testing.T) {
+	recorder := &batchRecorder{failOn: "b"}
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(2),
+		WithBatchInterval(time.Hour))
+	defer consumer.Stop()
+
+	assert.Nil(t, consumer.Consume("a"))
+	assert.NotNil(t, consumer.Consume("b"))
+	assert.Empty(t, recorder.all())
+}
+
+func TestBatchConsumerSplitOnFailure(t *testing.T) {
+	recorder := &batchRecorder{failOn: "b"}
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(2),
+		WithBatchInterval(time.Hour), WithBatchSplitOnFailure())
+	defer consumer.Stop()
+
+	assert.Nil(t, consumer.Consume("a"))
+	assert.NotNil(t, consumer.Consume("b"))
+	// the healthy message around the poison one still got through
+	assert.Equal(t, "a", strings.Join(recorder.all(), ","))
+
+	recorder.failOn = ""
+	assert.Nil(t, consumer.Consume("c"))
+	assert.Nil(t, consumer.Consume("d"))
+	assert.Equal(t, []string{"a", "c", "d"}, recorder.all())
+}
+
+func TestBatchConsumerStopFlushes(t *testing.T) {
+	recorder := new(batchRecorder)
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(100),
+		WithBatchInterval(time.Hour))
+	assert.Nil(t, consumer.Consume("a"))
+	assert.Nil(t, consumer.Stop())
+	assert.Equal(t, []string{"a"}, recorder.all())
+}
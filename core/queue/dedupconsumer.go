@@ -0,0 +1,121 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+const (
+	defaultDedupWindow  = time.Hour * 24
+	processedKeyPrefix  = "queue:processed:"
+	processedPlaceholer = "1"
+)
+
+type (
+	// A ProcessedStore tracks which message ids are already processed.
+	ProcessedStore interface {
+		// Seen checks whether the message id was processed before.
+		Seen(id string) (bool, error)
+		// Mark records the message id as processed.
+		Mark(id string) error
+	}
+
+	// MessageIdFn extracts the dedup id from a message.
+	MessageIdFn func(message string) string
+
+	// DedupConsumerOption defines the method to customize a dedupConsumer.
+	DedupConsumerOption func(c *dedupConsumer)
+
+	dedupConsumer struct {
+		consumer  Consumer
+		store     ProcessedStore
+		messageId MessageIdFn
+	}
+
+	redisProcessedStore struct {
+		store  *redis.Redis
+		window time.Duration
+	}
+)
+
+// NewDedupConsumer returns a Consumer that skips messages already recorded
+// in the given store, and marks them after successful handling.
+// This gives effectively-once processing across redeliveries,
+// not true exactly-once, a crash between handling and marking still
+// redelivers the message.
+func NewDedupConsumer(consumer Consumer, store ProcessedStore,
+	opts ...DedupConsumerOption) Consumer {
+	c := &dedupConsumer{
+		consumer: consumer,
+		store:    store,
+		messageId: func(message string) string {
+			return message
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithMessageId customizes a dedupConsumer with the message id extractor,
+// the whole message is used as the id by default.
+func WithMessageId(fn MessageIdFn) DedupConsumerOption {
+	return func(c *dedupConsumer) {
+		c.messageId = fn
+	}
+}
+
+// NewRedisProcessedStore returns a ProcessedStore backed by redis keys
+// expiring after the given window.
+func NewRedisProcessedStore(store *redis.Redis, window time.Duration) ProcessedStore {
+	if window <= 0 {
+		window = defaultDedupWindow
+	}
+
+	return redisProcessedStore{
+		store:  store,
+		window: window,
+	}
+}
+
+func (c *dedupConsumer) Consume(message string) error {
+	id := c.messageId(message)
+	if len(id) > 0 {
+		seen, err := c.store.Seen(id)
+		if err != nil {
+			// fail open, better to double-process than to drop
+			logx.Errorf("Error on checking processed store for %q: %v", id, err)
+		} else if seen {
+			return nil
+		}
+	}
+
+	if err := c.consumer.Consume(message); err != nil {
+		return err
+	}
+
+	if len(id) > 0 {
+		if err := c.store.Mark(id); err != nil {
+			logx.Errorf("Error on marking %q as processed: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *dedupConsumer) OnEvent(event interface{}) {
+	c.consumer.OnEvent(event)
+}
+
+func (s redisProcessedStore) Seen(id string) (bool, error) {
+	return s.store.Exists(processedKeyPrefix + id)
+}
+
+func (s redisProcessedStore) Mark(id string) error {
+	return s.store.Setex(processedKeyPrefix+id, processedPlaceholer,
+		int(s.window/time.Second))
+}
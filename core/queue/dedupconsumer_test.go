@@ -0,0 +1,80 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+type countConsumer struct {
+	calls int
+	err   error
+}
+
+func (c *countConsumer) Consume(message string) error {
+	c.calls++
+	return c.err
+}
+
+func (c *countConsumer) OnEvent(event interface{}) {
+}
+
+func TestDedupConsumerSkipsProcessed(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	inner := new(countConsumer)
+	consumer := NewDedupConsumer(inner, NewRedisProcessedStore(store, time.Minute))
+	assert.Nil(t, consumer.Consume("payment-1"))
+	assert.Nil(t, consumer.Consume("payment-1"))
+	assert.Nil(t, consumer.Consume("payment-2"))
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestDedupConsumerFailedNotMarked(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	inner := &countConsumer{err: errors.New("handler failure")}
+	consumer := NewDedupConsumer(inner, NewRedisProcessedStore(store, time.Minute))
+	assert.NotNil(t, consumer.Consume("payment-1"))
+	inner.err = nil
+	// a failed message is redelivered and processed again
+	assert.Nil(t, consumer.Consume("payment-1"))
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestDedupConsumerCustomId(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	inner := new(countConsumer)
+	consumer := NewDedupConsumer(inner, NewRedisProcessedStore(store, time.Minute),
+		WithMessageId(func(message string) string {
+			return message[:1]
+		}))
+	assert.Nil(t, consumer.Consume("a-one"))
+	assert.Nil(t, consumer.Consume("a-two"))
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestRedisProcessedStore(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	processed := NewRedisProcessedStore(store, 0)
+	seen, err := processed.Seen("id-1")
+	assert.Nil(t, err)
+	assert.False(t, seen)
+	assert.Nil(t, processed.Mark("id-1"))
+	seen, err = processed.Seen("id-1")
+	assert.Nil(t, err)
+	assert.True(t, seen)
+}
@@ -13,7 +13,10 @@ import (
 	"github.com/tal-tech/go-zero/core/timex"
 )
 
-const queueName = "queue"
+const (
+	queueName             = "queue"
+	rebalancePollInterval = time.Millisecond * 100
+)
 
 type (
 	// A Queue is a message queue.
@@ -27,6 +30,8 @@ type (
 		producerCount        int
 		consumerCount        int
 		active               int32
+		rebalancing          int32
+		rebalanceHooks       RebalanceHooks
 		channel              chan string
 		quit                 chan struct{}
 		listeners            []Listener
@@ -88,6 +93,34 @@ func (q *Queue) Broadcast(message interface{}) {
 	}()
 }
 
+// AssignPartitions notifies q that the given partitions were assigned,
+// invokes the OnPartitionAssigned hook and resumes fetching.
+func (q *Queue) AssignPartitions(partitions []int) {
+	if q.rebalanceHooks.OnPartitionAssigned != nil {
+		q.rebalanceHooks.OnPartitionAssigned(partitions)
+	}
+	q.broadcastSync(PartitionsAssignedEvent{
+		Partitions: partitions,
+	})
+	atomic.StoreInt32(&q.rebalancing, 0)
+}
+
+// RevokePartitions notifies q that the given partitions are about to move,
+// pauses fetching, drains the in-flight messages, and invokes the
+// OnPartitionRevoked hook to let callers commit offsets.
+func (q *Queue) RevokePartitions(partitions []int) {
+	atomic.StoreInt32(&q.rebalancing, 1)
+	// synchronous delivery doubles as a drain barrier, a consumer only
+	// receives events between messages, so once every consumer took the
+	// event, the messages fetched before the revoke are all handled.
+	q.broadcastSync(PartitionsRevokedEvent{
+		Partitions: partitions,
+	})
+	if q.rebalanceHooks.OnPartitionRevoked != nil {
+		q.rebalanceHooks.OnPartitionRevoked(partitions)
+	}
+}
+
 // SetName sets the name of q.
 func (q *Queue) SetName(name string) {
 	q.name = name
@@ -104,6 +137,12 @@ func (q *Queue) SetNumProducer(count int) {
 	q.producerCount = count
 }
 
+// SetRebalanceHooks sets the rebalance hooks of q,
+// call it before Start.
+func (q *Queue) SetRebalanceHooks(hooks RebalanceHooks) {
+	q.rebalanceHooks = hooks
+}
+
 // Start starts q.
 func (q *Queue) Start() {
 	q.startProducers(q.producerCount)
@@ -119,6 +158,17 @@ func (q *Queue) Stop() {
 	close(q.quit)
 }
 
+// broadcastSync delivers message to all event channels, and waits until
+// every consumer has taken it.
+func (q *Queue) broadcastSync(message interface{}) {
+	q.eventLock.Lock()
+	defer q.eventLock.Unlock()
+
+	for _, channel := range q.eventChannels {
+		channel <- message
+	}
+}
+
 func (q *Queue) consume(eventChan chan interface{}) {
 	var consumer Consumer
 
@@ -194,6 +244,11 @@ func (q *Queue) produce() {
 			logx.Info("Quitting producer")
 			return
 		default:
+			if atomic.LoadInt32(&q.rebalancing) == 1 {
+				time.Sleep(rebalancePollInterval)
+				continue
+			}
+
 			if v, ok := q.produceOne(producer); ok {
 				q.channel <- v
 			}
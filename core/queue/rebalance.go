@@ -0,0 +1,26 @@
+package queue
+
+type (
+	// A PartitionsRevokedEvent is broadcast to consumers before partitions
+	// move away, so they can flush buffers and commit their offsets.
+	PartitionsRevokedEvent struct {
+		Partitions []int
+	}
+
+	// A PartitionsAssignedEvent is broadcast to consumers after new
+	// partitions are assigned.
+	PartitionsAssignedEvent struct {
+		Partitions []int
+	}
+
+	// RebalanceHooks holds the callbacks invoked on consumer-group
+	// rebalances, both are optional.
+	RebalanceHooks struct {
+		// OnPartitionRevoked is called after consumers drained,
+		// commit offsets here before the partitions move.
+		OnPartitionRevoked func(partitions []int)
+		// OnPartitionAssigned is called before fetching resumes
+		// on the new assignment.
+		OnPartitionAssigned func(partitions []int)
+	}
+)
@@ -0,0 +1,65 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueRebalance(t *testing.T) {
+	producer := newMockedProducer(rounds)
+	consumer := newMockedConsumer()
+	consumer.wait.Add(consumers)
+	q := NewQueue(func() (Producer, error) {
+		return producer, nil
+	}, func() (Consumer, error) {
+		return consumer, nil
+	})
+	q.SetNumConsumer(consumers)
+	q.SetNumProducer(1)
+
+	var lock sync.Mutex
+	var revoked, assigned []int
+	q.SetRebalanceHooks(RebalanceHooks{
+		OnPartitionRevoked: func(partitions []int) {
+			lock.Lock()
+			revoked = partitions
+			lock.Unlock()
+		},
+		OnPartitionAssigned: func(partitions []int) {
+			lock.Lock()
+			assigned = partitions
+			lock.Unlock()
+		},
+	})
+
+	go func() {
+		producer.wait.Wait()
+		q.RevokePartitions([]int{0, 1})
+		q.AssignPartitions([]int{2})
+		q.Stop()
+	}()
+	q.Start()
+
+	assert.Equal(t, int32(rounds), atomic.LoadInt32(&consumer.count))
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []int{0, 1}, revoked)
+	assert.Equal(t, []int{2}, assigned)
+}
+
+func TestBatchConsumerFlushOnRevoke(t *testing.T) {
+	recorder := new(batchRecorder)
+	consumer := NewBatchConsumer(recorder.handle, WithBatchSize(100),
+		WithBatchInterval(time.Hour))
+	defer consumer.Stop()
+
+	assert.Nil(t, consumer.Consume("a"))
+	consumer.OnEvent(PartitionsRevokedEvent{Partitions: []int{0}})
+	assert.Equal(t, []string{"a"}, recorder.all())
+	// unrelated events don't flush
+	consumer.OnEvent("pause")
+}
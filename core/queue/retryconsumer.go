@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const (
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = time.Millisecond * 100
+)
+
+type (
+	// DeadLetterFn handles messages that failed all retry attempts.
+	DeadLetterFn func(message string, err error)
+
+	// RetryConsumerOption defines the method to customize a retryConsumer.
+	RetryConsumerOption func(c *retryConsumer)
+
+	retryConsumer struct {
+		consumer    Consumer
+		maxAttempts int
+		backoff     time.Duration
+		deadLetter  DeadLetterFn
+	}
+)
+
+// NewRetryConsumer returns a Consumer that retries failed messages with
+// backoff, routing them to the dead-letter handler after maxAttempts,
+// so poison messages don't block the queue. A message that succeeds on a
+// retry is not processed again.
+func NewRetryConsumer(consumer Consumer, opts ...RetryConsumerOption) Consumer {
+	c := &retryConsumer{
+		consumer:    consumer,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithDeadLetter customizes a retryConsumer with the dead-letter handler.
+func WithDeadLetter(fn DeadLetterFn) RetryConsumerOption {
+	return func(c *retryConsumer) {
+		c.deadLetter = fn
+	}
+}
+
+// WithDeadLetterPusher customizes a retryConsumer to push dead messages
+// into the given pusher, typically another queue.
+func WithDeadLetterPusher(pusher Pusher) RetryConsumerOption {
+	return func(c *retryConsumer) {
+		c.deadLetter = func(message string, err error) {
+			if err := pusher.Push(message); err != nil {
+				logx.Errorf("Error on pushing dead letter to %s: %v", pusher.Name(), err)
+			}
+		}
+	}
+}
+
+// WithMaxAttempts customizes a retryConsumer with maxAttempts per message.
+func WithMaxAttempts(attempts int) RetryConsumerOption {
+	return func(c *retryConsumer) {
+		if attempts > 0 {
+			c.maxAttempts = attempts
+		}
+	}
+}
+
+// WithRetryBackoff customizes a retryConsumer with the base backoff
+// between attempts, doubling each attempt.
+func WithRetryBackoff(backoff time.Duration) RetryConsumerOption {
+	return func(c *retryConsumer) {
+		c.backoff = backoff
+	}
+}
+
+func (c *retryConsumer) Consume(message string) error {
+	var err error
+	backoff := c.backoff
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		if err = c.consumer.Consume(message); err == nil {
+			return nil
+		}
+
+		if attempt < c.maxAttempts {
+			logx.Errorf("Error on consuming %q on attempt %d: %v, retrying", message, attempt, err)
+			time.Sleep(backoff)
+			backoff <<= 1
+		}
+	}
+
+	if c.deadLetter != nil {
+		c.deadLetter(message, err)
+		return nil
+	}
+
+	return err
+}
+
+func (c *retryConsumer) OnEvent(event interface{}) {
+	c.consumer.OnEvent(event)
+}
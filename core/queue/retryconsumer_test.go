@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flakyConsumer struct {
+	failures int
+	calls    int
+	events   []interface{}
+}
+
+func (c *flakyConsumer) Consume(message string) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return errors.New("transient failure")
+	}
+
+	return nil
+}
+
+func (c *flakyConsumer) OnEvent(event interface{}) {
+	c.events = append(c.events, event)
+}
+
+type recordPusher struct {
+	messages []string
+}
+
+func (p *recordPusher) Name() string {
+	return "dead-letters"
+}
+
+func (p *recordPusher) Push(message string) error {
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+func TestRetryConsumerSucceedsOnRetry(t *testing.T) {
+	inner := &flakyConsumer{failures: 2}
+	consumer := NewRetryConsumer(inner, WithMaxAttempts(3),
+		WithRetryBackoff(time.Millisecond))
+	assert.Nil(t, consumer.Consume("order-1"))
+	// succeeded on the third attempt, not processed again
+	assert.Equal(t, 3, inner.calls)
+}
+
+func TestRetryConsumerDeadLetter(t *testing.T) {
+	inner := &flakyConsumer{failures: 10}
+	var deadMessage string
+	var deadErr error
+	consumer := NewRetryConsumer(inner, WithMaxAttempts(2),
+		WithRetryBackoff(time.Millisecond),
+		WithDeadLetter(func(message string, err error) {
+			deadMessage = message
+			deadErr = err
+		}))
+	assert.Nil(t, consumer.Consume("poison"))
+	assert.Equal(t, 2, inner.calls)
+	assert.Equal(t, "poison", deadMessage)
+	assert.NotNil(t, deadErr)
+}
+
+func TestRetryConsumerDeadLetterPusher(t *testing.T) {
+	inner := &flakyConsumer{failures: 10}
+	pusher := new(recordPusher)
+	consumer := NewRetryConsumer(inner, WithMaxAttempts(1),
+		WithDeadLetterPusher(pusher))
+	assert.Nil(t, consumer.Consume("poison"))
+	assert.Equal(t, []string{"poison"}, pusher.messages)
+}
+
+func TestRetryConsumerNoDeadLetter(t *testing.T) {
+	inner := &flakyConsumer{failures: 10}
+	consumer := NewRetryConsumer(inner, WithMaxAttempts(2),
+		WithRetryBackoff(time.Millisecond))
+	assert.NotNil(t, consumer.Consume("poison"))
+}
+
+func TestRetryConsumerOnEvent(t *testing.T) {
+	inner := new(flakyConsumer)
+	consumer := NewRetryConsumer(inner)
+	consumer.OnEvent("pause")
+	assert.Equal(t, []interface{}{"pause"}, inner.events)
+}
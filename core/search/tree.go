@@ -3,22 +3,33 @@ package search
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"sync"
 )
 
 const (
 	colon = ':'
 	slash = '/'
+	star  = '*'
 )
 
 var (
+	regexCache = make(map[string]*regexp.Regexp)
+	regexLock  sync.RWMutex
+
 	// errDupItem means adding duplicated item.
 	errDupItem = errors.New("duplicated item")
 	// errDupSlash means item is started with more than one slash.
 	errDupSlash = errors.New("duplicated slash")
 	// errEmptyItem means adding empty item.
 	errEmptyItem = errors.New("empty item")
+	// errInvalidRegex means a param constraint doesn't compile.
+	errInvalidRegex = errors.New("invalid regex constraint")
 	// errInvalidState means search tree is in an invalid state.
 	errInvalidState = errors.New("search tree is in an invalid state")
+	// errTrailingCatchAll means a catch-all segment isn't the last one.
+	errTrailingCatchAll = errors.New("catch-all must be the last segment")
 	// errNotFromRoot means path is not starting with slash.
 	errNotFromRoot = errors.New("path should start with /")
 
@@ -102,7 +113,7 @@ func (t *Tree) next(n *node, route string, result *Result) bool {
 		}
 
 		token := route[:i]
-		return n.forEach(func(k string, v *node) bool {
+		if n.forEach(func(k string, v *node) bool {
 			r := match(k, token)
 			if !r.found || !t.next(v, route[i+1:], result) {
 				return false
@@ -112,10 +123,14 @@ func (t *Tree) next(n *node, route string, result *Result) bool {
 			}
 
 			return true
-		})
+		}) {
+			return true
+		}
+
+		return n.catchAll(route, result)
 	}
 
-	return n.forEach(func(k string, v *node) bool {
+	if n.forEach(func(k string, v *node) bool {
 		if r := match(k, route); r.found && v.item != nil {
 			result.Item = v.item
 			if r.named {
@@ -126,7 +141,26 @@ func (t *Tree) next(n *node, route string, result *Result) bool {
 		}
 
 		return false
-	})
+	}) {
+		return true
+	}
+
+	return n.catchAll(route, result)
+}
+
+func (nd *node) catchAll(route string, result *Result) bool {
+	for k, v := range nd.children[1] {
+		if k[0] == star && v.item != nil {
+			result.Item = v.item
+			if len(k) > 1 {
+				addParam(result, k[1:], route)
+			}
+
+			return true
+		}
+	}
+
+	return false
 }
 
 func (nd *node) forEach(fn func(string, *node) bool) bool {
@@ -142,7 +176,7 @@ func (nd *node) forEach(fn func(string, *node) bool) bool {
 }
 
 func (nd *node) getChildren(route string) map[string]*node {
-	if len(route) > 0 && route[0] == colon {
+	if len(route) > 0 && (route[0] == colon || route[0] == star) {
 		return nd.children[1]
 	}
 
@@ -169,6 +203,13 @@ func add(nd *node, route string, item interface{}) error {
 		}
 
 		token := route[:i]
+		if len(token) > 0 && token[0] == star {
+			return errTrailingCatchAll
+		}
+		if err := validateSegment(token); err != nil {
+			return err
+		}
+
 		children := nd.getChildren(token)
 		if child, ok := children[token]; ok {
 			if child != nil {
@@ -183,6 +224,10 @@ func add(nd *node, route string, item interface{}) error {
 		return add(child, route[i+1:], item)
 	}
 
+	if err := validateSegment(route); err != nil {
+		return err
+	}
+
 	children := nd.getChildren(route)
 	if child, ok := children[route]; ok {
 		if child.item != nil {
@@ -215,6 +260,19 @@ func duplicatedSlash(item string) error {
 
 func match(pat, token string) innerResult {
 	if pat[0] == colon {
+		if key, expr, ok := splitConstraint(pat); ok {
+			if !matchRegex(expr, token) {
+				return innerResult{}
+			}
+
+			return innerResult{
+				key:   key,
+				value: token,
+				named: true,
+				found: true,
+			}
+		}
+
 		return innerResult{
 			key:   pat[1:],
 			value: token,
@@ -228,6 +286,50 @@ func match(pat, token string) innerResult {
 	}
 }
 
+func matchRegex(expr, token string) bool {
+	regexLock.RLock()
+	re, ok := regexCache[expr]
+	regexLock.RUnlock()
+	if !ok {
+		compiled, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return false
+		}
+
+		regexLock.Lock()
+		regexCache[expr] = compiled
+		regexLock.Unlock()
+		re = compiled
+	}
+
+	return re.MatchString(token)
+}
+
+// splitConstraint splits a pattern like :id{[0-9]+} into its param name
+// and regex constraint.
+func splitConstraint(pat string) (key, expr string, ok bool) {
+	idx := strings.IndexByte(pat, '{')
+	if idx <= 1 || pat[len(pat)-1] != '}' {
+		return "", "", false
+	}
+
+	return pat[1:idx], pat[idx+1 : len(pat)-1], true
+}
+
+func validateSegment(token string) error {
+	if len(token) == 0 || token[0] != colon {
+		return nil
+	}
+
+	if _, expr, ok := splitConstraint(token); ok {
+		if _, err := regexp.Compile("^(?:" + expr + ")$"); err != nil {
+			return errInvalidRegex
+		}
+	}
+
+	return nil
+}
+
 func newNode(item interface{}) *node {
 	return &node{
 		item: item,
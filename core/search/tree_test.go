@@ -185,3 +185,70 @@ func TestSearchInvalidItem(t *testing.T) {
 	err := tree.Add("/", nil)
 	assert.Equal(t, errEmptyItem, err)
 }
+
+func TestSearchRegexParam(t *testing.T) {
+	tree := NewTree()
+	assert.Nil(t, tree.Add("/users/:id{[0-9]+}", 1))
+	assert.Nil(t, tree.Add("/orders/:code{[a-z]{3}-[0-9]+}", 2))
+
+	result, ok := tree.Search("/users/123")
+	assert.True(t, ok)
+	assert.Equal(t, 1, result.Item.(int))
+	assert.Equal(t, map[string]string{"id": "123"}, result.Params)
+
+	_, ok = tree.Search("/users/abc")
+	assert.False(t, ok)
+
+	result, ok = tree.Search("/orders/abc-42")
+	assert.True(t, ok)
+	assert.Equal(t, 2, result.Item.(int))
+	assert.Equal(t, map[string]string{"code": "abc-42"}, result.Params)
+
+	_, ok = tree.Search("/orders/abcd-42")
+	assert.False(t, ok)
+}
+
+func TestSearchRegexParamNotLast(t *testing.T) {
+	tree := NewTree()
+	assert.Nil(t, tree.Add("/users/:id{[0-9]+}/posts", 1))
+
+	result, ok := tree.Search("/users/7/posts")
+	assert.True(t, ok)
+	assert.Equal(t, 1, result.Item.(int))
+	assert.Equal(t, map[string]string{"id": "7"}, result.Params)
+
+	_, ok = tree.Search("/users/seven/posts")
+	assert.False(t, ok)
+}
+
+func TestAddInvalidRegex(t *testing.T) {
+	tree := NewTree()
+	assert.Equal(t, errInvalidRegex, tree.Add("/users/:id{[0-9+}", 1))
+	assert.Equal(t, errInvalidRegex, tree.Add("/users/:id{[0-9+}/posts", 1))
+}
+
+func TestSearchCatchAll(t *testing.T) {
+	tree := NewTree()
+	assert.Nil(t, tree.Add("/files/*path", 1))
+	assert.Nil(t, tree.Add("/files/latest", 2))
+
+	result, ok := tree.Search("/files/a/b/c.txt")
+	assert.True(t, ok)
+	assert.Equal(t, 1, result.Item.(int))
+	assert.Equal(t, map[string]string{"path": "a/b/c.txt"}, result.Params)
+
+	result, ok = tree.Search("/files/readme.md")
+	assert.True(t, ok)
+	assert.Equal(t, 1, result.Item.(int))
+	assert.Equal(t, map[string]string{"path": "readme.md"}, result.Params)
+
+	// static routes still win over the catch-all
+	result, ok = tree.Search("/files/latest")
+	assert.True(t, ok)
+	assert.Equal(t, 2, result.Item.(int))
+}
+
+func TestAddCatchAllNotLast(t *testing.T) {
+	tree := NewTree()
+	assert.Equal(t, errTrailingCatchAll, tree.Add("/files/*path/extra", 1))
+}
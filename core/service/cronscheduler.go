@@ -0,0 +1,235 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+type (
+	// CronOption defines the method to customize a CronScheduler.
+	CronOption func(cs *CronScheduler)
+
+	// CronJobOption defines the method to customize a cron job.
+	CronJobOption func(job *cronJob)
+
+	// A CronScheduler runs registered jobs on their cron schedules.
+	// It implements Service, so it can be added to a ServiceGroup.
+	// Jobs run with per-job panic recovery, schedules resolve in the
+	// configured location, minute granularity.
+	CronScheduler struct {
+		location *time.Location
+		jobs     []*cronJob
+		done     chan struct{}
+		stopOnce sync.Once
+	}
+
+	cronJob struct {
+		schedule  cronSchedule
+		run       func()
+		noOverlap bool
+		running   int32
+	}
+
+	// a cronSchedule holds one bitmask per cron field.
+	cronSchedule struct {
+		minutes uint64
+		hours   uint64
+		doms    uint64
+		months  uint64
+		dows    uint64
+		anyDom  bool
+		anyDow  bool
+	}
+)
+
+// NewCronScheduler returns a CronScheduler.
+func NewCronScheduler(opts ...CronOption) *CronScheduler {
+	cs := &CronScheduler{
+		location: time.Local,
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	return cs
+}
+
+// WithCronLocation customizes a CronScheduler to resolve schedules
+// in the given timezone.
+func WithCronLocation(location *time.Location) CronOption {
+	return func(cs *CronScheduler) {
+		cs.location = location
+	}
+}
+
+// WithNoOverlap customizes a cron job to skip a scheduled run
+// while the previous run is still going.
+func WithNoOverlap() CronJobOption {
+	return func(job *cronJob) {
+		job.noOverlap = true
+	}
+}
+
+// AddJob registers job to run on the given five-field cron spec,
+// like "*/5 * * * *". Call it before Start.
+func (cs *CronScheduler) AddJob(spec string, job func(), opts ...CronJobOption) error {
+	schedule, err := parseCronSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	cj := &cronJob{
+		schedule: schedule,
+		run:      job,
+	}
+	for _, opt := range opts {
+		opt(cj)
+	}
+	cs.jobs = append(cs.jobs, cj)
+
+	return nil
+}
+
+// Start starts the scheduler, blocking until Stop is called.
+func (cs *CronScheduler) Start() {
+	for {
+		now := time.Now().In(cs.location)
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-cs.done:
+			timer.Stop()
+			return
+		case fired := <-timer.C:
+			cs.runDue(fired.In(cs.location))
+		}
+	}
+}
+
+// Stop stops the scheduler.
+func (cs *CronScheduler) Stop() {
+	cs.stopOnce.Do(func() {
+		close(cs.done)
+	})
+}
+
+func (cs *CronScheduler) runDue(now time.Time) {
+	for _, job := range cs.jobs {
+		if !job.schedule.matches(now) {
+			continue
+		}
+
+		job := job
+		if job.noOverlap && !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+			continue
+		}
+		threading.GoSafe(func() {
+			defer atomic.StoreInt32(&job.running, 0)
+			job.run()
+		})
+	}
+}
+
+func (s cronSchedule) matches(t time.Time) bool {
+	if s.minutes&(1<<uint(t.Minute())) == 0 {
+		return false
+	}
+	if s.hours&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	if s.months&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.doms&(1<<uint(t.Day())) != 0
+	dowMatch := s.dows&(1<<uint(t.Weekday())) != 0
+	// per cron convention, when both day fields are restricted either may match
+	if !s.anyDom && !s.anyDow {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+func parseCronSpec(spec string) (cronSchedule, error) {
+	var schedule cronSchedule
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return schedule, fmt.Errorf("cron spec %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var err error
+	if schedule.minutes, _, err = parseCronField(fields[0], 0, 59); err != nil {
+		return schedule, fmt.Errorf("cron spec %q minute: %w", spec, err)
+	}
+	if schedule.hours, _, err = parseCronField(fields[1], 0, 23); err != nil {
+		return schedule, fmt.Errorf("cron spec %q hour: %w", spec, err)
+	}
+	if schedule.doms, schedule.anyDom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return schedule, fmt.Errorf("cron spec %q day of month: %w", spec, err)
+	}
+	if schedule.months, _, err = parseCronField(fields[3], 1, 12); err != nil {
+		return schedule, fmt.Errorf("cron spec %q month: %w", spec, err)
+	}
+	if schedule.dows, schedule.anyDow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return schedule, fmt.Errorf("cron spec %q day of week: %w", spec, err)
+	}
+	// 7 means sunday too
+	if schedule.dows&(1<<7) != 0 {
+		schedule.dows |= 1
+	}
+
+	return schedule, nil
+}
+
+// parseCronField parses one cron field into a bitmask,
+// reporting whether the field is the unrestricted "*".
+func parseCronField(field string, min, max int) (uint64, bool, error) {
+	var mask uint64
+	any := field == "*"
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart := part
+		step := 1
+		if idx := strings.IndexByte(part, '/'); idx >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[idx+1:]); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("bad step in %q", part)
+			}
+			rangePart = part[:idx]
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, false, fmt.Errorf("bad value in %q", part)
+			}
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, false, fmt.Errorf("bad value in %q", part)
+				}
+			} else {
+				hi = lo
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, false, fmt.Errorf("value out of range [%d, %d] in %q", min, max, part)
+		}
+
+		for i := lo; i <= hi; i += step {
+			mask |= 1 << uint(i)
+		}
+	}
+
+	return mask, any, nil
+}
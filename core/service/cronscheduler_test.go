@@ -0,0 +1,173 @@
+package service
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		ok   bool
+	}{
+		{"* * * * *", true},
+		{"*/5 * * * *", true},
+		{"0 0 1 1 0", true},
+		{"0-30/10,45 8-18 * * 1-5", true},
+		{"0 0 * * 7", true},
+		{"* * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"*/0 * * * *", false},
+		{"a * * * *", false},
+		{"5-2 * * * *", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			_, err := parseCronSpec(test.spec)
+			if test.ok {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	// Tuesday 2020-06-16 10:30
+	at := time.Date(2020, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec  string
+		match bool
+	}{
+		{"* * * * *", true},
+		{"30 10 * * *", true},
+		{"*/15 * * * *", true},
+		{"30 10 16 6 *", true},
+		{"30 10 * * 2", true},
+		{"31 10 * * *", false},
+		{"30 11 * * *", false},
+		{"30 10 17 * *", false},
+		{"30 10 * 7 *", false},
+		{"30 10 * * 3", false},
+		// both day fields restricted, either matches
+		{"30 10 1 * 2", true},
+		{"30 10 16 * 5", true},
+		{"30 10 1 * 5", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			schedule, err := parseCronSpec(test.spec)
+			assert.Nil(t, err)
+			assert.Equal(t, test.match, schedule.matches(at))
+		})
+	}
+}
+
+func TestCronScheduleSundayAlias(t *testing.T) {
+	// Sunday 2020-06-14
+	at := time.Date(2020, time.June, 14, 0, 0, 0, 0, time.UTC)
+	schedule, err := parseCronSpec("0 0 * * 7")
+	assert.Nil(t, err)
+	assert.True(t, schedule.matches(at))
+}
+
+func TestCronSchedulerAddJob(t *testing.T) {
+	cs := NewCronScheduler(WithCronLocation(time.UTC))
+	assert.Nil(t, cs.AddJob("* * * * *", func() {}))
+	assert.NotNil(t, cs.AddJob("bad spec", func() {}))
+}
+
+func TestCronSchedulerRunDue(t *testing.T) {
+	cs := NewCronScheduler(WithCronLocation(time.UTC))
+	var count int32
+	assert.Nil(t, cs.AddJob("* * * * *", func() {
+		atomic.AddInt32(&count, 1)
+	}))
+	assert.Nil(t, cs.AddJob("30 10 * * *", func() {
+		atomic.AddInt32(&count, 100)
+	}))
+
+	cs.runDue(time.Date(2020, time.June, 16, 9, 0, 0, 0, time.UTC))
+	waitForValue(t, &count, 1)
+	cs.runDue(time.Date(2020, time.June, 16, 10, 30, 0, 0, time.UTC))
+	waitForValue(t, &count, 102)
+}
+
+func TestCronSchedulerNoOverlap(t *testing.T) {
+	cs := NewCronScheduler(WithCronLocation(time.UTC))
+	blocker := make(chan struct{})
+	var count int32
+	assert.Nil(t, cs.AddJob("* * * * *", func() {
+		atomic.AddInt32(&count, 1)
+		<-blocker
+	}, WithNoOverlap()))
+
+	at := time.Date(2020, time.June, 16, 9, 0, 0, 0, time.UTC)
+	cs.runDue(at)
+	waitForValue(t, &count, 1)
+	// previous run still going, this tick is skipped
+	cs.runDue(at.Add(time.Minute))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	close(blocker)
+	waitForValue(t, &cs.jobs[0].running, 0)
+	cs.runDue(at.Add(2 * time.Minute))
+	waitForValue(t, &count, 2)
+}
+
+func TestCronSchedulerPanicRecovery(t *testing.T) {
+	cs := NewCronScheduler(WithCronLocation(time.UTC))
+	var count int32
+	assert.Nil(t, cs.AddJob("* * * * *", func() {
+		atomic.AddInt32(&count, 1)
+		panic("boom")
+	}))
+
+	at := time.Date(2020, time.June, 16, 9, 0, 0, 0, time.UTC)
+	cs.runDue(at)
+	waitForValue(t, &count, 1)
+	cs.runDue(at.Add(time.Minute))
+	waitForValue(t, &count, 2)
+}
+
+func TestCronSchedulerStartStop(t *testing.T) {
+	cs := NewCronScheduler()
+	done := make(chan struct{})
+	go func() {
+		cs.Start()
+		close(done)
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	assert.NotPanics(t, func() {
+		cs.Stop()
+		cs.Stop()
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start didn't return after Stop")
+	}
+}
+
+func waitForValue(t *testing.T, p *int32, expect int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 2)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(p) == expect {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d, got %d", expect, atomic.LoadInt32(p))
+}
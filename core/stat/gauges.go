@@ -0,0 +1,146 @@
+package stat
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+var (
+	gaugeLock    sync.Mutex
+	gaugeEntries = make(map[string]map[string]GaugeFn)
+	gaugeWriter  GaugeWriter
+	gaugeOnce    sync.Once
+)
+
+type (
+	// GaugeFn returns the current value of a gauge, sampled on each report.
+	GaugeFn func() float64
+
+	// A GaugeReport carries the sampled gauges of one group.
+	GaugeReport struct {
+		Name      string             `json:"name"`
+		Timestamp int64              `json:"tm"`
+		Pid       int                `json:"pid"`
+		Gauges    map[string]float64 `json:"gauges"`
+	}
+
+	// A GaugeWriter writes gauge reports, like Writer does for stat reports.
+	GaugeWriter interface {
+		WriteGauge(report *GaugeReport) error
+	}
+)
+
+// RegisterGauge registers fn as the gauge name under group, sampled and
+// logged periodically together with the QPS reports. Subsystems like
+// caches or breakers report hit rates and savings through it instead of
+// each logging its own format.
+func RegisterGauge(group, name string, fn GaugeFn) {
+	gaugeLock.Lock()
+	defer gaugeLock.Unlock()
+
+	gauges, ok := gaugeEntries[group]
+	if !ok {
+		gauges = make(map[string]GaugeFn)
+		gaugeEntries[group] = gauges
+	}
+	gauges[name] = fn
+
+	gaugeOnce.Do(func() {
+		threading.GoSafe(gaugeLoop)
+	})
+}
+
+// SetGaugeWriter sets the writer that gauge reports are written to.
+func SetGaugeWriter(writer GaugeWriter) {
+	gaugeLock.Lock()
+	gaugeWriter = writer
+	gaugeLock.Unlock()
+}
+
+// UnregisterGauge removes the gauge name under group.
+func UnregisterGauge(group, name string) {
+	gaugeLock.Lock()
+	defer gaugeLock.Unlock()
+
+	if gauges, ok := gaugeEntries[group]; ok {
+		delete(gauges, name)
+		if len(gauges) == 0 {
+			delete(gaugeEntries, group)
+		}
+	}
+}
+
+func collectGaugeReports() []*GaugeReport {
+	gaugeLock.Lock()
+	defer gaugeLock.Unlock()
+
+	var reports []*GaugeReport
+	for group, gauges := range gaugeEntries {
+		report := &GaugeReport{
+			Name:      group,
+			Timestamp: time.Now().Unix(),
+			Pid:       os.Getpid(),
+			Gauges:    make(map[string]float64, len(gauges)),
+		}
+		for name, fn := range gauges {
+			report.Gauges[name] = fn()
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	return reports
+}
+
+func gaugeLoop() {
+	ticker := time.NewTicker(logInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reportGauges()
+	}
+}
+
+func logGaugeReport(report *GaugeReport) {
+	gaugeLock.Lock()
+	writer := gaugeWriter
+	gaugeLock.Unlock()
+
+	if writer != nil {
+		if err := writer.WriteGauge(report); err != nil {
+			logx.Error(err)
+		}
+	}
+
+	if logEnabled.True() {
+		names := make([]string, 0, len(report.Gauges))
+		for name := range report.Gauges {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var builder strings.Builder
+		for i, name := range names {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			fmt.Fprintf(&builder, "%s: %.3f", name, report.Gauges[name])
+		}
+		logx.Statf("(%s) - gauges: %s", report.Name, builder.String())
+	}
+}
+
+func reportGauges() {
+	for _, report := range collectGaugeReports() {
+		logGaugeReport(report)
+	}
+}
@@ -0,0 +1,76 @@
+package stat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedGaugeWriter struct {
+	reports []*GaugeReport
+}
+
+func (w *mockedGaugeWriter) WriteGauge(report *GaugeReport) error {
+	w.reports = append(w.reports, report)
+	return nil
+}
+
+func TestRegisterGauge(t *testing.T) {
+	defer UnregisterGauge("cache", "hit_ratio")
+	defer UnregisterGauge("cache", "size")
+
+	RegisterGauge("cache", "hit_ratio", func() float64 {
+		return 0.75
+	})
+	RegisterGauge("cache", "size", func() float64 {
+		return 128
+	})
+
+	reports := collectGaugeReports()
+	assert.Equal(t, 1, len(reports))
+	assert.Equal(t, "cache", reports[0].Name)
+	assert.Equal(t, 0.75, reports[0].Gauges["hit_ratio"])
+	assert.Equal(t, float64(128), reports[0].Gauges["size"])
+}
+
+func TestUnregisterGauge(t *testing.T) {
+	RegisterGauge("breaker", "rejects", func() float64 {
+		return 1
+	})
+	UnregisterGauge("breaker", "rejects")
+	assert.Empty(t, collectGaugeReports())
+}
+
+func TestGaugeWriter(t *testing.T) {
+	defer UnregisterGauge("singleflight", "savings")
+	defer SetGaugeWriter(nil)
+
+	writer := new(mockedGaugeWriter)
+	SetGaugeWriter(writer)
+	RegisterGauge("singleflight", "savings", func() float64 {
+		return 42
+	})
+
+	reportGauges()
+	assert.Equal(t, 1, len(writer.reports))
+	assert.Equal(t, "singleflight", writer.reports[0].Name)
+	assert.Equal(t, float64(42), writer.reports[0].Gauges["savings"])
+}
+
+func TestGaugeGroups(t *testing.T) {
+	defer UnregisterGauge("a", "v")
+	defer UnregisterGauge("b", "v")
+
+	RegisterGauge("b", "v", func() float64 {
+		return 2
+	})
+	RegisterGauge("a", "v", func() float64 {
+		return 1
+	})
+
+	reports := collectGaugeReports()
+	assert.Equal(t, 2, len(reports))
+	// reports come out sorted by group name
+	assert.Equal(t, "a", reports[0].Name)
+	assert.Equal(t, "b", reports[1].Name)
+}
@@ -15,12 +15,7 @@ import (
 	"github.com/tal-tech/go-zero/core/syncx"
 )
 
-const (
-	notFoundPlaceholder = "*"
-	// make the expiry unstable to avoid lots of cached items expire at the same time
-	// make the unstable expiry to be [0.95, 1.05] * seconds
-	expiryDeviation = 0.05
-)
+const notFoundPlaceholder = "*"
 
 // indicates there is no such value associate with the key
 var errPlaceholder = errors.New("placeholder")
@@ -35,6 +30,7 @@ type cacheNode struct {
 	unstableExpiry mathx.Unstable
 	stat           *Stat
 	errNotFound    error
+	earlyBeta      float64
 }
 
 // NewNode returns a cacheNode.
@@ -53,9 +49,10 @@ func NewNode(rds *redis.Redis, barrier syncx.SharedCalls, st *Stat,
 		barrier:        barrier,
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(o.ExpiryDeviation),
 		stat:           st,
 		errNotFound:    errNotFound,
+		earlyBeta:      o.EarlyRecomputeBeta,
 	}
 }
 
@@ -111,6 +108,10 @@ func (c cacheNode) String() string {
 // Take takes the result from cache first, if not found,
 // query from DB and set cache using c.expiry, then return the result.
 func (c cacheNode) Take(v interface{}, key string, query func(v interface{}) error) error {
+	if c.earlyBeta > 0 {
+		return c.takeEarly(v, key, query, c.aroundDuration(c.expiry))
+	}
+
 	return c.doTake(v, key, query, func(v interface{}) error {
 		return c.Set(key, v)
 	})
@@ -121,6 +122,12 @@ func (c cacheNode) Take(v interface{}, key string, query func(v interface{}) err
 func (c cacheNode) TakeWithExpire(v interface{}, key string, query func(v interface{},
 	expire time.Duration) error) error {
 	expire := c.aroundDuration(c.expiry)
+	if c.earlyBeta > 0 {
+		return c.takeEarly(v, key, func(v interface{}) error {
+			return query(v, expire)
+		}, expire)
+	}
+
 	return c.doTake(v, key, func(v interface{}) error {
 		return query(v, expire)
 	}, func(v interface{}) error {
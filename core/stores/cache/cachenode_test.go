@@ -35,7 +35,7 @@ func TestCacheNode_DelCache(t *testing.T) {
 		rds:            store,
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errTestNotFound,
 	}
@@ -58,7 +58,7 @@ func TestCacheNode_InvalidCache(t *testing.T) {
 		rds:            redis.NewRedis(s.Addr(), redis.NodeType),
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errTestNotFound,
 	}
@@ -80,7 +80,7 @@ func TestCacheNode_Take(t *testing.T) {
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		barrier:        syncx.NewSharedCalls(),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errTestNotFound,
 	}
@@ -107,7 +107,7 @@ func TestCacheNode_TakeNotFound(t *testing.T) {
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		barrier:        syncx.NewSharedCalls(),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errTestNotFound,
 	}
@@ -146,7 +146,7 @@ func TestCacheNode_TakeWithExpire(t *testing.T) {
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		barrier:        syncx.NewSharedCalls(),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errors.New("any"),
 	}
@@ -173,7 +173,7 @@ func TestCacheNode_String(t *testing.T) {
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		barrier:        syncx.NewSharedCalls(),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errors.New("any"),
 	}
@@ -190,7 +190,7 @@ func TestCacheValueWithBigInt(t *testing.T) {
 		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
 		barrier:        syncx.NewSharedCalls(),
 		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		unstableExpiry: mathx.NewUnstable(defaultExpiryDeviation),
 		stat:           NewStat("any"),
 		errNotFound:    errors.New("any"),
 	}
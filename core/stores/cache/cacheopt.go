@@ -5,13 +5,19 @@ import "time"
 const (
 	defaultExpiry         = time.Hour * 24 * 7
 	defaultNotFoundExpiry = time.Minute
+	// make the expiry unstable to avoid lots of cached items expire at the same time
+	// make the unstable expiry to be [0.95, 1.05] * seconds
+	defaultExpiryDeviation = 0.05
+	maxExpiryDeviation     = 1
 )
 
 type (
 	// An Options is used to store the cache options.
 	Options struct {
-		Expiry         time.Duration
-		NotFoundExpiry time.Duration
+		Expiry             time.Duration
+		NotFoundExpiry     time.Duration
+		ExpiryDeviation    float64
+		EarlyRecomputeBeta float64
 	}
 
 	// Option defines the method to customize an Options.
@@ -30,10 +36,25 @@ func newOptions(opts ...Option) Options {
 	if o.NotFoundExpiry <= 0 {
 		o.NotFoundExpiry = defaultNotFoundExpiry
 	}
+	if o.ExpiryDeviation <= 0 || o.ExpiryDeviation >= maxExpiryDeviation {
+		o.ExpiryDeviation = defaultExpiryDeviation
+	}
 
 	return o
 }
 
+// WithEarlyRecompute returns a func to customize a Options with probabilistic
+// early recomputation (XFetch) enabled. Higher beta rebuilds earlier,
+// 1 is a reasonable default, beta <= 0 picks the default.
+func WithEarlyRecompute(beta float64) Option {
+	return func(o *Options) {
+		if beta <= 0 {
+			beta = defaultRecomputeBeta
+		}
+		o.EarlyRecomputeBeta = beta
+	}
+}
+
 // WithExpiry returns a func to customize a Options with given expiry.
 func WithExpiry(expiry time.Duration) Option {
 	return func(o *Options) {
@@ -41,6 +62,15 @@ func WithExpiry(expiry time.Duration) Option {
 	}
 }
 
+// WithExpiryDeviation returns a func to customize a Options with given expiry
+// deviation, e.g. 0.1 jitters the ttl of each write within [0.9, 1.1] * expiry.
+// Values outside (0, 1) fall back to the default deviation.
+func WithExpiryDeviation(deviation float64) Option {
+	return func(o *Options) {
+		o.ExpiryDeviation = deviation
+	}
+}
+
 // WithNotFoundExpiry returns a func to customize a Options with given not found expiry.
 func WithNotFoundExpiry(expiry time.Duration) Option {
 	return func(o *Options) {
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryDeviationOption(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, defaultExpiryDeviation, o.ExpiryDeviation)
+
+	o = newOptions(WithExpiryDeviation(0.1))
+	assert.Equal(t, 0.1, o.ExpiryDeviation)
+
+	// out of range values fall back to the default
+	o = newOptions(WithExpiryDeviation(-1))
+	assert.Equal(t, defaultExpiryDeviation, o.ExpiryDeviation)
+	o = newOptions(WithExpiryDeviation(1))
+	assert.Equal(t, defaultExpiryDeviation, o.ExpiryDeviation)
+}
@@ -5,10 +5,19 @@ import (
 	"time"
 
 	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/metric"
 )
 
 const statInterval = time.Minute
 
+var metricCacheOpTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: "dbcache",
+	Subsystem: "ops",
+	Name:      "total",
+	Help:      "db cache operations count.",
+	Labels:    []string{"name", "op"},
+})
+
 // A Stat is used to stat the cache.
 type Stat struct {
 	name string
@@ -33,21 +42,25 @@ func NewStat(name string) *Stat {
 // IncrementTotal increments the total count.
 func (s *Stat) IncrementTotal() {
 	atomic.AddUint64(&s.Total, 1)
+	metricCacheOpTotal.Inc(s.name, "total")
 }
 
 // IncrementHit increments the hit count.
 func (s *Stat) IncrementHit() {
 	atomic.AddUint64(&s.Hit, 1)
+	metricCacheOpTotal.Inc(s.name, "hit")
 }
 
 // IncrementMiss increments the miss count.
 func (s *Stat) IncrementMiss() {
 	atomic.AddUint64(&s.Miss, 1)
+	metricCacheOpTotal.Inc(s.name, "miss")
 }
 
 // IncrementDbFails increments the db fail count.
 func (s *Stat) IncrementDbFails() {
 	atomic.AddUint64(&s.DbFails, 1)
+	metricCacheOpTotal.Inc(s.name, "db_fails")
 }
 
 func (s *Stat) statLoop() {
@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/collection"
+	"github.com/tal-tech/go-zero/core/jsonx"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/stringx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultLocalLimit          = 10000
+	defaultLocalExpire         = time.Minute
+	defaultInvalidationChannel = "cache:invalidations"
+	nodeIdLen                  = 8
+)
+
+type (
+	// TwoTierOption defines the method to customize a TwoTierCache.
+	TwoTierOption func(c *TwoTierCache)
+
+	// A TwoTierCache fronts a remote cache with a bounded in-process lru tier,
+	// backfilling the local tier on remote hits. Writes and deletes publish an
+	// invalidation message through redis pub/sub, so the local copies on other
+	// nodes are evicted. Delivery is best effort, local entries expire on their
+	// own, a missed message only delays consistency by the local expire.
+	TwoTierCache struct {
+		remote      Cache
+		rds         *redis.Redis
+		local       *collection.Cache
+		localExpire time.Duration
+		localLimit  int
+		channel     string
+		id          string
+		unsubscribe func()
+	}
+
+	invalidationMessage struct {
+		Node string   `json:"node"`
+		Keys []string `json:"keys"`
+	}
+)
+
+// NewTwoTier returns a TwoTierCache that checks a local lru tier before remote.
+// rds is only used for the pub/sub based invalidation, remote may well be
+// backed by the same redis instance or cluster.
+func NewTwoTier(rds *redis.Redis, remote Cache, opts ...TwoTierOption) (*TwoTierCache, error) {
+	c := &TwoTierCache{
+		remote:      remote,
+		rds:         rds,
+		localExpire: defaultLocalExpire,
+		localLimit:  defaultLocalLimit,
+		channel:     defaultInvalidationChannel,
+		id:          stringx.Randn(nodeIdLen),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	local, err := collection.NewCache(c.localExpire, collection.WithLimit(c.localLimit),
+		collection.WithName("twotier"))
+	if err != nil {
+		return nil, err
+	}
+	c.local = local
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, err := rds.Subscribe(ctx, c.channel)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	c.unsubscribe = cancel
+
+	threading.GoSafe(func() {
+		for msg := range messages {
+			c.onInvalidation(msg.Payload)
+		}
+	})
+
+	return c, nil
+}
+
+// Close cancels the invalidation subscription.
+func (c *TwoTierCache) Close() {
+	c.unsubscribe()
+}
+
+// Del deletes cached values with keys from both tiers,
+// and broadcasts the invalidation to other nodes.
+func (c *TwoTierCache) Del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, key := range keys {
+		c.local.Del(key)
+	}
+	err := c.remote.Del(keys...)
+	c.publishInvalidation(keys)
+
+	return err
+}
+
+// Get gets the cache with key and fills into v, checking the local tier first.
+func (c *TwoTierCache) Get(key string, v interface{}) error {
+	if data, ok := c.local.Get(key); ok {
+		return jsonx.Unmarshal(data.([]byte), v)
+	}
+
+	if err := c.remote.Get(key, v); err != nil {
+		return err
+	}
+
+	c.backfill(key, v)
+	return nil
+}
+
+// IsNotFound checks if the given error is the remote defined errNotFound.
+func (c *TwoTierCache) IsNotFound(err error) bool {
+	return c.remote.IsNotFound(err)
+}
+
+// Set sets the cache with key and v into both tiers.
+func (c *TwoTierCache) Set(key string, v interface{}) error {
+	if err := c.remote.Set(key, v); err != nil {
+		return err
+	}
+
+	c.backfill(key, v)
+	c.publishInvalidation([]string{key})
+	return nil
+}
+
+// SetWithExpire sets the cache with key and v, using given expire.
+// The local copy is skipped when expire is shorter than the local expire,
+// otherwise it could outlive the remote one.
+func (c *TwoTierCache) SetWithExpire(key string, v interface{}, expire time.Duration) error {
+	if err := c.remote.SetWithExpire(key, v, expire); err != nil {
+		return err
+	}
+
+	if expire >= c.localExpire {
+		c.backfill(key, v)
+	}
+	c.publishInvalidation([]string{key})
+	return nil
+}
+
+// Take takes the result from the local tier first, then remote,
+// if still not found, query from DB and set cache, then return the result.
+func (c *TwoTierCache) Take(v interface{}, key string, query func(v interface{}) error) error {
+	if data, ok := c.local.Get(key); ok {
+		return jsonx.Unmarshal(data.([]byte), v)
+	}
+
+	if err := c.remote.Take(v, key, query); err != nil {
+		return err
+	}
+
+	c.backfill(key, v)
+	return nil
+}
+
+// TakeWithExpire takes the result like Take, but sets cache using given expire.
+func (c *TwoTierCache) TakeWithExpire(v interface{}, key string,
+	query func(v interface{}, expire time.Duration) error) error {
+	if data, ok := c.local.Get(key); ok {
+		return jsonx.Unmarshal(data.([]byte), v)
+	}
+
+	if err := c.remote.TakeWithExpire(v, key, query); err != nil {
+		return err
+	}
+
+	c.backfill(key, v)
+	return nil
+}
+
+// WithInvalidationChannel customizes a TwoTierCache with the pub/sub channel
+// that carries the invalidation messages.
+func WithInvalidationChannel(channel string) TwoTierOption {
+	return func(c *TwoTierCache) {
+		c.channel = channel
+	}
+}
+
+// WithLocalExpire customizes a TwoTierCache with the expire of the local tier.
+func WithLocalExpire(expire time.Duration) TwoTierOption {
+	return func(c *TwoTierCache) {
+		c.localExpire = expire
+	}
+}
+
+// WithLocalLimit customizes a TwoTierCache with up to limit local items.
+func WithLocalLimit(limit int) TwoTierOption {
+	return func(c *TwoTierCache) {
+		c.localLimit = limit
+	}
+}
+
+func (c *TwoTierCache) backfill(key string, v interface{}) {
+	data, err := jsonx.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	c.local.Set(key, data)
+}
+
+func (c *TwoTierCache) onInvalidation(message string) {
+	var msg invalidationMessage
+	if err := jsonx.Unmarshal([]byte(message), &msg); err != nil {
+		logx.Errorf("invalid cache invalidation message: %q, error: %v", message, err)
+		return
+	}
+
+	if msg.Node == c.id {
+		// our own write, the local copy is already fresh
+		return
+	}
+
+	for _, key := range msg.Keys {
+		c.local.Del(key)
+	}
+}
+
+func (c *TwoTierCache) publishInvalidation(keys []string) {
+	data, err := jsonx.Marshal(invalidationMessage{
+		Node: c.id,
+		Keys: keys,
+	})
+	if err != nil {
+		return
+	}
+
+	if _, err := c.rds.Publish(c.channel, string(data)); err != nil {
+		logx.Errorf("failed to publish cache invalidation for keys: %q, error: %v",
+			formatKeys(keys), err)
+	}
+}
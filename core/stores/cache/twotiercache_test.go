@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+func TestTwoTierCacheInvalidation(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	remote := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound)
+	first, err := NewTwoTier(rds, remote, WithLocalLimit(100))
+	assert.Nil(t, err)
+	defer first.Close()
+	second, err := NewTwoTier(rds, remote, WithLocalLimit(100))
+	assert.Nil(t, err)
+	defer second.Close()
+
+	assert.Nil(t, first.Set("key", "value"))
+	var v string
+	assert.Nil(t, first.Get("key", &v))
+	assert.Equal(t, "value", v)
+
+	// populate the local tier of the second node
+	assert.Nil(t, second.Get("key", &v))
+	assert.Equal(t, "value", v)
+	_, ok := second.local.Get("key")
+	assert.True(t, ok)
+
+	// a delete on the first node evicts the local copy on the second
+	assert.Nil(t, first.Del("key"))
+	for i := 0; i < 100; i++ {
+		if _, ok := second.local.Get("key"); !ok {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	_, ok = second.local.Get("key")
+	assert.False(t, ok)
+	assert.True(t, second.IsNotFound(second.Get("key", &v)))
+}
+
+func TestTwoTierCacheTake(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	remote := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound)
+	c, err := NewTwoTier(rds, remote)
+	assert.Nil(t, err)
+	defer c.Close()
+
+	var count int
+	for i := 0; i < 3; i++ {
+		var v string
+		assert.Nil(t, c.Take(&v, "key", func(val interface{}) error {
+			count++
+			*val.(*string) = "value"
+			return nil
+		}))
+		assert.Equal(t, "value", v)
+	}
+	assert.Equal(t, 1, count)
+
+	// served from the local tier, not remote
+	_, ok := c.local.Get("key")
+	assert.True(t, ok)
+}
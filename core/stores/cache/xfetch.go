@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/jsonx"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const defaultRecomputeBeta = 1.0
+
+// the stored payload when early recomputation is enabled,
+// carries the metadata that drives the XFetch check.
+type xfetchValue struct {
+	Value json.RawMessage `json:"v"`
+	// the compute cost of the value in milliseconds
+	Delta int64 `json:"d"`
+	// the expiry of the value in unix milliseconds
+	Expire int64 `json:"e"`
+}
+
+// takeEarly works like doTake, but stores the value wrapped with its compute
+// duration and expiry, and probabilistically rebuilds it in the background
+// slightly before expiry while still serving the valid value, so a hot key
+// doesn't make all requests miss and rebuild at the same time.
+func (c cacheNode) takeEarly(v interface{}, key string, query func(v interface{}) error,
+	expire time.Duration) error {
+	val, fresh, err := c.barrier.DoEx(key, func() (interface{}, error) {
+		envelope, err := c.getEnvelope(key)
+		if err != nil {
+			if err == errPlaceholder {
+				return nil, c.errNotFound
+			} else if err != c.errNotFound {
+				// why we just return the error instead of query from db,
+				// because we don't allow the disaster pass to the dbs.
+				// fail fast, in case we bring down the dbs.
+				return nil, err
+			}
+
+			data, err := c.computeEnvelope(key, query, v, expire)
+			if err == c.errNotFound {
+				if e := c.setCacheWithNotFound(key); e != nil {
+					logx.Error(e)
+				}
+
+				return nil, c.errNotFound
+			} else if err != nil {
+				c.stat.IncrementDbFails()
+				return nil, err
+			}
+
+			return data, nil
+		}
+
+		if envelope.Expire > 0 {
+			ttl := time.Until(time.UnixMilli(envelope.Expire))
+			if c.shouldRefreshEarly(time.Duration(envelope.Delta)*time.Millisecond, ttl) {
+				c.refreshEarly(v, key, query, expire)
+			}
+		}
+
+		return []byte(envelope.Value), nil
+	})
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		// got the result from previous ongoing query
+		c.stat.IncrementTotal()
+		c.stat.IncrementHit()
+	}
+
+	return jsonx.Unmarshal(val.([]byte), v)
+}
+
+// computeEnvelope queries the value into v, stores it wrapped with the
+// xfetch metadata, and returns the marshaled value.
+func (c cacheNode) computeEnvelope(key string, query func(v interface{}) error,
+	v interface{}, expire time.Duration) ([]byte, error) {
+	start := timex.Now()
+	if err := query(v); err != nil {
+		return nil, err
+	}
+	delta := timex.Since(start)
+
+	data, err := jsonx.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := jsonx.Marshal(xfetchValue{
+		Value:  data,
+		Delta:  delta.Milliseconds(),
+		Expire: time.Now().Add(expire).UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rds.Setex(key, string(envelope), int(expire.Seconds())); err != nil {
+		logx.Error(err)
+	}
+
+	return data, nil
+}
+
+// getEnvelope loads the stored envelope of key, stats like doGetCache.
+func (c cacheNode) getEnvelope(key string) (xfetchValue, error) {
+	c.stat.IncrementTotal()
+	data, err := c.rds.Get(key)
+	if err != nil {
+		c.stat.IncrementMiss()
+		return xfetchValue{}, err
+	}
+
+	if len(data) == 0 {
+		c.stat.IncrementMiss()
+		return xfetchValue{}, c.errNotFound
+	}
+
+	c.stat.IncrementHit()
+	if data == notFoundPlaceholder {
+		return xfetchValue{}, errPlaceholder
+	}
+
+	var envelope xfetchValue
+	if err := jsonx.Unmarshal([]byte(data), &envelope); err != nil || len(envelope.Value) == 0 {
+		// written before early recomputation was enabled, serve as a plain value
+		return xfetchValue{Value: json.RawMessage(data)}, nil
+	}
+
+	return envelope, nil
+}
+
+// refreshEarly rebuilds key in the background, the caller keeps serving
+// the stale-but-valid value.
+func (c cacheNode) refreshEarly(v interface{}, key string, query func(v interface{}) error,
+	expire time.Duration) {
+	tp := reflect.TypeOf(v)
+	if tp.Kind() != reflect.Ptr {
+		return
+	}
+
+	// query into a fresh value, the caller still owns v
+	fresh := reflect.New(tp.Elem()).Interface()
+	threading.GoSafe(func() {
+		// a dedicated barrier key keeps one background rebuild per key
+		_, _ = c.barrier.Do("xfetch!"+key, func() (interface{}, error) {
+			if _, err := c.computeEnvelope(key, query, fresh, expire); err != nil {
+				c.stat.IncrementDbFails()
+				logx.Error(err)
+			}
+
+			return nil, nil
+		})
+	})
+}
+
+// shouldRefreshEarly implements the XFetch check,
+// refresh when now - delta*beta*ln(rand) >= expiry.
+func (c cacheNode) shouldRefreshEarly(delta, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return true
+	}
+
+	c.lock.Lock()
+	rnd := c.r.Float64()
+	c.lock.Unlock()
+
+	return -float64(delta)*c.earlyBeta*math.Log(rnd) >= float64(ttl)
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/jsonx"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+func TestShouldRefreshEarly(t *testing.T) {
+	cn := cacheNode{
+		r:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		lock:      new(sync.Mutex),
+		earlyBeta: defaultRecomputeBeta,
+	}
+
+	// expired values always refresh
+	assert.True(t, cn.shouldRefreshEarly(time.Millisecond, 0))
+	// a cheap value far from expiry practically never refreshes
+	assert.False(t, cn.shouldRefreshEarly(time.Millisecond, time.Hour))
+}
+
+func TestTakeEarly(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cn := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound,
+		WithEarlyRecompute(1))
+	var count int
+	for i := 0; i < 3; i++ {
+		var v string
+		assert.Nil(t, cn.Take(&v, "key", func(val interface{}) error {
+			count++
+			*val.(*string) = "value"
+			return nil
+		}))
+		assert.Equal(t, "value", v)
+	}
+	assert.Equal(t, 1, count)
+
+	// the stored payload carries the xfetch metadata
+	data, err := rds.Get("key")
+	assert.Nil(t, err)
+	var envelope xfetchValue
+	assert.Nil(t, jsonx.Unmarshal([]byte(data), &envelope))
+	assert.True(t, envelope.Expire > 0)
+}
+
+func TestTakeEarlyNotFound(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cn := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound,
+		WithEarlyRecompute(1))
+	var count int
+	for i := 0; i < 2; i++ {
+		var v string
+		assert.Equal(t, errTestNotFound, cn.Take(&v, "key", func(val interface{}) error {
+			count++
+			return errTestNotFound
+		}))
+	}
+	// the second call is served from the not found placeholder
+	assert.Equal(t, 1, count)
+}
+
+func TestTakeEarlyPlainValue(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	// a value written before early recomputation was enabled
+	assert.Nil(t, rds.Set("key", `"plain"`))
+
+	cn := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound,
+		WithEarlyRecompute(1))
+	var v string
+	assert.Nil(t, cn.Take(&v, "key", func(val interface{}) error {
+		t.Fatal("query should not be called on a cache hit")
+		return nil
+	}))
+	assert.Equal(t, "plain", v)
+}
+
+func TestTakeEarlyBackgroundRefresh(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	// an envelope already past its expiry triggers a background rebuild
+	envelope, err := jsonx.Marshal(xfetchValue{
+		Value:  []byte(`"stale"`),
+		Delta:  100,
+		Expire: time.Now().Add(-time.Second).UnixMilli(),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, rds.Set("key", string(envelope)))
+
+	cn := NewNode(rds, syncx.NewSharedCalls(), NewStat("any"), errTestNotFound,
+		WithEarlyRecompute(1))
+	var v string
+	assert.Nil(t, cn.Take(&v, "key", func(val interface{}) error {
+		*val.(*string) = "fresh"
+		return nil
+	}))
+	// the stale-but-valid value is served while rebuilding
+	assert.Equal(t, "stale", v)
+
+	for i := 0; i < 100; i++ {
+		if data, err := rds.Get("key"); err == nil && strings.Contains(data, "fresh") {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("background refresh didn't update the cache")
+}
@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/globalsign/mgo"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const warmupTimeout = time.Second * 5
+
+// Warmup eagerly copies and validates up to n sessions, so the first
+// requests after deploy don't pay the session setup cost.
+func (mm *Model) Warmup(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sessions := make([]*mgo.Session, 0, n)
+	defer func() {
+		for _, session := range sessions {
+			mm.PutSession(session)
+		}
+	}()
+
+	// hold the sessions while pinging, so n of them get established
+	for i := 0; i < n; i++ {
+		session, err := mm.TakeSession()
+		if err != nil {
+			return err
+		}
+
+		sessions = append(sessions, session)
+		if err := session.Ping(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WarmupChecker returns a func that warms up mm with n sessions,
+// suitable to register as a readiness checker on a rest.HealthManager.
+func (mm *Model) WarmupChecker(n int) func() error {
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+		defer cancel()
+
+		if err := mm.Warmup(ctx, n); err != nil {
+			logx.Errorf("mongo warmup failed: %v", err)
+			return err
+		}
+
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 
@@ -23,6 +24,9 @@ const (
 	readWriteTimeout     = 2 * time.Second
 
 	slowThreshold = time.Millisecond * 100
+
+	// the earth radius used by redis geo commands, in kilometers
+	earthRadiusKm = 6372.7976
 )
 
 // ErrNilNode is an error that indicates a nil redis node.
@@ -59,6 +63,18 @@ type (
 	// GeoPos is used to represent a geo position.
 	GeoPos = red.GeoPos
 
+	// GeoBoxQuery is used with GeoSearchBox to query a rectangular
+	// area centered on a point.
+	GeoBoxQuery struct {
+		// box dimensions, in Unit
+		Width, Height float64
+		// Can be m, km, ft, or mi. Default is km.
+		Unit  string
+		Count int
+		// Can be ASC or DESC. Default is no sort order.
+		Sort string
+	}
+
 	// Pipeliner is an alias of redis.Pipeliner.
 	Pipeliner = red.Pipeliner
 
@@ -440,6 +456,46 @@ func (s *Redis) GeoPos(key string, members ...string) (val []*GeoPos, err error)
 	return
 }
 
+// GeoSearchBox searches the members within a box of given width and height centered
+// on longitude/latitude, with the coordinates and the distances from the center filled
+// in. It's built on georadius over the circumscribed circle, so it also works with
+// redis servers that predate the geosearch command.
+func (s *Redis) GeoSearchBox(key string, longitude, latitude float64, query GeoBoxQuery) (
+	val []GeoLocation, err error) {
+	kmPerUnit, err := distanceToKm(1, query.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	halfWidth := query.Width * kmPerUnit / 2
+	halfHeight := query.Height * kmPerUnit / 2
+	locations, err := s.GeoRadius(key, longitude, latitude, &GeoRadiusQuery{
+		Radius:    math.Sqrt(halfWidth*halfWidth + halfHeight*halfHeight),
+		Unit:      "km",
+		WithCoord: true,
+		WithDist:  true,
+		Sort:      query.Sort,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, location := range locations {
+		if !inGeoBox(longitude, latitude, location.Longitude, location.Latitude,
+			halfWidth, halfHeight) {
+			continue
+		}
+
+		location.Dist /= kmPerUnit
+		val = append(val, location)
+		if query.Count > 0 && len(val) == query.Count {
+			break
+		}
+	}
+
+	return val, nil
+}
+
 // Get is the implementation of redis get command.
 func (s *Redis) Get(key string) (val string, err error) {
 	err = s.brk.DoWithAcceptable(func() error {
@@ -884,15 +940,16 @@ func (s *Redis) Pfadd(key string, values ...interface{}) (val bool, err error) {
 	return
 }
 
-// Pfcount is the implementation of redis pfcount command.
-func (s *Redis) Pfcount(key string) (val int64, err error) {
+// Pfcount is the implementation of redis pfcount command,
+// counting the union of the hyperloglogs at keys.
+func (s *Redis) Pfcount(keys ...string) (val int64, err error) {
 	err = s.brk.DoWithAcceptable(func() error {
 		conn, err := getRedis(s)
 		if err != nil {
 			return err
 		}
 
-		val, err = conn.PFCount(key).Result()
+		val, err = conn.PFCount(keys...).Result()
 		return err
 	}, acceptable)
 
@@ -950,6 +1007,26 @@ func (s *Redis) Pipelined(fn func(Pipeliner) error) (err error) {
 	return
 }
 
+// Publish is the implementation of redis publish command.
+func (s *Redis) Publish(channel, message string) (val int64, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		v, err := conn.Publish(channel, message).Result()
+		if err != nil {
+			return err
+		}
+
+		val = v
+		return nil
+	}, acceptable)
+
+	return
+}
+
 // Rpop is the implementation of redis rpop command.
 func (s *Redis) Rpop(key string) (val string, err error) {
 	err = s.brk.DoWithAcceptable(func() error {
@@ -1337,6 +1414,31 @@ func (s *Redis) Ttl(key string) (val int, err error) {
 	return
 }
 
+// Xadd is the implementation of redis xadd command,
+// appending values to the stream key with an auto-generated id.
+func (s *Redis) Xadd(key string, values map[string]interface{}) (val string, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		v, err := conn.XAdd(&red.XAddArgs{
+			Stream: key,
+			ID:     "*",
+			Values: values,
+		}).Result()
+		if err != nil {
+			return err
+		}
+
+		val = v
+		return nil
+	}, acceptable)
+
+	return
+}
+
 // Zadd is the implementation of redis zadd command.
 func (s *Redis) Zadd(key string, score int64, value string) (val bool, err error) {
 	err = s.brk.DoWithAcceptable(func() error {
@@ -1773,6 +1875,21 @@ func acceptable(err error) bool {
 	return err == nil || err == red.Nil
 }
 
+func distanceToKm(distance float64, unit string) (float64, error) {
+	switch unit {
+	case "m":
+		return distance / 1000, nil
+	case "", "km":
+		return distance, nil
+	case "mi":
+		return distance * 1.60934, nil
+	case "ft":
+		return distance * 0.0003048, nil
+	default:
+		return 0, fmt.Errorf("unsupported unit '%s'", unit)
+	}
+}
+
 func getRedis(r *Redis) (RedisNode, error) {
 	switch r.Type {
 	case ClusterType:
@@ -1784,6 +1901,13 @@ func getRedis(r *Redis) (RedisNode, error) {
 	}
 }
 
+func inGeoBox(centerLon, centerLat, lon, lat, halfWidthKm, halfHeightKm float64) bool {
+	latDist := math.Abs(lat-centerLat) * math.Pi / 180 * earthRadiusKm
+	lonDist := math.Abs(lon-centerLon) * math.Pi / 180 * earthRadiusKm *
+		math.Cos(centerLat*math.Pi/180)
+	return latDist <= halfHeightKm && lonDist <= halfWidthKm
+}
+
 func toPairs(vals []red.Z) []Pair {
 	pairs := make([]Pair, len(vals))
 	for i, val := range vals {
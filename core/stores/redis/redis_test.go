@@ -261,6 +261,8 @@ func TestRedis_HyperLogLog(t *testing.T) {
 		assert.NotNil(t, err)
 		_, err = r.Pfcount("*")
 		assert.NotNil(t, err)
+		_, err = r.Pfcount("key1", "key2")
+		assert.NotNil(t, err)
 		err = r.Pfmerge("*")
 		assert.NotNil(t, err)
 	})
@@ -1073,6 +1075,57 @@ func TestRedisGeo(t *testing.T) {
 	})
 }
 
+func TestRedisGeoSearchBox(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		geoLocation := []*GeoLocation{{Longitude: 13.361389, Latitude: 38.115556, Name: "Palermo"},
+			{Longitude: 15.087269, Latitude: 37.502669, Name: "Catania"}}
+		_, err := client.GeoAdd("sicily", geoLocation...)
+		assert.Nil(t, err)
+
+		// a box around Palermo wide enough to cover Catania as well
+		locations, err := client.GeoSearchBox("sicily", 13.361389, 38.115556, GeoBoxQuery{
+			Width:  400,
+			Height: 200,
+			Unit:   "km",
+			Sort:   "ASC",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(locations))
+		assert.Equal(t, "Palermo", locations[0].Name)
+		assert.Equal(t, "Catania", locations[1].Name)
+		assert.InDelta(t, 166.274, locations[1].Dist, 1)
+
+		// too narrow for Catania
+		locations, err = client.GeoSearchBox("sicily", 13.361389, 38.115556, GeoBoxQuery{
+			Width:  200,
+			Height: 200,
+			Unit:   "km",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(locations))
+		assert.Equal(t, "Palermo", locations[0].Name)
+
+		// count caps the results
+		locations, err = client.GeoSearchBox("sicily", 13.361389, 38.115556, GeoBoxQuery{
+			Width:  400000,
+			Height: 200000,
+			Unit:   "m",
+			Sort:   "ASC",
+			Count:  1,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(locations))
+		assert.Equal(t, "Palermo", locations[0].Name)
+
+		_, err = client.GeoSearchBox("sicily", 13.361389, 38.115556, GeoBoxQuery{
+			Width:  1,
+			Height: 1,
+			Unit:   "furlong",
+		})
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRedis_WithPass(t *testing.T) {
 	runOnRedis(t, func(client *Redis) {
 		err := NewRedis(client.Addr, NodeType, "any").Ping()
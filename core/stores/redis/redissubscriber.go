@@ -0,0 +1,162 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	red "github.com/go-redis/redis"
+	"github.com/tal-tech/go-zero/core/jsonx"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+type (
+	// A Message is a message received from a subscription.
+	Message struct {
+		// the channel the message was published on
+		Channel string
+		// the pattern that matched the channel, only set for PSubscribe
+		Pattern string
+		Payload string
+	}
+
+	// A TypedMessage is a Message with the payload unmarshaled into T.
+	TypedMessage[T any] struct {
+		Channel string
+		Pattern string
+		Value   T
+	}
+)
+
+// PSubscribe subscribes to the channels matching the given patterns on a
+// dedicated connection, returning the stream of messages. The underlying
+// client reconnects and resubscribes on connection loss, the returned
+// channel is closed when ctx is canceled.
+func (s *Redis) PSubscribe(ctx context.Context, patterns ...string) (<-chan Message, error) {
+	return s.subscribe(ctx, func(client subscribable) *red.PubSub {
+		return client.PSubscribe(patterns...)
+	})
+}
+
+// Subscribe subscribes to the given channels on a dedicated connection,
+// returning the stream of messages. The underlying client reconnects and
+// resubscribes on connection loss, the returned channel is closed when
+// ctx is canceled.
+func (s *Redis) Subscribe(ctx context.Context, channels ...string) (<-chan Message, error) {
+	return s.subscribe(ctx, func(client subscribable) *red.PubSub {
+		return client.Subscribe(channels...)
+	})
+}
+
+// PublishTyped marshals v as json and publishes it on channel with s.
+func PublishTyped[T any](s *Redis, channel string, v T) error {
+	data, err := jsonx.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Publish(channel, string(data))
+	return err
+}
+
+// SubscribeTyped works like Subscribe, unmarshaling each payload into T.
+// Messages that fail to unmarshal are logged and dropped.
+func SubscribeTyped[T any](ctx context.Context, s *Redis, channels ...string) (
+	<-chan TypedMessage[T], error) {
+	messages, err := s.Subscribe(ctx, channels...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan TypedMessage[T])
+	threading.GoSafe(func() {
+		defer close(out)
+
+		for msg := range messages {
+			var value T
+			if err := jsonx.Unmarshal([]byte(msg.Payload), &value); err != nil {
+				logx.Errorf("invalid message on channel %s: %q, error: %v",
+					msg.Channel, msg.Payload, err)
+				continue
+			}
+
+			select {
+			case out <- TypedMessage[T]{
+				Channel: msg.Channel,
+				Pattern: msg.Pattern,
+				Value:   value,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return out, nil
+}
+
+type subscribable interface {
+	PSubscribe(patterns ...string) *red.PubSub
+	Subscribe(channels ...string) *red.PubSub
+}
+
+func (s *Redis) subscribe(ctx context.Context, sub func(client subscribable) *red.PubSub) (
+	<-chan Message, error) {
+	var pubsub *red.PubSub
+	var closer interface{ Close() error }
+
+	switch s.Type {
+	case NodeType:
+		client := red.NewClient(&red.Options{
+			Addr:       s.Addr,
+			Password:   s.Pass,
+			DB:         defaultDatabase,
+			MaxRetries: maxRetries,
+		})
+		pubsub = sub(client)
+		closer = client
+	case ClusterType:
+		client := red.NewClusterClient(&red.ClusterOptions{
+			Addrs:      []string{s.Addr},
+			Password:   s.Pass,
+			MaxRetries: maxRetries,
+		})
+		pubsub = sub(client)
+		closer = client
+	default:
+		return nil, fmt.Errorf("unknown redis type: %s", s.Type)
+	}
+
+	out := make(chan Message)
+	threading.GoSafe(func() {
+		defer func() {
+			close(out)
+			pubsub.Close()
+			closer.Close()
+		}()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- Message{
+					Channel: msg.Channel,
+					Pattern: msg.Pattern,
+					Payload: msg.Payload,
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func publishUntilReceived(t *testing.T, rds *Redis, channel, payload string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		n, err := rds.Publish(channel, payload)
+		assert.Nil(t, err)
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatalf("no subscriber received the message on %s", channel)
+}
+
+func TestSubscribe(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, err := rds.Subscribe(ctx, "events")
+	assert.Nil(t, err)
+
+	publishUntilReceived(t, rds, "events", "hello")
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "events", msg.Channel)
+		assert.Equal(t, "hello", msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed on cancel")
+	}
+}
+
+func TestPSubscribe(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	messages, err := rds.PSubscribe(ctx, "news.*")
+	assert.Nil(t, err)
+
+	publishUntilReceived(t, rds, "news.tech", "golang")
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "news.tech", msg.Channel)
+		assert.Equal(t, "news.*", msg.Pattern)
+		assert.Equal(t, "golang", msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+}
+
+func TestSubscribeBadType(t *testing.T) {
+	rds := New("localhost:6379", func(r *Redis) {
+		r.Type = "bad"
+	})
+	_, err := rds.Subscribe(context.Background(), "events")
+	assert.NotNil(t, err)
+}
+
+func TestSubscribeTyped(t *testing.T) {
+	type event struct {
+		Name string `json:"name"`
+	}
+
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	messages, err := SubscribeTyped[event](ctx, rds, "events")
+	assert.Nil(t, err)
+
+	// malformed payloads are dropped
+	publishUntilReceived(t, rds, "events", "not json")
+	assert.Nil(t, PublishTyped(rds, "events", event{Name: "deploy"}))
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "events", msg.Channel)
+		assert.Equal(t, "deploy", msg.Value.Name)
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+}
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+
+	red "github.com/go-redis/redis"
+)
+
+const defaultScanCount = 100
+
+// ScanKeys iterates the keys matching match in batches of roughly count using
+// SCAN cursors, calling fn with each batch. Unlike Keys it doesn't block the
+// server, so it's safe for maintenance sweeps on production instances. On
+// clusters every master is scanned, covering all slots. Iteration stops on the
+// first error returned by fn, or when ctx is canceled.
+func (s *Redis) ScanKeys(ctx context.Context, match string, count int64,
+	fn func(keys []string) error) error {
+	if count <= 0 {
+		count = defaultScanCount
+	}
+
+	conn, err := getRedis(s)
+	if err != nil {
+		return err
+	}
+
+	switch client := conn.(type) {
+	case *red.ClusterClient:
+		return client.ForEachMaster(func(node *red.Client) error {
+			return s.scanNode(ctx, node, match, count, fn)
+		})
+	default:
+		return s.scanNode(ctx, conn, match, count, fn)
+	}
+}
+
+func (s *Redis) scanNode(ctx context.Context, node RedisNode, match string, count int64,
+	fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var keys []string
+		var next uint64
+		if err := s.brk.DoWithAcceptable(func() error {
+			var err error
+			keys, next, err = node.Scan(cursor, match, count).Result()
+			return err
+		}, acceptable); err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
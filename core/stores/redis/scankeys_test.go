@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanKeys(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		for _, key := range []string{"user:1", "user:2", "user:3", "order:1"} {
+			assert.Nil(t, client.Set(key, "value"))
+		}
+
+		var keys []string
+		err := client.ScanKeys(context.Background(), "user:*", 2, func(batch []string) error {
+			keys = append(keys, batch...)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"user:1", "user:2", "user:3"}, keys)
+	})
+}
+
+func TestScanKeysFnError(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		assert.Nil(t, client.Set("user:1", "value"))
+
+		mockedErr := errors.New("stop")
+		err := client.ScanKeys(context.Background(), "*", 0, func(batch []string) error {
+			return mockedErr
+		})
+		assert.Equal(t, mockedErr, err)
+	})
+}
+
+func TestScanKeysCanceled(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := client.ScanKeys(ctx, "*", 0, func(batch []string) error {
+			return nil
+		})
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestScanKeysBadType(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		client.Ping()
+		err := New(client.Addr, badType()).ScanKeys(context.Background(), "*", 0,
+			func(batch []string) error {
+				return nil
+			})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,243 @@
+package redis
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	red "github.com/go-redis/redis"
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultStreamBatchSize  = 10
+	defaultStreamBlock      = time.Second * 5
+	defaultClaimInterval    = time.Minute
+	defaultClaimStaleAfter  = time.Minute
+	streamReadRetryInterval = time.Second
+	busyGroupError          = "BUSYGROUP"
+)
+
+type (
+	// A StreamMessage is a message read from a redis stream.
+	StreamMessage struct {
+		ID     string
+		Values map[string]interface{}
+	}
+
+	// StreamHandler defines the method to consume a StreamMessage,
+	// a nil return acks the message.
+	StreamHandler func(msg StreamMessage) error
+
+	// StreamConsumerOption defines the method to customize a StreamConsumer.
+	StreamConsumerOption func(c *StreamConsumer)
+
+	// A StreamConsumer consumes a redis stream as a member of a consumer
+	// group. Messages whose handler returns nil are acked, failed ones stay
+	// pending and get redelivered when they are claimed as stale, also from
+	// consumers that died without acking.
+	StreamConsumer struct {
+		node            ClosableNode
+		stream          string
+		group           string
+		consumer        string
+		handler         StreamHandler
+		batchSize       int64
+		block           time.Duration
+		claimInterval   time.Duration
+		claimStaleAfter time.Duration
+		done            chan lang.PlaceholderType
+		stopOnce        sync.Once
+	}
+)
+
+// NewStreamConsumer creates the consumer group on stream if missing, and
+// returns a StreamConsumer reading it as consumer within group.
+func NewStreamConsumer(rds *Redis, stream, group, consumer string, handler StreamHandler,
+	opts ...StreamConsumerOption) (*StreamConsumer, error) {
+	node, err := CreateBlockingNode(rds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := node.XGroupCreateMkStream(stream, group, "$").Err(); err != nil &&
+		!strings.Contains(err.Error(), busyGroupError) {
+		node.Close()
+		return nil, err
+	}
+
+	c := &StreamConsumer{
+		node:            node,
+		stream:          stream,
+		group:           group,
+		consumer:        consumer,
+		handler:         handler,
+		batchSize:       defaultStreamBatchSize,
+		block:           defaultStreamBlock,
+		claimInterval:   defaultClaimInterval,
+		claimStaleAfter: defaultClaimStaleAfter,
+		done:            make(chan lang.PlaceholderType),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Start consumes the stream until Stop is called. Blocking.
+func (c *StreamConsumer) Start() {
+	threading.GoSafe(c.claimLoop)
+
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+			c.consumeBatch()
+		}
+	}
+}
+
+// Stop stops the consumer and releases its connection.
+func (c *StreamConsumer) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+		c.node.Close()
+	})
+}
+
+// WithStreamBatchSize customizes a StreamConsumer with up to size messages per read.
+func WithStreamBatchSize(size int64) StreamConsumerOption {
+	return func(c *StreamConsumer) {
+		c.batchSize = size
+	}
+}
+
+// WithStreamBlock customizes a StreamConsumer with the blocking timeout
+// of the group reads, negative reads without blocking.
+func WithStreamBlock(block time.Duration) StreamConsumerOption {
+	return func(c *StreamConsumer) {
+		c.block = block
+	}
+}
+
+// WithStreamClaimInterval customizes a StreamConsumer with the interval
+// of scanning for stale pending entries.
+func WithStreamClaimInterval(interval time.Duration) StreamConsumerOption {
+	return func(c *StreamConsumer) {
+		c.claimInterval = interval
+	}
+}
+
+// WithStreamClaimStaleAfter customizes a StreamConsumer with the idle time
+// after which a pending entry is considered stale and claimed.
+func WithStreamClaimStaleAfter(staleAfter time.Duration) StreamConsumerOption {
+	return func(c *StreamConsumer) {
+		c.claimStaleAfter = staleAfter
+	}
+}
+
+func (c *StreamConsumer) claimLoop() {
+	ticker := time.NewTicker(c.claimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.claimStalePending()
+		}
+	}
+}
+
+func (c *StreamConsumer) claimStalePending() {
+	pending, err := c.node.XPendingExt(&red.XPendingExtArgs{
+		Stream: c.stream,
+		Group:  c.group,
+		Start:  "-",
+		End:    "+",
+		Count:  c.batchSize,
+	}).Result()
+	if err != nil {
+		if err != red.Nil {
+			logx.Errorf("failed to read pending entries of stream %s: %v", c.stream, err)
+		}
+		return
+	}
+
+	var ids []string
+	for _, entry := range pending {
+		if entry.Idle >= c.claimStaleAfter {
+			ids = append(ids, entry.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	messages, err := c.node.XClaim(&red.XClaimArgs{
+		Stream:   c.stream,
+		Group:    c.group,
+		Consumer: c.consumer,
+		MinIdle:  c.claimStaleAfter,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		if err != red.Nil {
+			logx.Errorf("failed to claim stale entries of stream %s: %v", c.stream, err)
+		}
+		return
+	}
+
+	for _, msg := range messages {
+		c.handleMessage(StreamMessage{
+			ID:     msg.ID,
+			Values: msg.Values,
+		})
+	}
+}
+
+func (c *StreamConsumer) consumeBatch() {
+	streams, err := c.node.XReadGroup(&red.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.consumer,
+		Streams:  []string{c.stream, ">"},
+		Count:    c.batchSize,
+		Block:    c.block,
+	}).Result()
+	if err != nil {
+		// red.Nil means the read timed out without messages
+		if err != red.Nil {
+			select {
+			case <-c.done:
+			case <-time.After(streamReadRetryInterval):
+			}
+		}
+		return
+	}
+
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			c.handleMessage(StreamMessage{
+				ID:     msg.ID,
+				Values: msg.Values,
+			})
+		}
+	}
+}
+
+func (c *StreamConsumer) handleMessage(msg StreamMessage) {
+	if err := c.handler(msg); err != nil {
+		// left pending, redelivered when claimed as stale
+		logx.Errorf("failed to consume message %s of stream %s: %v", msg.ID, c.stream, err)
+		return
+	}
+
+	if err := c.node.XAck(c.stream, c.group, msg.ID).Err(); err != nil {
+		logx.Errorf("failed to ack message %s of stream %s: %v", msg.ID, c.stream, err)
+	}
+}
@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamConsumer(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	var lock sync.Mutex
+	var received []string
+	consumer, err := NewStreamConsumer(rds, "jobs", "workers", "worker-1",
+		func(msg StreamMessage) error {
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, msg.Values["payload"].(string))
+			return nil
+		}, WithStreamBatchSize(5), WithStreamBlock(time.Millisecond*10),
+		WithStreamClaimInterval(time.Hour), WithStreamClaimStaleAfter(time.Hour))
+	assert.Nil(t, err)
+	defer consumer.Stop()
+
+	for _, payload := range []string{"first", "second"} {
+		_, err := rds.Xadd("jobs", map[string]interface{}{"payload": payload})
+		assert.Nil(t, err)
+	}
+
+	go consumer.Start()
+
+	assert.True(t, waitFor(time.Second*5, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) == 2
+	}))
+	lock.Lock()
+	assert.ElementsMatch(t, []string{"first", "second"}, received)
+	lock.Unlock()
+}
+
+func TestStreamConsumerFailedMessageNotAcked(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	var count int32
+	var lock sync.Mutex
+	consumer, err := NewStreamConsumer(rds, "jobs", "workers", "worker-1",
+		func(msg StreamMessage) error {
+			lock.Lock()
+			defer lock.Unlock()
+			count++
+			return errors.New("transient failure")
+		}, WithStreamBlock(time.Millisecond*10), WithStreamClaimInterval(time.Hour))
+	assert.Nil(t, err)
+	defer consumer.Stop()
+
+	_, err = rds.Xadd("jobs", map[string]interface{}{"payload": "boom"})
+	assert.Nil(t, err)
+
+	go consumer.Start()
+
+	assert.True(t, waitFor(time.Second*5, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return count == 1
+	}))
+}
+
+func TestStreamConsumerExistingGroup(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	handler := func(msg StreamMessage) error {
+		return nil
+	}
+	first, err := NewStreamConsumer(rds, "jobs", "workers", "worker-1", handler)
+	assert.Nil(t, err)
+	first.Stop()
+
+	// creating the same group again is not an error
+	second, err := NewStreamConsumer(rds, "jobs", "workers", "worker-2", handler)
+	assert.Nil(t, err)
+	second.Stop()
+	// Stop is idempotent
+	second.Stop()
+}
+
+func TestStreamConsumerBadType(t *testing.T) {
+	rds := New("localhost:6379", func(r *Redis) {
+		r.Type = "bad"
+	})
+	_, err := NewStreamConsumer(rds, "jobs", "workers", "worker-1",
+		func(msg StreamMessage) error {
+			return nil
+		})
+	assert.NotNil(t, err)
+}
+
+func waitFor(timeout time.Duration, fn func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if fn() {
+			return true
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return fn()
+}
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const warmupTimeout = time.Second * 5
+
+// Warmup eagerly establishes up to n pooled connections by pinging them
+// concurrently, so the first requests after deploy don't pay the
+// connection setup cost.
+func (s *Redis) Warmup(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	conn, err := getRedis(s)
+	if err != nil {
+		return err
+	}
+
+	// concurrent pings hold one pooled connection each while in flight
+	errs := make(chan error, n)
+	group := threading.NewRoutineGroup()
+	for i := 0; i < n; i++ {
+		group.RunSafe(func() {
+			if err := conn.Ping().Err(); err != nil {
+				errs <- err
+			}
+		})
+	}
+	group.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// WarmupChecker returns a func that warms up s with n connections,
+// suitable to register as a readiness checker on a rest.HealthManager.
+func (s *Redis) WarmupChecker(n int) func() error {
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+		defer cancel()
+
+		return s.Warmup(ctx, n)
+	}
+}
@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmup(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	assert.Nil(t, rds.Warmup(context.Background(), 4))
+	assert.Nil(t, rds.Warmup(context.Background(), 0))
+	assert.Nil(t, rds.WarmupChecker(2)())
+}
+
+func TestWarmupCanceledContext(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer mr.Close()
+
+	rds := NewRedis(mr.Addr(), NodeType)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NotNil(t, rds.Warmup(ctx, 1))
+}
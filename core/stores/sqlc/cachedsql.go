@@ -47,6 +47,15 @@ func NewNodeConn(db sqlx.SqlConn, rds *redis.Redis, opts ...cache.Option) Cached
 	}
 }
 
+// NewConnWithCache returns a CachedConn with a user-supplied cache,
+// such as the two-tier cache built with cache.NewTwoTier.
+func NewConnWithCache(db sqlx.SqlConn, c cache.Cache) CachedConn {
+	return CachedConn{
+		db:    db,
+		cache: c,
+	}
+}
+
 // NewConn returns a CachedConn with a redis cluster cache.
 func NewConn(db sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) CachedConn {
 	return CachedConn{
@@ -0,0 +1,115 @@
+package sqlx
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/hash"
+	"github.com/tal-tech/go-zero/core/jsonx"
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/stores/cache"
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+const cachedQueryKeyPrefix = "cache:sqlx:"
+
+var (
+	// shared calls dedupes concurrent queries on the same derived key
+	cachedQueryBarrier = syncx.NewSharedCalls()
+
+	cachedQueryLock sync.Mutex
+	cachedQueryKeys = make(map[string]map[string]lang.PlaceholderType)
+)
+
+// CachedQuery runs q with args against conn, caching the marshaled result
+// in c for ttl. The cache key is derived from the query and args, so
+// identical queries share an entry. A slice dest scans all rows, any other
+// dest scans a single row. Not found results are not cached.
+func CachedQuery(conn SqlConn, c cache.Cache, ttl time.Duration, v interface{},
+	q string, args ...interface{}) error {
+	return CachedQueryWithPrefix(conn, c, "", ttl, v, q, args...)
+}
+
+// CachedQueryWithPrefix works like CachedQuery, grouping the derived key
+// under prefix so InvalidateCachedQueries can drop the group explicitly.
+func CachedQueryWithPrefix(conn SqlConn, c cache.Cache, prefix string, ttl time.Duration,
+	v interface{}, q string, args ...interface{}) error {
+	key := cachedQueryKey(prefix, q, args)
+	if len(prefix) > 0 {
+		registerCachedQueryKey(prefix, key)
+	}
+
+	val, err := cachedQueryBarrier.Do(key, func() (interface{}, error) {
+		if err := c.Get(key, v); err == nil {
+			return jsonx.Marshal(v)
+		} else if !c.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err := queryAny(conn, v, q, args...); err != nil {
+			return nil, err
+		}
+
+		if err := c.SetWithExpire(key, v, ttl); err != nil {
+			logSqlError(q, err)
+		}
+
+		return jsonx.Marshal(v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return jsonx.Unmarshal(val.([]byte), v)
+}
+
+// InvalidateCachedQueries deletes the cached queries grouped under prefix.
+// Only the keys derived in this process are known, entries written by other
+// instances expire with their ttl.
+func InvalidateCachedQueries(c cache.Cache, prefix string) error {
+	cachedQueryLock.Lock()
+	var keys []string
+	for key := range cachedQueryKeys[prefix] {
+		keys = append(keys, key)
+	}
+	delete(cachedQueryKeys, prefix)
+	cachedQueryLock.Unlock()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.Del(keys...)
+}
+
+func cachedQueryKey(prefix, q string, args []interface{}) string {
+	sum := hash.Md5Hex([]byte(fmt.Sprintf("%s%v", q, args)))
+	if len(prefix) > 0 {
+		return cachedQueryKeyPrefix + prefix + ":" + sum
+	}
+
+	return cachedQueryKeyPrefix + sum
+}
+
+func queryAny(conn SqlConn, v interface{}, q string, args ...interface{}) error {
+	rt := reflect.TypeOf(v)
+	if rt.Kind() == reflect.Ptr && rt.Elem().Kind() == reflect.Slice {
+		return conn.QueryRowsPartial(v, q, args...)
+	}
+
+	return conn.QueryRowPartial(v, q, args...)
+}
+
+func registerCachedQueryKey(prefix, key string) {
+	cachedQueryLock.Lock()
+	defer cachedQueryLock.Unlock()
+
+	keys, ok := cachedQueryKeys[prefix]
+	if !ok {
+		keys = make(map[string]lang.PlaceholderType)
+		cachedQueryKeys[prefix] = keys
+	}
+	keys[key] = lang.Placeholder
+}
@@ -0,0 +1,63 @@
+package sqlx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/cache"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+func TestCachedQuery(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := cache.NewNode(rds, syncx.NewSharedCalls(), cache.NewStat("any"),
+		errors.New("not found"))
+	mock := buildMigrateConn("cached-query")
+	mock.ExpectQuery("select name from users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("first").AddRow("second"))
+	conn := NewMysql("cached-query")
+
+	for i := 0; i < 3; i++ {
+		var names []string
+		assert.Nil(t, CachedQuery(conn, c, time.Minute, &names,
+			"select name from users where type = ?", 1))
+		assert.Equal(t, []string{"first", "second"}, names)
+	}
+
+	// only the first call reaches the database
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedQueryInvalidate(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := cache.NewNode(rds, syncx.NewSharedCalls(), cache.NewStat("any"),
+		errors.New("not found"))
+	mock := buildMigrateConn("cached-query-invalidate")
+	mock.ExpectQuery("select name from users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("first"))
+	mock.ExpectQuery("select name from users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("second"))
+	conn := NewMysql("cached-query-invalidate")
+
+	var name string
+	assert.Nil(t, CachedQueryWithPrefix(conn, c, "users", time.Minute, &name,
+		"select name from users limit 1"))
+	assert.Equal(t, "first", name)
+
+	assert.Nil(t, InvalidateCachedQueries(c, "users"))
+
+	assert.Nil(t, CachedQueryWithPrefix(conn, c, "users", time.Minute, &name,
+		"select name from users limit 1"))
+	assert.Equal(t, "second", name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,63 @@
+package sqlx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ColumnMapper maps a column name to the key used to match struct fields.
+// Columns are first matched against the db tags as-is, the mapped name is
+// only consulted for columns that don't match a tag. With a mapper
+// configured, untagged fields match by their field names as well.
+type ColumnMapper func(column string) string
+
+// CamelColumnMapper maps snake_case column names to CamelCase field names,
+// e.g. user_name matches the field UserName.
+func CamelColumnMapper(column string) string {
+	var b strings.Builder
+	upper := true
+
+	for _, ch := range column {
+		if ch == '_' {
+			upper = true
+			continue
+		}
+
+		if upper {
+			b.WriteRune(unicode.ToUpper(ch))
+			upper = false
+		} else {
+			b.WriteRune(ch)
+		}
+	}
+
+	return b.String()
+}
+
+// SnakeColumnMapper maps CamelCase column names to snake_case keys,
+// e.g. UserName matches the tag or field named user_name.
+func SnakeColumnMapper(column string) string {
+	var b strings.Builder
+
+	for i, ch := range column {
+		if unicode.IsUpper(ch) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(ch))
+		} else {
+			b.WriteRune(ch)
+		}
+	}
+
+	return b.String()
+}
+
+// WithColumnMapper returns a SqlOption that customizes the column-name
+// mapping of the connection, including the sessions and prepared
+// statements derived from it.
+func WithColumnMapper(mapper ColumnMapper) SqlOption {
+	return func(conn *commonSqlConn) {
+		conn.columnMapper = mapper
+	}
+}
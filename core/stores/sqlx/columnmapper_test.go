@@ -0,0 +1,89 @@
+package sqlx
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCamelColumnMapper(t *testing.T) {
+	assert.Equal(t, "UserName", CamelColumnMapper("user_name"))
+	assert.Equal(t, "Age", CamelColumnMapper("age"))
+	assert.Equal(t, "Id", CamelColumnMapper("id"))
+}
+
+func TestSnakeColumnMapper(t *testing.T) {
+	assert.Equal(t, "user_name", SnakeColumnMapper("UserName"))
+	assert.Equal(t, "age", SnakeColumnMapper("age"))
+	assert.Equal(t, "id", SnakeColumnMapper("Id"))
+}
+
+func TestUnmarshalRowWithCamelMapper(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"user_name", "user_age"}).FromCSVString("liao,5")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		var value struct {
+			UserName string
+			UserAge  int
+		}
+		assert.Nil(t, query(db, func(rows *sql.Rows) error {
+			return unmarshalRowEx(&value, rows, true, CamelColumnMapper)
+		}, "select user_name, user_age from users where user=?", "anyone"))
+		assert.Equal(t, "liao", value.UserName)
+		assert.Equal(t, 5, value.UserAge)
+	})
+}
+
+func TestUnmarshalRowEmbeddedStructWithMapper(t *testing.T) {
+	type Base struct {
+		Id int64 `db:"id"`
+	}
+
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"id", "user_name"}).FromCSVString("2,liao")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		var value struct {
+			Base
+			UserName string
+		}
+		assert.Nil(t, query(db, func(rows *sql.Rows) error {
+			return unmarshalRowEx(&value, rows, true, CamelColumnMapper)
+		}, "select id, user_name from users where user=?", "anyone"))
+		assert.Equal(t, int64(2), value.Id)
+		assert.Equal(t, "liao", value.UserName)
+	})
+}
+
+func TestUnmarshalRowIntoMap(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name", "age"}).FromCSVString("liao,5")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		var value map[string]interface{}
+		assert.Nil(t, query(db, func(rows *sql.Rows) error {
+			return unmarshalRow(&value, rows, true)
+		}, "select name, age from users where user=?", "anyone"))
+		assert.Len(t, value, 2)
+		assert.Contains(t, value, "name")
+		assert.Contains(t, value, "age")
+	})
+}
+
+func TestUnmarshalRowsIntoMaps(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name", "age"}).FromCSVString("first,2\nsecond,3")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		var value []map[string]interface{}
+		assert.Nil(t, query(db, func(rows *sql.Rows) error {
+			return unmarshalRows(&value, rows, true)
+		}, "select name, age from users where user=?", "anyone"))
+		assert.Len(t, value, 2)
+		assert.Contains(t, value[0], "name")
+		assert.Contains(t, value[1], "age")
+	})
+}
@@ -0,0 +1,118 @@
+package sqlx
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// EmptyInPolicy defines how an empty slice bound to an IN (?) placeholder is handled.
+type EmptyInPolicy int32
+
+const (
+	// EmptyInAsNull expands an empty slice to a single NULL, so IN (?) matches no rows.
+	EmptyInAsNull EmptyInPolicy = iota
+	// EmptyInAsError fails the statement when an empty slice is passed to IN (?).
+	EmptyInAsError
+)
+
+// ErrEmptyInArgs is the error that an empty slice is passed to an IN (?)
+// placeholder while EmptyInAsError is set.
+var ErrEmptyInArgs = errors.New("empty slice passed to sql in clause")
+
+var emptyInPolicy int32
+
+// SetEmptyInPolicy sets how an empty slice bound to an IN (?) placeholder is handled.
+func SetEmptyInPolicy(policy EmptyInPolicy) {
+	atomic.StoreInt32(&emptyInPolicy, int32(policy))
+}
+
+// In expands each slice argument bound to a ? placeholder into one placeholder
+// per element and flattens the arguments, so queries like `... WHERE id IN (?)`
+// can take a slice directly. Non-slice arguments, []byte and driver.Valuer
+// values are bound as-is. Exec and the Query methods call it automatically,
+// only prepared statements need to call it by hand before Prepare.
+func In(query string, args ...interface{}) (string, []interface{}, error) {
+	if !hasSliceArgs(args) {
+		return query, args, nil
+	}
+
+	var b strings.Builder
+	var argIndex int
+	flat := make([]interface{}, 0, len(args))
+
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		if ch != '?' {
+			b.WriteByte(ch)
+			continue
+		}
+
+		if argIndex >= len(args) {
+			return "", nil, fmt.Errorf("error: %d ? in sql, but less arguments provided", argIndex)
+		}
+
+		vals, ok := sliceArg(args[argIndex])
+		switch {
+		case !ok:
+			b.WriteByte(ch)
+			flat = append(flat, args[argIndex])
+		case len(vals) == 0:
+			if EmptyInPolicy(atomic.LoadInt32(&emptyInPolicy)) == EmptyInAsError {
+				return "", nil, ErrEmptyInArgs
+			}
+
+			// matches no rows, but keeps the statement valid
+			b.WriteString("NULL")
+		default:
+			b.WriteString(strings.Repeat("?,", len(vals)-1))
+			b.WriteByte('?')
+			flat = append(flat, vals...)
+		}
+		argIndex++
+	}
+
+	if argIndex < len(args) {
+		return "", nil, fmt.Errorf("error: %d arguments provided, not matching sql", len(args))
+	}
+
+	return b.String(), flat, nil
+}
+
+func expandIn(query string, args []interface{}) (string, []interface{}, error) {
+	return In(query, args...)
+}
+
+func hasSliceArgs(args []interface{}) bool {
+	for _, arg := range args {
+		if _, ok := sliceArg(arg); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sliceArg(arg interface{}) ([]interface{}, bool) {
+	switch arg.(type) {
+	case nil, []byte, driver.Valuer:
+		return nil, false
+	}
+
+	val := reflect.ValueOf(arg)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return nil, false
+	}
+
+	vals := make([]interface{}, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		vals[i] = val.Index(i).Interface()
+	}
+
+	return vals, true
+}
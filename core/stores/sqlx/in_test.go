@@ -0,0 +1,75 @@
+package sqlx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIn(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		args       []interface{}
+		expectSql  string
+		expectArgs []interface{}
+	}{
+		{
+			name:       "no slice args",
+			query:      "select name from users where id = ?",
+			args:       []interface{}{1},
+			expectSql:  "select name from users where id = ?",
+			expectArgs: []interface{}{1},
+		},
+		{
+			name:       "single slice",
+			query:      "select name from users where id in (?)",
+			args:       []interface{}{[]int64{1, 2, 3}},
+			expectSql:  "select name from users where id in (?,?,?)",
+			expectArgs: []interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:       "mixed args",
+			query:      "select name from users where id in (?) and age > ?",
+			args:       []interface{}{[]string{"a", "b"}, 18},
+			expectSql:  "select name from users where id in (?,?) and age > ?",
+			expectArgs: []interface{}{"a", "b", 18},
+		},
+		{
+			name:       "empty slice as null",
+			query:      "select name from users where id in (?)",
+			args:       []interface{}{[]int{}},
+			expectSql:  "select name from users where id in (NULL)",
+			expectArgs: []interface{}{},
+		},
+		{
+			name:       "bytes not expanded",
+			query:      "select name from users where id in (?) and hash = ?",
+			args:       []interface{}{[]int{1}, []byte("raw")},
+			expectSql:  "select name from users where id in (?) and hash = ?",
+			expectArgs: []interface{}{1, []byte("raw")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualSql, actualArgs, err := In(test.query, test.args...)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectSql, actualSql)
+			assert.EqualValues(t, test.expectArgs, actualArgs)
+		})
+	}
+}
+
+func TestInEmptyPolicy(t *testing.T) {
+	SetEmptyInPolicy(EmptyInAsError)
+	defer SetEmptyInPolicy(EmptyInAsNull)
+
+	_, _, err := In("select name from users where id in (?)", []int{})
+	assert.Equal(t, ErrEmptyInArgs, err)
+}
+
+func TestInArgsMismatch(t *testing.T) {
+	_, _, err := In("select name from users where id in (?)", []int{1}, 2)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,312 @@
+package sqlx
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const (
+	defaultMigrationTable = "schema_migrations"
+	defaultLockKey        = "go_zero_migrations"
+	lockTimeoutSeconds    = 30
+	upSuffix              = ".up.sql"
+	downSuffix            = ".down.sql"
+)
+
+var (
+	// ErrLockNotAcquired is an error that indicates the migration lock is held by another instance.
+	ErrLockNotAcquired = errors.New("migration lock not acquired")
+	// ErrMissingMigrationFile is an error that indicates a migration misses the file for the direction.
+	ErrMissingMigrationFile = errors.New("missing migration file")
+)
+
+type (
+	// MigrateOption defines the method to customize a Migrator.
+	MigrateOption func(m *Migrator)
+
+	// A Migrator applies ordered .sql migration files from a directory,
+	// tracking the applied versions in a table. Files are named
+	// <version>_<name>.up.sql and <version>_<name>.down.sql. An advisory
+	// lock keeps concurrent instances from racing on startup.
+	Migrator struct {
+		conn    SqlConn
+		dir     string
+		table   string
+		lockKey string
+		useLock bool
+	}
+
+	migration struct {
+		version int64
+		name    string
+		up      string
+		down    string
+	}
+)
+
+// NewMigrator returns a Migrator that applies the migrations in dir with conn.
+func NewMigrator(conn SqlConn, dir string, opts ...MigrateOption) *Migrator {
+	m := &Migrator{
+		conn:    conn,
+		dir:     dir,
+		table:   defaultMigrationTable,
+		lockKey: defaultLockKey,
+		useLock: true,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Down rolls back up to steps applied migrations, newest first.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return nil
+	}
+
+	migrations, err := loadMigrations(m.dir)
+	if err != nil {
+		return err
+	}
+
+	byVersion := make(map[int64]migration, len(migrations))
+	for _, each := range migrations {
+		byVersion[each.version] = each
+	}
+
+	return m.withLock(func() error {
+		if err := m.ensureTable(); err != nil {
+			return err
+		}
+
+		var versions []int64
+		if err := m.conn.QueryRowsPartial(&versions, fmt.Sprintf(
+			"SELECT version FROM %s ORDER BY version DESC", m.table)); err != nil {
+			return err
+		}
+		if len(versions) > steps {
+			versions = versions[:steps]
+		}
+
+		for _, version := range versions {
+			each, ok := byVersion[version]
+			if !ok || len(each.down) == 0 {
+				return fmt.Errorf("migration %d: %w", version, ErrMissingMigrationFile)
+			}
+
+			content, err := ioutil.ReadFile(each.down)
+			if err != nil {
+				return err
+			}
+
+			logx.Infof("reverting migration %d_%s", each.version, each.name)
+			if _, err := m.conn.Exec(string(content)); err != nil {
+				return fmt.Errorf("migration %d_%s revert failed: %w", each.version, each.name, err)
+			}
+			if _, err := m.conn.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", m.table),
+				each.version); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Up applies all pending migrations in version order.
+func (m *Migrator) Up() error {
+	migrations, err := loadMigrations(m.dir)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(func() error {
+		if err := m.ensureTable(); err != nil {
+			return err
+		}
+
+		applied, err := m.appliedVersions()
+		if err != nil {
+			return err
+		}
+
+		for _, each := range migrations {
+			if applied[each.version] {
+				continue
+			}
+			if len(each.up) == 0 {
+				return fmt.Errorf("migration %d: %w", each.version, ErrMissingMigrationFile)
+			}
+
+			content, err := ioutil.ReadFile(each.up)
+			if err != nil {
+				return err
+			}
+
+			logx.Infof("applying migration %d_%s", each.version, each.name)
+			if _, err := m.conn.Exec(string(content)); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", each.version, each.name, err)
+			}
+			if _, err := m.conn.Exec(fmt.Sprintf("INSERT INTO %s (version, name) VALUES (?, ?)",
+				m.table), each.version, each.name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// WithLockKey customizes a Migrator with the advisory lock key.
+func WithLockKey(key string) MigrateOption {
+	return func(m *Migrator) {
+		m.lockKey = key
+	}
+}
+
+// WithMigrationTable customizes a Migrator with the table that tracks applied versions.
+func WithMigrationTable(table string) MigrateOption {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithoutLock customizes a Migrator to skip the advisory lock,
+// for drivers without GET_LOCK support.
+func WithoutLock() MigrateOption {
+	return func(m *Migrator) {
+		m.useLock = false
+	}
+}
+
+func (m *Migrator) appliedVersions() (map[int64]bool, error) {
+	var versions []int64
+	if err := m.conn.QueryRowsPartial(&versions, fmt.Sprintf(
+		"SELECT version FROM %s", m.table)); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) ensureTable() error {
+	_, err := m.conn.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+version bigint NOT NULL PRIMARY KEY,
+name varchar(255) NOT NULL DEFAULT '',
+applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`, m.table))
+	return err
+}
+
+func (m *Migrator) withLock(fn func() error) error {
+	if !m.useLock {
+		return fn()
+	}
+
+	// a transaction pins a single session, so the advisory lock and its
+	// release happen on the same connection.
+	return m.conn.Transact(func(session Session) error {
+		var locked int
+		if err := session.QueryRow(&locked, "SELECT GET_LOCK(?, ?)",
+			m.lockKey, lockTimeoutSeconds); err != nil {
+			return err
+		}
+		if locked != 1 {
+			return ErrLockNotAcquired
+		}
+		defer func() {
+			var released int
+			if err := session.QueryRow(&released, "SELECT RELEASE_LOCK(?)", m.lockKey); err != nil {
+				logx.Errorf("failed to release migration lock: %v", err)
+			}
+		}()
+
+		return fn()
+	})
+}
+
+func loadMigrations(dir string) ([]migration, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*migration)
+	var versions []int64
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		name := file.Name()
+		var down bool
+		switch {
+		case strings.HasSuffix(name, upSuffix):
+			name = strings.TrimSuffix(name, upSuffix)
+		case strings.HasSuffix(name, downSuffix):
+			name = strings.TrimSuffix(name, downSuffix)
+			down = true
+		default:
+			continue
+		}
+
+		parts := strings.SplitN(name, "_", 2)
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("bad migration filename: %s", file.Name())
+		}
+		var title string
+		if len(parts) > 1 {
+			title = parts[1]
+		}
+
+		each, ok := byVersion[version]
+		if !ok {
+			each = &migration{
+				version: version,
+				name:    title,
+			}
+			byVersion[version] = each
+			versions = append(versions, version)
+		}
+
+		path := filepath.Join(dir, file.Name())
+		if down {
+			if len(each.down) > 0 {
+				return nil, fmt.Errorf("duplicate down migration for version %d", version)
+			}
+			each.down = path
+		} else {
+			if len(each.up) > 0 {
+				return nil, fmt.Errorf("duplicate up migration for version %d", version)
+			}
+			each.up = path
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	migrations := make([]migration, 0, len(versions))
+	for _, version := range versions {
+		migrations = append(migrations, *byVersion[version])
+	}
+
+	return migrations, nil
+}
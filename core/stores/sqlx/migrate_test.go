@@ -0,0 +1,120 @@
+package sqlx
+
+import (
+	"database/sql"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeMigration(t *testing.T, dir, name, content string) {
+	t.Helper()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func buildMigrateConn(datasource string) (mock sqlmock.Sqlmock) {
+	connManager.GetResource(datasource, func() (io.Closer, error) {
+		var db *sql.DB
+		var err error
+		db, mock, err = sqlmock.New()
+		return &pingedDB{
+			DB: db,
+		}, err
+	})
+
+	return
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "2_add_index.up.sql", "create index i on users (name);")
+	writeMigration(t, dir, "1_init.up.sql", "create table users (id bigint);")
+	writeMigration(t, dir, "1_init.down.sql", "drop table users;")
+	writeMigration(t, dir, "README.md", "not a migration")
+
+	migrations, err := loadMigrations(dir)
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].version)
+	assert.Equal(t, "init", migrations[0].name)
+	assert.NotEmpty(t, migrations[0].down)
+	assert.Equal(t, int64(2), migrations[1].version)
+	assert.Equal(t, "add_index", migrations[1].name)
+	assert.Empty(t, migrations[1].down)
+}
+
+func TestLoadMigrationsBadFilename(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "first.up.sql", "create table users (id bigint);")
+
+	_, err := loadMigrations(dir)
+	assert.NotNil(t, err)
+}
+
+func TestLoadMigrationsDuplicateVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "1_init.up.sql", "create table users (id bigint);")
+	writeMigration(t, dir, "1_again.up.sql", "create table posts (id bigint);")
+
+	_, err := loadMigrations(dir)
+	assert.NotNil(t, err)
+}
+
+func TestMigratorUp(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "1_init.up.sql", "create table users (id bigint)")
+	writeMigration(t, dir, "2_posts.up.sql", "create table posts (id bigint)")
+
+	mock := buildMigrateConn("migrate-up")
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectExec("create table posts").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(2), "posts").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	conn := NewMysql("migrate-up")
+	m := NewMigrator(conn, dir, WithoutLock())
+	assert.Nil(t, m.Up())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorDown(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "1_init.up.sql", "create table users (id bigint)")
+	writeMigration(t, dir, "1_init.down.sql", "drop table users")
+
+	mock := buildMigrateConn("migrate-down")
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectExec("drop table users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	conn := NewMysql("migrate-down")
+	m := NewMigrator(conn, dir, WithoutLock())
+	assert.Nil(t, m.Down(1))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorDownMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "1_init.up.sql", "create table users (id bigint)")
+
+	mock := buildMigrateConn("migrate-down-missing")
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+
+	conn := NewMysql("migrate-down-missing")
+	m := NewMigrator(conn, dir, WithoutLock())
+	assert.NotNil(t, m.Down(1))
+}
@@ -28,18 +28,46 @@ type rowsScanner interface {
 	Scan(v ...interface{}) error
 }
 
-func getTaggedFieldValueMap(v reflect.Value) (map[string]interface{}, error) {
+func getTaggedFieldValueMap(v reflect.Value, mapper ColumnMapper) (map[string]interface{}, error) {
 	rt := mapping.Deref(v.Type())
 	size := rt.NumField()
 	result := make(map[string]interface{}, size)
 
 	for i := 0; i < size; i++ {
-		key := parseTagName(rt.Field(i))
+		field := rt.Field(i)
+		valueField := reflect.Indirect(v).Field(i)
+		key := parseTagName(field)
 		if len(key) == 0 {
-			return nil, nil
+			if field.Anonymous && mapping.Deref(field.Type).Kind() == reflect.Struct {
+				// merge the fields of the embedded struct
+				if valueField.Kind() == reflect.Ptr && valueField.IsNil() {
+					valueField.Set(reflect.New(mapping.Deref(valueField.Type())))
+				}
+
+				embedded, err := getTaggedFieldValueMap(valueField, mapper)
+				if err != nil {
+					return nil, err
+				}
+				if embedded == nil {
+					return nil, nil
+				}
+
+				for k, val := range embedded {
+					if _, ok := result[k]; !ok {
+						result[k] = val
+					}
+				}
+				continue
+			}
+
+			if mapper == nil {
+				return nil, nil
+			}
+
+			// with a mapper configured, untagged fields match by field name
+			key = field.Name
 		}
 
-		valueField := reflect.Indirect(v).Field(i)
 		switch valueField.Kind() {
 		case reflect.Ptr:
 			if !valueField.CanInterface() {
@@ -61,13 +89,14 @@ func getTaggedFieldValueMap(v reflect.Value) (map[string]interface{}, error) {
 	return result, nil
 }
 
-func mapStructFieldsIntoSlice(v reflect.Value, columns []string, strict bool) ([]interface{}, error) {
+func mapStructFieldsIntoSlice(v reflect.Value, columns []string, strict bool,
+	mapper ColumnMapper) ([]interface{}, error) {
 	fields := unwrapFields(v)
 	if strict && len(columns) < len(fields) {
 		return nil, ErrNotMatchDestination
 	}
 
-	taggedMap, err := getTaggedFieldValueMap(v)
+	taggedMap, err := getTaggedFieldValueMap(v, mapper)
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +126,17 @@ func mapStructFieldsIntoSlice(v reflect.Value, columns []string, strict bool) ([
 		for i, column := range columns {
 			if tagged, ok := taggedMap[column]; ok {
 				values[i] = tagged
-			} else {
-				var anonymous interface{}
-				values[i] = &anonymous
+				continue
+			}
+			if mapper != nil {
+				if tagged, ok := taggedMap[mapper(column)]; ok {
+					values[i] = tagged
+					continue
+				}
 			}
+
+			var anonymous interface{}
+			values[i] = &anonymous
 		}
 	}
 
@@ -117,7 +153,30 @@ func parseTagName(field reflect.StructField) string {
 	return options[0]
 }
 
+func scanRowIntoMap(scanner rowsScanner, columns []string) (map[string]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		var value interface{}
+		values[i] = &value
+	}
+
+	if err := scanner.Scan(values...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		result[column] = *values[i].(*interface{})
+	}
+
+	return result, nil
+}
+
 func unmarshalRow(v interface{}, scanner rowsScanner, strict bool) error {
+	return unmarshalRowEx(v, scanner, strict, nil)
+}
+
+func unmarshalRowEx(v interface{}, scanner rowsScanner, strict bool, mapper ColumnMapper) error {
 	if !scanner.Next() {
 		if err := scanner.Err(); err != nil {
 			return err
@@ -149,18 +208,43 @@ func unmarshalRow(v interface{}, scanner rowsScanner, strict bool) error {
 			return err
 		}
 
-		values, err := mapStructFieldsIntoSlice(rve, columns, strict)
+		values, err := mapStructFieldsIntoSlice(rve, columns, strict, mapper)
 		if err != nil {
 			return err
 		}
 
 		return scanner.Scan(values...)
+	case reflect.Map:
+		if !rve.CanSet() {
+			return ErrNotSettable
+		}
+		if rte.Key().Kind() != reflect.String || rte.Elem().Kind() != reflect.Interface ||
+			rte.Elem().NumMethod() != 0 {
+			return ErrUnsupportedValueType
+		}
+
+		columns, err := scanner.Columns()
+		if err != nil {
+			return err
+		}
+
+		result, err := scanRowIntoMap(scanner, columns)
+		if err != nil {
+			return err
+		}
+
+		rve.Set(reflect.ValueOf(result).Convert(rte))
+		return nil
 	default:
 		return ErrUnsupportedValueType
 	}
 }
 
 func unmarshalRows(v interface{}, scanner rowsScanner, strict bool) error {
+	return unmarshalRowsEx(v, scanner, strict, nil)
+}
+
+func unmarshalRowsEx(v interface{}, scanner rowsScanner, strict bool, mapper ColumnMapper) error {
 	rv := reflect.ValueOf(v)
 	if err := mapping.ValidatePtr(&rv); err != nil {
 		return err
@@ -213,7 +297,7 @@ func unmarshalRows(v interface{}, scanner rowsScanner, strict bool) error {
 
 				for scanner.Next() {
 					value := reflect.New(base)
-					values, err := mapStructFieldsIntoSlice(value, columns, strict)
+					values, err := mapStructFieldsIntoSlice(value, columns, strict, mapper)
 					if err != nil {
 						return err
 					}
@@ -222,6 +306,27 @@ func unmarshalRows(v interface{}, scanner rowsScanner, strict bool) error {
 						return err
 					}
 
+					appendFn(value)
+				}
+			case reflect.Map:
+				if base.Key().Kind() != reflect.String || base.Elem().Kind() != reflect.Interface ||
+					base.Elem().NumMethod() != 0 {
+					return ErrUnsupportedValueType
+				}
+
+				columns, err := scanner.Columns()
+				if err != nil {
+					return err
+				}
+
+				for scanner.Next() {
+					result, err := scanRowIntoMap(scanner, columns)
+					if err != nil {
+						return err
+					}
+
+					value := reflect.New(base)
+					value.Elem().Set(reflect.ValueOf(result).Convert(base))
 					appendFn(value)
 				}
 			default:
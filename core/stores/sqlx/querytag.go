@@ -0,0 +1,66 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+type queryTagKey struct{}
+
+// WithQueryTag returns a context carrying tag, attributing the queries
+// issued with it to a business operation, e.g. checkout.reserve.
+func WithQueryTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, queryTagKey{}, tag)
+}
+
+// QueryTagFromContext returns the query tag carried by ctx.
+func QueryTagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(queryTagKey{}).(string)
+	return tag
+}
+
+// TagQuery prepends the query tag in ctx to q as a SQL comment, so the tag
+// shows up in the statement logs and in the server side slow-query log.
+func TagQuery(ctx context.Context, q string) string {
+	tag := QueryTagFromContext(ctx)
+	if len(tag) == 0 {
+		return q
+	}
+
+	// keep the tag from breaking out of the comment
+	tag = strings.ReplaceAll(tag, "*/", "")
+	return "/* " + tag + " */ " + q
+}
+
+// ExecCtx works like session.Exec, tagging the statement with the query tag in ctx.
+func ExecCtx(ctx context.Context, session Session, q string, args ...interface{}) (
+	sql.Result, error) {
+	return session.Exec(TagQuery(ctx, q), args...)
+}
+
+// QueryRowCtx works like session.QueryRow, tagging the statement with the query tag in ctx.
+func QueryRowCtx(ctx context.Context, session Session, v interface{}, q string,
+	args ...interface{}) error {
+	return session.QueryRow(v, TagQuery(ctx, q), args...)
+}
+
+// QueryRowPartialCtx works like session.QueryRowPartial, tagging the statement
+// with the query tag in ctx.
+func QueryRowPartialCtx(ctx context.Context, session Session, v interface{}, q string,
+	args ...interface{}) error {
+	return session.QueryRowPartial(v, TagQuery(ctx, q), args...)
+}
+
+// QueryRowsCtx works like session.QueryRows, tagging the statement with the query tag in ctx.
+func QueryRowsCtx(ctx context.Context, session Session, v interface{}, q string,
+	args ...interface{}) error {
+	return session.QueryRows(v, TagQuery(ctx, q), args...)
+}
+
+// QueryRowsPartialCtx works like session.QueryRowsPartial, tagging the statement
+// with the query tag in ctx.
+func QueryRowsPartialCtx(ctx context.Context, session Session, v interface{}, q string,
+	args ...interface{}) error {
+	return session.QueryRowsPartial(v, TagQuery(ctx, q), args...)
+}
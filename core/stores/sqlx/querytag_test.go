@@ -0,0 +1,41 @@
+package sqlx
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTagFromContext(t *testing.T) {
+	assert.Empty(t, QueryTagFromContext(context.Background()))
+
+	ctx := WithQueryTag(context.Background(), "checkout.reserve")
+	assert.Equal(t, "checkout.reserve", QueryTagFromContext(ctx))
+}
+
+func TestTagQuery(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "select 1", TagQuery(ctx, "select 1"))
+
+	ctx = WithQueryTag(ctx, "checkout.reserve")
+	assert.Equal(t, "/* checkout.reserve */ select 1", TagQuery(ctx, "select 1"))
+
+	ctx = WithQueryTag(ctx, "bad */ select 2; --")
+	assert.Equal(t, "/* bad  select 2; -- */ select 1", TagQuery(ctx, "select 1"))
+}
+
+func TestQueryRowCtxTagsStatement(t *testing.T) {
+	mock := buildMigrateConn("query-tag")
+	mock.ExpectQuery(regexp.QuoteMeta("/* checkout.reserve */ select name from users")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("first"))
+	conn := NewMysql("query-tag")
+
+	ctx := WithQueryTag(context.Background(), "checkout.reserve")
+	var name string
+	assert.Nil(t, QueryRowCtx(ctx, conn, &name, "select name from users limit 1"))
+	assert.Equal(t, "first", name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -43,11 +43,12 @@ type (
 	// Because CORBA doesn't support PREPARE, so we need to combine the
 	// query arguments into one string and do underlying query without arguments
 	commonSqlConn struct {
-		driverName string
-		datasource string
-		beginTx    beginnable
-		brk        breaker.Breaker
-		accept     func(error) bool
+		driverName   string
+		datasource   string
+		beginTx      beginnable
+		brk          breaker.Breaker
+		accept       func(error) bool
+		columnMapper ColumnMapper
 	}
 
 	sessionConn interface {
@@ -56,8 +57,9 @@ type (
 	}
 
 	statement struct {
-		query string
-		stmt  *sql.Stmt
+		query  string
+		stmt   *sql.Stmt
+		mapper ColumnMapper
 	}
 
 	stmtConn interface {
@@ -112,8 +114,9 @@ func (db *commonSqlConn) Prepare(query string) (stmt StmtSession, err error) {
 		}
 
 		stmt = statement{
-			query: query,
-			stmt:  st,
+			query:  query,
+			stmt:   st,
+			mapper: db.columnMapper,
 		}
 		return nil
 	}, db.acceptable)
@@ -123,25 +126,25 @@ func (db *commonSqlConn) Prepare(query string) (stmt StmtSession, err error) {
 
 func (db *commonSqlConn) QueryRow(v interface{}, q string, args ...interface{}) error {
 	return db.queryRows(func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, true)
+		return unmarshalRowEx(v, rows, true, db.columnMapper)
 	}, q, args...)
 }
 
 func (db *commonSqlConn) QueryRowPartial(v interface{}, q string, args ...interface{}) error {
 	return db.queryRows(func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, false)
+		return unmarshalRowEx(v, rows, false, db.columnMapper)
 	}, q, args...)
 }
 
 func (db *commonSqlConn) QueryRows(v interface{}, q string, args ...interface{}) error {
 	return db.queryRows(func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, true)
+		return unmarshalRowsEx(v, rows, true, db.columnMapper)
 	}, q, args...)
 }
 
 func (db *commonSqlConn) QueryRowsPartial(v interface{}, q string, args ...interface{}) error {
 	return db.queryRows(func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, false)
+		return unmarshalRowsEx(v, rows, false, db.columnMapper)
 	}, q, args...)
 }
 
@@ -188,24 +191,24 @@ func (s statement) Exec(args ...interface{}) (sql.Result, error) {
 
 func (s statement) QueryRow(v interface{}, args ...interface{}) error {
 	return queryStmt(s.stmt, func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, true)
+		return unmarshalRowEx(v, rows, true, s.mapper)
 	}, s.query, args...)
 }
 
 func (s statement) QueryRowPartial(v interface{}, args ...interface{}) error {
 	return queryStmt(s.stmt, func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, false)
+		return unmarshalRowEx(v, rows, false, s.mapper)
 	}, s.query, args...)
 }
 
 func (s statement) QueryRows(v interface{}, args ...interface{}) error {
 	return queryStmt(s.stmt, func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, true)
+		return unmarshalRowsEx(v, rows, true, s.mapper)
 	}, s.query, args...)
 }
 
 func (s statement) QueryRowsPartial(v interface{}, args ...interface{}) error {
 	return queryStmt(s.stmt, func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, false)
+		return unmarshalRowsEx(v, rows, false, s.mapper)
 	}, s.query, args...)
 }
@@ -11,6 +11,11 @@ import (
 const slowThreshold = time.Millisecond * 500
 
 func exec(conn sessionConn, q string, args ...interface{}) (sql.Result, error) {
+	q, args, err := expandIn(q, args)
+	if err != nil {
+		return nil, err
+	}
+
 	stmt, err := format(q, args...)
 	if err != nil {
 		return nil, err
@@ -53,6 +58,11 @@ func execStmt(conn stmtConn, q string, args ...interface{}) (sql.Result, error)
 }
 
 func query(conn sessionConn, scanner func(*sql.Rows) error, q string, args ...interface{}) error {
+	q, args, err := expandIn(q, args)
+	if err != nil {
+		return err
+	}
+
 	stmt, err := format(q, args...)
 	if err != nil {
 		return err
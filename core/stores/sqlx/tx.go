@@ -16,6 +16,7 @@ type (
 
 	txSession struct {
 		*sql.Tx
+		mapper ColumnMapper
 	}
 )
 
@@ -30,32 +31,33 @@ func (t txSession) Prepare(q string) (StmtSession, error) {
 	}
 
 	return statement{
-		query: q,
-		stmt:  stmt,
+		query:  q,
+		stmt:   stmt,
+		mapper: t.mapper,
 	}, nil
 }
 
 func (t txSession) QueryRow(v interface{}, q string, args ...interface{}) error {
 	return query(t.Tx, func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, true)
+		return unmarshalRowEx(v, rows, true, t.mapper)
 	}, q, args...)
 }
 
 func (t txSession) QueryRowPartial(v interface{}, q string, args ...interface{}) error {
 	return query(t.Tx, func(rows *sql.Rows) error {
-		return unmarshalRow(v, rows, false)
+		return unmarshalRowEx(v, rows, false, t.mapper)
 	}, q, args...)
 }
 
 func (t txSession) QueryRows(v interface{}, q string, args ...interface{}) error {
 	return query(t.Tx, func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, true)
+		return unmarshalRowsEx(v, rows, true, t.mapper)
 	}, q, args...)
 }
 
 func (t txSession) QueryRowsPartial(v interface{}, q string, args ...interface{}) error {
 	return query(t.Tx, func(rows *sql.Rows) error {
-		return unmarshalRows(v, rows, false)
+		return unmarshalRowsEx(v, rows, false, t.mapper)
 	}, q, args...)
 }
 
@@ -77,16 +79,22 @@ func transact(db *commonSqlConn, b beginnable, fn func(Session) error) (err erro
 		return err
 	}
 
-	return transactOnConn(conn, b, fn)
+	return transactOnConn(conn, b, fn, db.columnMapper)
 }
 
-func transactOnConn(conn *sql.DB, b beginnable, fn func(Session) error) (err error) {
+func transactOnConn(conn *sql.DB, b beginnable, fn func(Session) error,
+	mapper ColumnMapper) (err error) {
 	var tx trans
 	tx, err = b(conn)
 	if err != nil {
 		return
 	}
 
+	if ts, ok := tx.(txSession); ok {
+		ts.mapper = mapper
+		tx = ts
+	}
+
 	defer func() {
 		if p := recover(); p != nil {
 			if e := tx.Rollback(); e != nil {
@@ -61,7 +61,7 @@ func TestTransactCommit(t *testing.T) {
 	mock := &mockTx{}
 	err := transactOnConn(nil, beginMock(mock), func(Session) error {
 		return nil
-	})
+	}, nil)
 	assert.Equal(t, mockCommit, mock.status)
 	assert.Nil(t, err)
 }
@@ -70,7 +70,7 @@ func TestTransactRollback(t *testing.T) {
 	mock := &mockTx{}
 	err := transactOnConn(nil, beginMock(mock), func(Session) error {
 		return errors.New("rollback")
-	})
+	}, nil)
 	assert.Equal(t, mockRollback, mock.status)
 	assert.NotNil(t, err)
 }
@@ -0,0 +1,71 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const warmupTimeout = time.Second * 5
+
+// Warmup eagerly opens and validates up to n pooled connections of conn,
+// so the first requests after deploy don't pay the connection setup cost.
+func Warmup(ctx context.Context, conn SqlConn, n int) error {
+	db, ok := conn.(*commonSqlConn)
+	if !ok {
+		return fmt.Errorf("warmup is not supported on %T", conn)
+	}
+
+	return db.Warmup(ctx, n)
+}
+
+// WarmupChecker returns a func that warms up conn with n connections,
+// suitable to register as a readiness checker on a rest.HealthManager.
+func WarmupChecker(conn SqlConn, n int) func() error {
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+		defer cancel()
+
+		return Warmup(ctx, conn, n)
+	}
+}
+
+// Warmup eagerly opens and validates up to n pooled connections.
+func (db *commonSqlConn) Warmup(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	pool, err := getSqlConn(db.driverName, db.datasource)
+	if err != nil {
+		logInstanceError(db.datasource, err)
+		return err
+	}
+
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, each := range conns {
+			if err := each.Close(); err != nil {
+				logx.Error(err)
+			}
+		}
+	}()
+
+	// hold the connections while pinging, so the pool actually grows to n
+	for i := 0; i < n; i++ {
+		conn, err := pool.Conn(ctx)
+		if err != nil {
+			return err
+		}
+
+		conns = append(conns, conn)
+		if err := conn.PingContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package sqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmup(t *testing.T) {
+	buildMigrateConn("warmup")
+	conn := NewMysql("warmup")
+
+	assert.Nil(t, Warmup(context.Background(), conn, 2))
+	assert.Nil(t, Warmup(context.Background(), conn, 0))
+	assert.Nil(t, WarmupChecker(conn, 2)())
+}
+
+func TestWarmupUnsupportedConn(t *testing.T) {
+	assert.NotNil(t, Warmup(context.Background(), nil, 1))
+}
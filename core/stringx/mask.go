@@ -0,0 +1,61 @@
+package stringx
+
+import "strings"
+
+const maskRune = '*'
+
+// MaskEmail masks the local part of an email address, keeping its first rune
+// and the full domain, like `j***@example.com`.
+// Strings without an `@` are masked entirely.
+func MaskEmail(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return MaskMiddle(s, 0, 0, maskRune)
+	}
+
+	return MaskMiddle(s[:at], 1, 0, maskRune) + s[at:]
+}
+
+// MaskPhone masks a phone number, keeping the first 3 and last 2 runes,
+// like `138*****21`. Short numbers are masked entirely.
+func MaskPhone(s string) string {
+	return MaskMiddle(s, 3, 2, maskRune)
+}
+
+// MaskMiddle masks the middle part of s with the given mask rune,
+// keeping keepStart runes at the beginning and keepEnd runes at the end.
+// If s is too short to keep both ends without revealing most of it,
+// the whole string is masked.
+func MaskMiddle(s string, keepStart, keepEnd int, mask rune) string {
+	if len(s) == 0 {
+		return s
+	}
+	if keepStart < 0 {
+		keepStart = 0
+	}
+	if keepEnd < 0 {
+		keepEnd = 0
+	}
+
+	chars := []rune(s)
+	size := len(chars)
+	// don't over-reveal short strings, mask all if nothing would be hidden
+	if keepStart+keepEnd >= size {
+		keepStart = 0
+		keepEnd = 0
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(s))
+	for i := 0; i < keepStart; i++ {
+		builder.WriteRune(chars[i])
+	}
+	for i := keepStart; i < size-keepEnd; i++ {
+		builder.WriteRune(mask)
+	}
+	for i := size - keepEnd; i < size; i++ {
+		builder.WriteRune(chars[i])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,39 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskMiddle(t *testing.T) {
+	tests := []struct {
+		input     string
+		keepStart int
+		keepEnd   int
+		expect    string
+	}{
+		{"", 1, 1, ""},
+		{"1234567890", 3, 2, "123*****90"},
+		{"abc", 2, 2, "***"},
+		{"ab", 1, 1, "**"},
+		{"a", 0, 0, "*"},
+		{"12345", -1, -1, "*****"},
+		{"你好世界真美", 1, 1, "你****美"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expect, MaskMiddle(test.input, test.keepStart, test.keepEnd, '*'), test.input)
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "j***@example.com", MaskEmail("john@example.com"))
+	assert.Equal(t, "*@example.com", MaskEmail("j@example.com"))
+	assert.Equal(t, "*********", MaskEmail("not-email"))
+}
+
+func TestMaskPhone(t *testing.T) {
+	assert.Equal(t, "138******21", MaskPhone("13800138021"))
+	assert.Equal(t, "*****", MaskPhone("12345"))
+}
@@ -0,0 +1,64 @@
+package stringx
+
+// A PathTrie is a trie that maps string prefixes to values,
+// supporting longest-prefix match, typically used for routing and ACL rules.
+// The zero value is not usable, use NewPathTrie.
+type PathTrie[T any] struct {
+	root *pathTrieNode[T]
+}
+
+type pathTrieNode[T any] struct {
+	children map[rune]*pathTrieNode[T]
+	value    T
+	end      bool
+}
+
+// NewPathTrie returns a PathTrie.
+func NewPathTrie[T any]() *PathTrie[T] {
+	return &PathTrie[T]{
+		root: newPathTrieNode[T](),
+	}
+}
+
+// Add adds prefix with given value into t,
+// adding an existing prefix overwrites its value.
+func (t *PathTrie[T]) Add(prefix string, value T) {
+	cur := t.root
+	for _, ch := range prefix {
+		child, ok := cur.children[ch]
+		if !ok {
+			child = newPathTrieNode[T]()
+			cur.children[ch] = child
+		}
+		cur = child
+	}
+
+	cur.value = value
+	cur.end = true
+}
+
+// LongestPrefix returns the value of the longest added prefix of s,
+// false if no added prefix matches.
+func (t *PathTrie[T]) LongestPrefix(s string) (value T, matched bool) {
+	cur := t.root
+	for _, ch := range s {
+		child, ok := cur.children[ch]
+		if !ok {
+			break
+		}
+
+		cur = child
+		if cur.end {
+			value = cur.value
+			matched = true
+		}
+	}
+
+	return
+}
+
+func newPathTrieNode[T any]() *pathTrieNode[T] {
+	return &pathTrieNode[T]{
+		children: make(map[rune]*pathTrieNode[T]),
+	}
+}
@@ -0,0 +1,51 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathTrie(t *testing.T) {
+	trie := NewPathTrie[string]()
+	trie.Add("/api", "api")
+	trie.Add("/api/v1/users", "users")
+	trie.Add("/api/v1", "v1")
+
+	tests := []struct {
+		input   string
+		value   string
+		matched bool
+	}{
+		{"/api/v1/users/1", "users", true},
+		{"/api/v1/orders", "v1", true},
+		{"/api/v2", "api", true},
+		{"/static", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		value, matched := trie.LongestPrefix(test.input)
+		assert.Equal(t, test.matched, matched, test.input)
+		assert.Equal(t, test.value, value, test.input)
+	}
+}
+
+func TestPathTrieOverwrite(t *testing.T) {
+	trie := NewPathTrie[int]()
+	trie.Add("/api", 1)
+	trie.Add("/api", 2)
+
+	value, matched := trie.LongestPrefix("/api/users")
+	assert.True(t, matched)
+	assert.Equal(t, 2, value)
+}
+
+func TestPathTrieUnicode(t *testing.T) {
+	trie := NewPathTrie[string]()
+	trie.Add("/用户", "users")
+
+	value, matched := trie.LongestPrefix("/用户/1")
+	assert.True(t, matched)
+	assert.Equal(t, "users", value)
+}
@@ -1,8 +1,14 @@
 package stringx
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 type (
+	// ReplacerOption defines the method to customize a Replacer.
+	ReplacerOption func(r *replacer)
+
 	// Replacer interface wraps the Replace method.
 	Replacer interface {
 		Replace(text string) string
@@ -10,22 +16,42 @@ type (
 
 	replacer struct {
 		node
-		mapping map[string]string
+		mapping    map[string]string
+		ignoreCase bool
 	}
 )
 
 // NewReplacer returns a Replacer.
-func NewReplacer(mapping map[string]string) Replacer {
+func NewReplacer(mapping map[string]string, opts ...ReplacerOption) Replacer {
 	rep := &replacer{
 		mapping: mapping,
 	}
-	for k := range mapping {
+	for _, opt := range opts {
+		opt(rep)
+	}
+
+	if rep.ignoreCase {
+		lowered := make(map[string]string, len(mapping))
+		for k, v := range mapping {
+			lowered[strings.ToLower(k)] = v
+		}
+		rep.mapping = lowered
+	}
+	for k := range rep.mapping {
 		rep.add(k)
 	}
 
 	return rep
 }
 
+// WithIgnoreCase customizes a Replacer to match keywords case-insensitively,
+// the replacement values are written as given.
+func WithIgnoreCase() ReplacerOption {
+	return func(r *replacer) {
+		r.ignoreCase = true
+	}
+}
+
 func (r *replacer) Replace(text string) string {
 	var builder strings.Builder
 	chars := []rune(text)
@@ -33,7 +59,7 @@ func (r *replacer) Replace(text string) string {
 	start := -1
 
 	for i := 0; i < size; i++ {
-		child, ok := r.children[chars[i]]
+		child, ok := r.children[r.matchRune(chars[i])]
 		if !ok {
 			builder.WriteRune(chars[i])
 			continue
@@ -49,7 +75,7 @@ func (r *replacer) Replace(text string) string {
 
 		j := i + 1
 		for ; j < size; j++ {
-			grandchild, ok := child.children[chars[j]]
+			grandchild, ok := child.children[r.matchRune(chars[j])]
 			if !ok {
 				break
 			}
@@ -63,7 +89,7 @@ func (r *replacer) Replace(text string) string {
 
 		if end > 0 {
 			i = j - 1
-			builder.WriteString(r.mapping[string(chars[start:end])])
+			builder.WriteString(r.mapping[r.matchKey(string(chars[start:end]))])
 		} else {
 			builder.WriteRune(chars[i])
 		}
@@ -72,3 +98,19 @@ func (r *replacer) Replace(text string) string {
 
 	return builder.String()
 }
+
+func (r *replacer) matchKey(key string) string {
+	if r.ignoreCase {
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+func (r *replacer) matchRune(ch rune) rune {
+	if r.ignoreCase {
+		return unicode.ToLower(ch)
+	}
+
+	return ch
+}
@@ -42,3 +42,24 @@ func TestReplacer_ReplaceMultiMatches(t *testing.T) {
 	}
 	assert.Equal(t, "零一23四五一23四五", NewReplacer(mapping).Replace("零一二三四五一二三四五"))
 }
+
+func TestReplacer_ReplaceIgnoreCase(t *testing.T) {
+	mapping := map[string]string{
+		"Bad":  "good",
+		"word": "term",
+	}
+	rep := NewReplacer(mapping, WithIgnoreCase())
+	assert.Equal(t, "good good good", rep.Replace("bad Bad BAD"))
+	assert.Equal(t, "a term here", rep.Replace("a WORD here"))
+}
+
+func TestReplacer_ReplaceIgnoreCaseMixedRunes(t *testing.T) {
+	mapping := map[string]string{
+		"敏感Word": "***",
+	}
+	rep := NewReplacer(mapping, WithIgnoreCase())
+	assert.Equal(t, "前缀***后缀", rep.Replace("前缀敏感WORD后缀"))
+	assert.Equal(t, "前缀***后缀", rep.Replace("前缀敏感word后缀"))
+	// no partial multibyte match
+	assert.Equal(t, "前缀敏感wor后缀", rep.Replace("前缀敏感wor后缀"))
+}
@@ -0,0 +1,32 @@
+package syncx
+
+import "sync/atomic"
+
+// An AtomicValue is a typed wrapper around atomic.Pointer,
+// suitable for values that are swapped as a whole and read on every request,
+// like hot-reloaded config structs.
+type AtomicValue[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewAtomicValue returns an AtomicValue holding val.
+func NewAtomicValue[T any](val T) *AtomicValue[T] {
+	var v AtomicValue[T]
+	v.Store(val)
+	return &v
+}
+
+// Load returns the stored value, or the zero value of T if nothing stored yet.
+func (v *AtomicValue[T]) Load() T {
+	if p := v.p.Load(); p != nil {
+		return *p
+	}
+
+	var zero T
+	return zero
+}
+
+// Store sets the value of v to val.
+func (v *AtomicValue[T]) Store(val T) {
+	v.p.Store(&val)
+}
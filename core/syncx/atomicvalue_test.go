@@ -0,0 +1,37 @@
+package syncx
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicValue(t *testing.T) {
+	var v AtomicValue[string]
+	assert.Equal(t, "", v.Load())
+	v.Store("hello")
+	assert.Equal(t, "hello", v.Load())
+	v.Store("world")
+	assert.Equal(t, "world", v.Load())
+}
+
+func TestNewAtomicValue(t *testing.T) {
+	type config struct {
+		Timeout int
+	}
+
+	v := NewAtomicValue(config{Timeout: 5})
+	assert.Equal(t, 5, v.Load().Timeout)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(timeout int) {
+			defer wg.Done()
+			v.Store(config{Timeout: timeout})
+			v.Load()
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,44 @@
+package syncx
+
+import "sync"
+
+// A ResettableOnce is like sync.Once, but only latches on success,
+// and can be re-armed with Reset. A failing fn leaves it un-fired,
+// so the next Do retries. Typically used for lazy init that should
+// retry on transient failures.
+type ResettableOnce struct {
+	lock sync.Mutex
+	done bool
+}
+
+// Do calls fn only if once is not fired yet, and latches on success.
+// If fn returns an error, once stays un-fired and the next Do calls fn again.
+func (once *ResettableOnce) Do(fn func() error) error {
+	once.lock.Lock()
+	defer once.lock.Unlock()
+
+	if once.done {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	once.done = true
+	return nil
+}
+
+// Done returns true if once is fired.
+func (once *ResettableOnce) Done() bool {
+	once.lock.Lock()
+	defer once.lock.Unlock()
+	return once.done
+}
+
+// Reset re-arms once, the next Do calls its fn again.
+func (once *ResettableOnce) Reset() {
+	once.lock.Lock()
+	defer once.lock.Unlock()
+	once.done = false
+}
@@ -0,0 +1,51 @@
+package syncx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResettableOnce(t *testing.T) {
+	var once ResettableOnce
+	var calls int
+
+	assert.False(t, once.Done())
+	assert.Nil(t, once.Do(func() error {
+		calls++
+		return nil
+	}))
+	assert.True(t, once.Done())
+	assert.Nil(t, once.Do(func() error {
+		calls++
+		return nil
+	}))
+	assert.Equal(t, 1, calls)
+
+	once.Reset()
+	assert.False(t, once.Done())
+	assert.Nil(t, once.Do(func() error {
+		calls++
+		return nil
+	}))
+	assert.Equal(t, 2, calls)
+}
+
+func TestResettableOnceError(t *testing.T) {
+	var once ResettableOnce
+	someErr := errors.New("some error")
+	var calls int
+
+	assert.Equal(t, someErr, once.Do(func() error {
+		calls++
+		return someErr
+	}))
+	assert.False(t, once.Done())
+	assert.Nil(t, once.Do(func() error {
+		calls++
+		return nil
+	}))
+	assert.True(t, once.Done())
+	assert.Equal(t, 2, calls)
+}
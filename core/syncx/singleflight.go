@@ -0,0 +1,78 @@
+package syncx
+
+import "sync"
+
+type (
+	// SingleFlight is a typed version of SharedCalls, it lets the concurrent
+	// calls with the same key to share the call result without the type
+	// assertions that SharedCalls requires on the caller side.
+	SingleFlight[T any] interface {
+		Do(key string, fn func() (T, error)) (T, error)
+		DoEx(key string, fn func() (T, error)) (T, bool, error)
+	}
+
+	typedCall[T any] struct {
+		wg  sync.WaitGroup
+		val T
+		err error
+	}
+
+	flightGroup[T any] struct {
+		calls map[string]*typedCall[T]
+		lock  sync.Mutex
+	}
+)
+
+// NewSingleFlight returns a SingleFlight.
+func NewSingleFlight[T any]() SingleFlight[T] {
+	return &flightGroup[T]{
+		calls: make(map[string]*typedCall[T]),
+	}
+}
+
+func (g *flightGroup[T]) Do(key string, fn func() (T, error)) (T, error) {
+	c, done := g.createCall(key)
+	if done {
+		return c.val, c.err
+	}
+
+	g.makeCall(c, key, fn)
+	return c.val, c.err
+}
+
+func (g *flightGroup[T]) DoEx(key string, fn func() (T, error)) (val T, fresh bool, err error) {
+	c, done := g.createCall(key)
+	if done {
+		return c.val, false, c.err
+	}
+
+	g.makeCall(c, key, fn)
+	return c.val, true, c.err
+}
+
+func (g *flightGroup[T]) createCall(key string) (c *typedCall[T], done bool) {
+	g.lock.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.lock.Unlock()
+		c.wg.Wait()
+		return c, true
+	}
+
+	c = new(typedCall[T])
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.lock.Unlock()
+
+	return c, false
+}
+
+func (g *flightGroup[T]) makeCall(c *typedCall[T], key string, fn func() (T, error)) {
+	defer func() {
+		g.lock.Lock()
+		delete(g.calls, key)
+		g.lock.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+}
@@ -0,0 +1,66 @@
+package syncx
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleFlightDo(t *testing.T) {
+	g := NewSingleFlight[string]()
+	v, err := g.Do("key", func() (string, error) {
+		return "bar", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "bar", v)
+}
+
+func TestSingleFlightDoErr(t *testing.T) {
+	g := NewSingleFlight[int]()
+	someErr := errors.New("some error")
+	v, err := g.Do("key", func() (int, error) {
+		return 0, someErr
+	})
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, 0, v)
+}
+
+func TestSingleFlightDoDupSuppress(t *testing.T) {
+	g := NewSingleFlight[string]()
+	c := make(chan string)
+	var calls int32
+	fn := func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return <-c, nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := g.Do("key", fn)
+			assert.Nil(t, err)
+			assert.Equal(t, "bar", v)
+		}()
+	}
+	for atomic.LoadInt32(&calls) == 0 {
+	}
+	c <- "bar"
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestSingleFlightDoEx(t *testing.T) {
+	g := NewSingleFlight[string]()
+	v, fresh, err := g.DoEx("key", func() (string, error) {
+		return "bar", nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, fresh)
+	assert.Equal(t, "bar", v)
+}
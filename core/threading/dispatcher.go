@@ -0,0 +1,175 @@
+package threading
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	xrate "golang.org/x/time/rate"
+)
+
+const defaultQueueSize = 128
+
+// ErrDispatcherClosed is an error that indicates the dispatcher is closed.
+var ErrDispatcherClosed = errors.New("dispatcher is closed")
+
+type (
+	// Priority is the scheduling priority of a submitted task.
+	Priority int
+
+	// DispatcherOption defines the method to customize a Dispatcher.
+	DispatcherOption func(d *Dispatcher)
+
+	// A Dispatcher runs submitted tasks on a bounded worker pool,
+	// optionally capped by a token bucket rate limiter,
+	// high priority tasks are preferred over normal ones.
+	Dispatcher struct {
+		workers   int
+		queueSize int
+		limiter   *xrate.Limiter
+		high      chan func()
+		normal    chan func()
+		group     *RoutineGroup
+		lock      sync.RWMutex
+		closed    bool
+	}
+)
+
+const (
+	// PriorityNormal is the default task priority.
+	PriorityNormal Priority = iota
+	// PriorityHigh makes a task jump ahead of normal priority tasks.
+	PriorityHigh
+)
+
+// NewDispatcher returns a Dispatcher, call Stop to drain it.
+func NewDispatcher(opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		workers:   runtime.NumCPU(),
+		queueSize: defaultQueueSize,
+		group:     NewRoutineGroup(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.high = make(chan func(), d.queueSize)
+	d.normal = make(chan func(), d.queueSize)
+	for i := 0; i < d.workers; i++ {
+		d.group.RunSafe(d.worker)
+	}
+
+	return d
+}
+
+// WithQueueSize customizes a Dispatcher with the size of each priority queue.
+func WithQueueSize(size int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if size > 0 {
+			d.queueSize = size
+		}
+	}
+}
+
+// WithRate customizes a Dispatcher with a token bucket limiting
+// the task executions to rate per second.
+func WithRate(rate int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if rate > 0 {
+			d.limiter = xrate.NewLimiter(xrate.Limit(rate), rate)
+		}
+	}
+}
+
+// WithWorkers customizes a Dispatcher with the number of workers.
+func WithWorkers(workers int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if workers > 0 {
+			d.workers = workers
+		}
+	}
+}
+
+// Stop closes the dispatcher and waits until the queued tasks are drained,
+// further Submit calls return ErrDispatcherClosed.
+func (d *Dispatcher) Stop() {
+	d.lock.Lock()
+	if d.closed {
+		d.lock.Unlock()
+		return
+	}
+	d.closed = true
+	close(d.high)
+	close(d.normal)
+	d.lock.Unlock()
+
+	d.group.Wait()
+}
+
+// Submit queues a task with the given priority,
+// blocks when the queue is full.
+func (d *Dispatcher) Submit(task func(), priority Priority) error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+
+	if priority == PriorityHigh {
+		d.high <- task
+	} else {
+		d.normal <- task
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) execute(task func()) {
+	if d.limiter != nil {
+		// Wait only fails on context errors, and the context is never canceled
+		if err := d.limiter.Wait(context.Background()); err != nil {
+			return
+		}
+	}
+
+	RunSafe(task)
+}
+
+func (d *Dispatcher) worker() {
+	high, normal := d.high, d.normal
+
+	for {
+		// prefer high priority tasks, but don't starve normal ones forever,
+		// only skip them when a high priority task is ready.
+		select {
+		case task, ok := <-high:
+			if !ok {
+				high = nil
+				break
+			}
+			d.execute(task)
+			continue
+		default:
+		}
+
+		if high == nil && normal == nil {
+			return
+		}
+
+		select {
+		case task, ok := <-high:
+			if !ok {
+				high = nil
+				continue
+			}
+			d.execute(task)
+		case task, ok := <-normal:
+			if !ok {
+				normal = nil
+				continue
+			}
+			d.execute(task)
+		}
+	}
+}
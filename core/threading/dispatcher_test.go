@@ -0,0 +1,85 @@
+package threading
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	var count int32
+	dispatcher := NewDispatcher(WithWorkers(4))
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, dispatcher.Submit(func() {
+			atomic.AddInt32(&count, 1)
+		}, PriorityNormal))
+	}
+	dispatcher.Stop()
+	assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+}
+
+func TestDispatcherPriority(t *testing.T) {
+	var lock sync.Mutex
+	var order []string
+	gate := make(chan struct{})
+	dispatcher := NewDispatcher(WithWorkers(1))
+	// hold the only worker so the queued tasks compete on priority
+	assert.Nil(t, dispatcher.Submit(func() {
+		<-gate
+	}, PriorityNormal))
+	assert.Nil(t, dispatcher.Submit(func() {
+		lock.Lock()
+		order = append(order, "normal")
+		lock.Unlock()
+	}, PriorityNormal))
+	assert.Nil(t, dispatcher.Submit(func() {
+		lock.Lock()
+		order = append(order, "high")
+		lock.Unlock()
+	}, PriorityHigh))
+	close(gate)
+	dispatcher.Stop()
+
+	assert.Equal(t, []string{"high", "normal"}, order)
+}
+
+func TestDispatcherRate(t *testing.T) {
+	var count int32
+	dispatcher := NewDispatcher(WithWorkers(8), WithRate(100), WithQueueSize(256))
+	start := time.Now()
+	for i := 0; i < 150; i++ {
+		assert.Nil(t, dispatcher.Submit(func() {
+			atomic.AddInt32(&count, 1)
+		}, PriorityNormal))
+	}
+	dispatcher.Stop()
+
+	assert.Equal(t, int32(150), atomic.LoadInt32(&count))
+	// 100 burst free, the remaining 50 are paced at 100/s
+	assert.True(t, time.Since(start) >= time.Millisecond*400)
+}
+
+func TestDispatcherSubmitAfterStop(t *testing.T) {
+	dispatcher := NewDispatcher(WithWorkers(1))
+	dispatcher.Stop()
+	assert.Equal(t, ErrDispatcherClosed, dispatcher.Submit(func() {
+	}, PriorityHigh))
+	// Stop again is a no-op
+	dispatcher.Stop()
+}
+
+func TestDispatcherPanicTask(t *testing.T) {
+	var count int32
+	dispatcher := NewDispatcher(WithWorkers(2))
+	assert.Nil(t, dispatcher.Submit(func() {
+		panic("boom")
+	}, PriorityNormal))
+	assert.Nil(t, dispatcher.Submit(func() {
+		atomic.AddInt32(&count, 1)
+	}, PriorityNormal))
+	dispatcher.Stop()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
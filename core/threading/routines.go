@@ -4,8 +4,17 @@ import (
 	"bytes"
 	"runtime"
 	"strconv"
+	"sync"
 
-	"github.com/tal-tech/go-zero/core/rescue"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+// panicStackSize is the max size of the stack captured on panics.
+const panicStackSize = 64 << 10
+
+var (
+	panicLock    sync.RWMutex
+	panicHandler func(p interface{}, stack []byte)
 )
 
 // GoSafe runs the given fn using another goroutine, recovers if fn panics.
@@ -26,8 +35,36 @@ func RoutineId() uint64 {
 }
 
 // RunSafe runs the given fn, recovers if fn panics.
+// The full goroutine stack is logged, and the handler set with
+// SetPanicHandler is called with the recovered value and the stack.
 func RunSafe(fn func()) {
-	defer rescue.Recover()
+	defer func() {
+		if p := recover(); p != nil {
+			handlePanic(p)
+		}
+	}()
 
 	fn()
 }
+
+// SetPanicHandler sets the handler called when GoSafe/RunSafe recovers
+// a panic, e.g. to forward it to an error tracker.
+// Pass nil to remove a previously set handler.
+func SetPanicHandler(handler func(p interface{}, stack []byte)) {
+	panicLock.Lock()
+	panicHandler = handler
+	panicLock.Unlock()
+}
+
+func handlePanic(p interface{}) {
+	stack := make([]byte, panicStackSize)
+	stack = stack[:runtime.Stack(stack, false)]
+	logx.Errorf("%v\n%s", p, stack)
+
+	panicLock.RLock()
+	handler := panicHandler
+	panicLock.RUnlock()
+	if handler != nil {
+		handler(p, stack)
+	}
+}
@@ -34,3 +34,25 @@ func TestRunSafe(t *testing.T) {
 	<-ch
 	i++
 }
+
+func TestSetPanicHandler(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer SetPanicHandler(nil)
+
+	var recovered interface{}
+	var stack []byte
+	ch := make(chan lang.PlaceholderType)
+	SetPanicHandler(func(p interface{}, s []byte) {
+		recovered = p
+		stack = s
+		ch <- lang.Placeholder
+	})
+
+	GoSafe(func() {
+		panic("from background")
+	})
+
+	<-ch
+	assert.Equal(t, "from background", recovered)
+	assert.Contains(t, string(stack), "TestSetPanicHandler")
+}
@@ -0,0 +1,97 @@
+package timex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// A SteadyTick is one tick of a SteadyTicker.
+	SteadyTick struct {
+		// Time is when the tick fired.
+		Time time.Time
+		// Skipped is how many whole intervals were missed since the
+		// previous delivered tick, zero when on schedule.
+		Skipped int
+	}
+
+	// A SteadyTicker ticks like time.Ticker, but coalesces missed ticks
+	// after a pause (GC, suspend, slow consumer): it fires once, reports the
+	// number of skipped intervals, and realigns to the original schedule
+	// instead of bursting.
+	SteadyTicker interface {
+		Chan() <-chan SteadyTick
+		Stop()
+	}
+
+	steadyTicker struct {
+		c        chan SteadyTick
+		stopOnce sync.Once
+		done     chan struct{}
+	}
+)
+
+// NewSteadyTicker returns a SteadyTicker with given interval.
+func NewSteadyTicker(d time.Duration) SteadyTicker {
+	return NewSteadyTickerContext(context.Background(), d)
+}
+
+// NewSteadyTickerContext returns a SteadyTicker with given interval,
+// stopping when ctx is done.
+func NewSteadyTickerContext(ctx context.Context, d time.Duration) SteadyTicker {
+	st := &steadyTicker{
+		c:    make(chan SteadyTick, 1),
+		done: make(chan struct{}),
+	}
+	go st.run(ctx, d)
+
+	return st
+}
+
+func (st *steadyTicker) Chan() <-chan SteadyTick {
+	return st.c
+}
+
+func (st *steadyTicker) Stop() {
+	st.stopOnce.Do(func() {
+		close(st.done)
+	})
+}
+
+func (st *steadyTicker) run(ctx context.Context, d time.Duration) {
+	next := time.Now().Add(d)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	// skipped ticks carried over from a slow consumer
+	var pending int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-st.done:
+			return
+		case now := <-timer.C:
+			var skipped int
+			if behind := now.Sub(next); behind > 0 {
+				skipped = int(behind / d)
+			}
+			next = next.Add(time.Duration(skipped+1) * d)
+
+			tick := SteadyTick{
+				Time:    now,
+				Skipped: skipped + pending,
+			}
+			select {
+			case st.c <- tick:
+				pending = 0
+			default:
+				// consumer hasn't drained the last tick, coalesce
+				pending = tick.Skipped + 1
+			}
+
+			timer.Reset(time.Until(next))
+		}
+	}
+}
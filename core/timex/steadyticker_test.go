@@ -0,0 +1,65 @@
+package timex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSteadyTicker(t *testing.T) {
+	ticker := NewSteadyTicker(time.Millisecond * 10)
+	defer ticker.Stop()
+
+	tick := <-ticker.Chan()
+	assert.False(t, tick.Time.IsZero())
+	assert.Equal(t, 0, tick.Skipped)
+}
+
+func TestSteadyTickerCoalesce(t *testing.T) {
+	ticker := NewSteadyTicker(time.Millisecond * 10)
+	defer ticker.Stop()
+
+	<-ticker.Chan()
+	// simulate a pause, several intervals elapse undelivered
+	time.Sleep(time.Millisecond * 100)
+
+	var total int
+	deadline := time.After(time.Second)
+	for total < 5 {
+		select {
+		case tick := <-ticker.Chan():
+			total += tick.Skipped + 1
+		case <-deadline:
+			t.Fatal("timeout waiting for coalesced ticks")
+		}
+	}
+	assert.True(t, total >= 5)
+}
+
+func TestSteadyTickerContextStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := NewSteadyTickerContext(ctx, time.Millisecond*10)
+	<-ticker.Chan()
+	cancel()
+
+	// after cancellation the channel goes quiet
+	time.Sleep(time.Millisecond * 50)
+	for len(ticker.Chan()) > 0 {
+		<-ticker.Chan()
+	}
+	select {
+	case <-ticker.Chan():
+		t.Fatal("tick after context cancel")
+	case <-time.After(time.Millisecond * 50):
+	}
+}
+
+func TestSteadyTickerStopTwice(t *testing.T) {
+	ticker := NewSteadyTicker(time.Millisecond * 10)
+	assert.NotPanics(t, func() {
+		ticker.Stop()
+		ticker.Stop()
+	})
+}
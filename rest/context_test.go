@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/rest/handler"
+)
+
+func TestContextValues(t *testing.T) {
+	ctx := context.Background()
+	_, ok := RequestIDFromContext(ctx)
+	assert.False(t, ok)
+	_, ok = TenantFromContext(ctx)
+	assert.False(t, ok)
+	_, ok = UserFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = ContextWithRequestID(ctx, "req-1")
+	ctx = ContextWithTenant(ctx, "acme")
+	ctx = ContextWithUser(ctx, "alice")
+
+	requestID, ok := RequestIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", requestID)
+	tenant, ok := TenantFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	user, ok := UserFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", user)
+}
+
+func TestUserFromContextIdentityFallback(t *testing.T) {
+	ctx := handler.ContextWithIdentity(context.Background(), handler.Identity{
+		Subject: "bob",
+	})
+	user, ok := UserFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "bob", user)
+}
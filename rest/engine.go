@@ -30,6 +30,7 @@ type engine struct {
 	middlewares          []Middleware
 	shedder              load.Shedder
 	priorityShedder      load.Shedder
+	maxListenerConns     int
 }
 
 func newEngine(c RestConf) *engine {
@@ -66,11 +67,17 @@ func (s *engine) StartWithRouter(router httpx.Router) error {
 		return err
 	}
 
+	var opts []internal.StartOption
+	if s.maxListenerConns > 0 {
+		opts = append(opts, internal.WithMaxConns(s.maxListenerConns))
+	}
+
 	if len(s.conf.CertFile) == 0 && len(s.conf.KeyFile) == 0 {
-		return internal.StartHttp(s.conf.Host, s.conf.Port, router)
+		return internal.StartHttp(s.conf.Host, s.conf.Port, router, opts...)
 	}
 
-	return internal.StartHttps(s.conf.Host, s.conf.Port, s.conf.CertFile, s.conf.KeyFile, router)
+	return internal.StartHttps(s.conf.Host, s.conf.Port, s.conf.CertFile, s.conf.KeyFile,
+		router, opts...)
 }
 
 func (s *engine) appendAuthHandler(fr featuredRoutes, chain alice.Chain,
@@ -86,6 +93,10 @@ func (s *engine) appendAuthHandler(fr featuredRoutes, chain alice.Chain,
 		}
 	}
 
+	if len(fr.authenticators) > 0 {
+		chain = chain.Append(handler.AuthenticateHandler(fr.authenticators, s.unauthorizedCallback))
+	}
+
 	return verifier(chain)
 }
 
@@ -120,6 +131,9 @@ func (s *engine) bindRoute(fr featuredRoutes, router httpx.Router, metrics *stat
 		handler.GunzipHandler,
 	)
 	chain = s.appendAuthHandler(fr, chain, verifier)
+	if fr.dump.enabled {
+		chain = chain.Append(handler.DumpHandler(fr.dump.maxBytes, fr.dump.redactFields))
+	}
 
 	for _, middleware := range s.middlewares {
 		chain = chain.Append(convertMiddleware(middleware))
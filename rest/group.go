@@ -0,0 +1,59 @@
+package rest
+
+import "strings"
+
+// A RouteGroup registers routes under a shared path prefix with shared
+// middlewares, groups nest and compose.
+type RouteGroup struct {
+	server      *Server
+	prefix      string
+	middlewares []Middleware
+}
+
+// Group returns a RouteGroup mounting routes under prefix with the given
+// middlewares applied.
+func (e *Server) Group(prefix string, middlewares ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		server:      e,
+		prefix:      normalizePrefix(prefix),
+		middlewares: middlewares,
+	}
+}
+
+// Group returns a nested RouteGroup, prefixes concatenate and the parent
+// middlewares run before the child ones.
+func (g *RouteGroup) Group(prefix string, middlewares ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		server:      g.server,
+		prefix:      g.prefix + normalizePrefix(prefix),
+		middlewares: append(append([]Middleware{}, g.middlewares...), middlewares...),
+	}
+}
+
+// AddRoute adds given route into the group.
+func (g *RouteGroup) AddRoute(r Route, opts ...RouteOption) {
+	g.AddRoutes([]Route{r}, opts...)
+}
+
+// AddRoutes adds given routes into the group.
+func (g *RouteGroup) AddRoutes(rs []Route, opts ...RouteOption) {
+	prefixed := make([]Route, len(rs))
+	for i, r := range rs {
+		prefixed[i] = Route{
+			Method:  r.Method,
+			Path:    g.prefix + r.Path,
+			Handler: r.Handler,
+		}
+	}
+
+	g.server.AddRoutes(WithMiddlewares(g.middlewares, prefixed...), opts...)
+}
+
+func normalizePrefix(prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if len(prefix) > 0 && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return prefix
+}
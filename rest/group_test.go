@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePrefix(t *testing.T) {
+	assert.Equal(t, "/admin", normalizePrefix("admin"))
+	assert.Equal(t, "/admin", normalizePrefix("/admin"))
+	assert.Equal(t, "/admin", normalizePrefix("/admin/"))
+	assert.Equal(t, "", normalizePrefix(""))
+	assert.Equal(t, "", normalizePrefix("/"))
+}
+
+func TestGroupAddRoute(t *testing.T) {
+	server := newTestServer(t)
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next(w, r)
+			}
+		}
+	}
+
+	admin := server.Group("/admin", mw("auth"))
+	admin.AddRoute(Route{
+		Method: http.MethodGet,
+		Path:   "/users",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "handler")
+		},
+	})
+
+	routes := server.Routes()
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, "/admin/users", routes[0].Path)
+
+	for _, fr := range server.ngin.routes {
+		for _, route := range fr.routes {
+			route.Handler(nil, nil)
+		}
+	}
+	assert.Equal(t, []string{"auth", "handler"}, order)
+}
+
+func TestGroupNested(t *testing.T) {
+	server := newTestServer(t)
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next(w, r)
+			}
+		}
+	}
+
+	v1 := server.Group("/v1", mw("outer"))
+	admin := v1.Group("/admin", mw("inner"))
+	admin.AddRoutes([]Route{
+		{
+			Method: http.MethodGet,
+			Path:   "/users",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, "handler")
+			},
+		},
+	})
+
+	routes := server.Routes()
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, "/v1/admin/users", routes[0].Path)
+
+	for _, fr := range server.ngin.routes {
+		for _, route := range fr.routes {
+			route.Handler(nil, nil)
+		}
+	}
+	assert.Equal(t, []string{"outer", "inner", "handler"}, order)
+}
+
+func TestGroupSiblingsIndependent(t *testing.T) {
+	server := newTestServer(t)
+	parent := server.Group("/api")
+	a := parent.Group("/a")
+	b := parent.Group("/b")
+	a.AddRoute(Route{Method: http.MethodGet, Path: "/x", Handler: func(w http.ResponseWriter, r *http.Request) {}})
+	b.AddRoute(Route{Method: http.MethodGet, Path: "/y", Handler: func(w http.ResponseWriter, r *http.Request) {}})
+
+	var paths []string
+	for _, route := range server.Routes() {
+		paths = append(paths, route.Path)
+	}
+	assert.ElementsMatch(t, []string{"/api/a/x", "/api/b/y"}, paths)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNotAuthenticated is passed to the unauthorized callback when no
+// authenticator accepts the request.
+var ErrNotAuthenticated = errors.New("no authenticator accepted the request")
+
+type (
+	// An Identity is the authenticated caller of a request.
+	Identity struct {
+		// Subject identifies the caller, like a user id or cert common name.
+		Subject string
+		// Scheme is the name of the authenticator that accepted the request.
+		Scheme string
+		// Extra carries scheme specific attributes, like jwt claims.
+		Extra map[string]interface{}
+	}
+
+	// An Authenticator authenticates a request under one scheme.
+	Authenticator interface {
+		// Name returns the scheme name, recorded in the identity.
+		Name() string
+		// Authenticate returns the caller identity, or an error to let
+		// the next authenticator try.
+		Authenticate(r *http.Request) (Identity, error)
+	}
+
+	identityKey struct{}
+)
+
+// ContextWithIdentity returns a new context carrying the identity.
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity in ctx,
+// ok false when the request wasn't authenticated.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// AuthenticateHandler returns a middleware that tries the given
+// authenticators in order, the first that succeeds puts the identity
+// into the request context. When all fail the request is rejected
+// with 401, through callback when set.
+func AuthenticateHandler(authenticators []Authenticator,
+	callback UnauthorizedCallback) func(http.Handler) http.Handler {
+	if len(authenticators) == 0 {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var lastErr error
+			for _, authenticator := range authenticators {
+				identity, err := authenticator.Authenticate(r)
+				if err != nil {
+					lastErr = err
+					continue
+				}
+
+				identity.Scheme = authenticator.Name()
+				next.ServeHTTP(w, r.WithContext(ContextWithIdentity(r.Context(), identity)))
+				return
+			}
+
+			if lastErr == nil {
+				lastErr = ErrNotAuthenticated
+			}
+			unauthorized(w, r, lastErr, callback)
+		})
+	}
+}
+
+type apiKeyAuthenticator struct {
+	header string
+	keys   map[string]string
+}
+
+// NewApiKeyAuthenticator returns an Authenticator that matches the given
+// header against keys, a map from api key to subject.
+func NewApiKeyAuthenticator(header string, keys map[string]string) Authenticator {
+	return apiKeyAuthenticator{
+		header: header,
+		keys:   keys,
+	}
+}
+
+func (a apiKeyAuthenticator) Name() string {
+	return "apikey"
+}
+
+func (a apiKeyAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	key := r.Header.Get(a.header)
+	if len(key) == 0 {
+		return Identity{}, errors.New("missing api key")
+	}
+
+	subject, ok := a.keys[key]
+	if !ok {
+		return Identity{}, errors.New("unknown api key")
+	}
+
+	return Identity{Subject: subject}, nil
+}
+
+type mtlsAuthenticator struct{}
+
+// NewMtlsAuthenticator returns an Authenticator that takes the identity
+// from the verified client certificate's common name.
+func NewMtlsAuthenticator() Authenticator {
+	return mtlsAuthenticator{}
+}
+
+func (a mtlsAuthenticator) Name() string {
+	return "mtls"
+}
+
+func (a mtlsAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return Identity{}, errors.New("no client certificate")
+	}
+
+	return Identity{Subject: r.TLS.PeerCertificates[0].Subject.CommonName}, nil
+}
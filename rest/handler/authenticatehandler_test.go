@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAuthenticator struct {
+	name    string
+	subject string
+	err     error
+}
+
+func (a stubAuthenticator) Name() string {
+	return a.name
+}
+
+func (a stubAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	if a.err != nil {
+		return Identity{}, a.err
+	}
+
+	return Identity{Subject: a.subject}, nil
+}
+
+func TestIdentityContext(t *testing.T) {
+	_, ok := IdentityFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithIdentity(context.Background(), Identity{Subject: "kevin"})
+	identity, ok := IdentityFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "kevin", identity.Subject)
+}
+
+func TestAuthenticateHandlerFirstWins(t *testing.T) {
+	handler := AuthenticateHandler([]Authenticator{
+		stubAuthenticator{name: "jwt", err: errors.New("bad token")},
+		stubAuthenticator{name: "apikey", subject: "svc-a"},
+	}, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := IdentityFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "svc-a", identity.Subject)
+		assert.Equal(t, "apikey", identity.Scheme)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestAuthenticateHandlerAllFail(t *testing.T) {
+	handler := AuthenticateHandler([]Authenticator{
+		stubAuthenticator{name: "jwt", err: errors.New("bad token")},
+		stubAuthenticator{name: "apikey", err: errors.New("bad key")},
+	}, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestAuthenticateHandlerCallback(t *testing.T) {
+	var gotErr error
+	handler := AuthenticateHandler([]Authenticator{
+		stubAuthenticator{name: "jwt", err: errors.New("bad token")},
+	}, func(w http.ResponseWriter, r *http.Request, err error) {
+		gotErr = err
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.EqualError(t, gotErr, "bad token")
+}
+
+func TestAuthenticateHandlerNoAuthenticators(t *testing.T) {
+	handler := AuthenticateHandler(nil, nil)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestApiKeyAuthenticator(t *testing.T) {
+	auth := NewApiKeyAuthenticator("X-Api-Key", map[string]string{
+		"the-key": "svc-a",
+	})
+	assert.Equal(t, "apikey", auth.Name())
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	_, err := auth.Authenticate(req)
+	assert.NotNil(t, err)
+
+	req.Header.Set("X-Api-Key", "wrong")
+	_, err = auth.Authenticate(req)
+	assert.NotNil(t, err)
+
+	req.Header.Set("X-Api-Key", "the-key")
+	identity, err := auth.Authenticate(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "svc-a", identity.Subject)
+}
+
+func TestMtlsAuthenticator(t *testing.T) {
+	auth := NewMtlsAuthenticator()
+	assert.Equal(t, "mtls", auth.Name())
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	_, err := auth.Authenticate(req)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+// CoalesceHandler returns a middleware that single-flights concurrent
+// identical requests, so only one reaches the backend and all waiters
+// share the buffered response. The key defaults to method, path and
+// query, pass keyFn to override. Non-idempotent methods pass through.
+func CoalesceHandler(keyFn func(r *http.Request) string) func(http.Handler) http.Handler {
+	if keyFn == nil {
+		keyFn = defaultCoalesceKey
+	}
+
+	return func(next http.Handler) http.Handler {
+		flights := syncx.NewSingleFlight[*coalescedResponse]()
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := keyFn(r)
+			if len(key) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			resp, err := flights.Do(key, func() (*coalescedResponse, error) {
+				cw := newCoalescedResponse()
+				next.ServeHTTP(cw, r)
+				return cw, nil
+			})
+			if err != nil {
+				// can't happen, the flight never returns an error
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			for key, values := range resp.header {
+				for _, value := range values {
+					w.Header().Add(key, value)
+				}
+			}
+			w.WriteHeader(resp.code)
+			if _, err := w.Write(resp.buf.Bytes()); err != nil {
+				logx.WithContext(r.Context()).Error(err)
+			}
+		})
+	}
+}
+
+func defaultCoalesceKey(r *http.Request) string {
+	return r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery
+}
+
+type coalescedResponse struct {
+	header http.Header
+	code   int
+	buf    bytes.Buffer
+}
+
+func newCoalescedResponse() *coalescedResponse {
+	return &coalescedResponse{
+		header: make(http.Header),
+		code:   http.StatusOK,
+	}
+}
+
+func (r *coalescedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *coalescedResponse) Write(bs []byte) (int, error) {
+	return r.buf.Write(bs)
+}
+
+func (r *coalescedResponse) WriteHeader(code int) {
+	r.code = code
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesceHandlerSharesResponse(t *testing.T) {
+	var calls int32
+	gate := make(chan struct{})
+	handler := CoalesceHandler(nil)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-gate
+			w.Header().Set("X-Version", "1")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("payload"))
+		}))
+
+	const concurrency = 8
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, concurrency)
+	for i := 0; i < concurrency; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/item?id=1", nil)
+			responses[i] = httptest.NewRecorder()
+			handler.ServeHTTP(responses[i], req)
+		}()
+	}
+
+	// let the flights pile up behind the leader, then release
+	time.Sleep(time.Millisecond * 50)
+	close(gate)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, resp := range responses {
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "payload", resp.Body.String())
+		assert.Equal(t, "1", resp.Header().Get("X-Version"))
+	}
+}
+
+func TestCoalesceHandlerDifferentKeys(t *testing.T) {
+	var calls int32
+	handler := CoalesceHandler(nil)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	for _, url := range []string{"http://localhost/a", "http://localhost/b"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestCoalesceHandlerSkipsWrites(t *testing.T) {
+	var calls int32
+	handler := CoalesceHandler(func(r *http.Request) string {
+		return "same"
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestCoalesceHandlerEmptyKey(t *testing.T) {
+	var calls int32
+	handler := CoalesceHandler(func(r *http.Request) string {
+		return ""
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestDefaultCoalesceKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/item?id=1", nil)
+	assert.Equal(t, "GET /item?id=1", defaultCoalesceKey(req))
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+const (
+	defaultDumpBytes = 4096
+	redactedValue    = "******"
+)
+
+// DumpHandler returns a middleware that logs request and response bodies,
+// capped at maxBytes each, with the named json fields masked before
+// logging. Enable it per route for debugging, bodies over the cap are
+// truncated and logged raw.
+func DumpHandler(maxBytes int, redactFields []string) func(http.Handler) http.Handler {
+	if maxBytes <= 0 {
+		maxBytes = defaultDumpBytes
+	}
+	fields := make(map[string]lang.PlaceholderType, len(redactFields))
+	for _, field := range redactFields {
+		fields[field] = lang.Placeholder
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqBuf := newCappedBuffer(maxBytes)
+			if r.Body != nil {
+				r.Body = ioutil.NopCloser(io.TeeReader(r.Body, reqBuf))
+			}
+
+			respBuf := newCappedBuffer(maxBytes)
+			dw := &dumpResponseWriter{
+				w:    w,
+				buf:  respBuf,
+				code: http.StatusOK,
+			}
+			next.ServeHTTP(dw, r)
+
+			logx.WithContext(r.Context()).Infof("[dump] %s %s - %d\n>>> %s\n<<< %s",
+				r.Method, r.RequestURI, dw.code, redactJson(reqBuf, fields), redactJson(respBuf, fields))
+		})
+	}
+}
+
+type cappedBuffer struct {
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func newCappedBuffer(max int) *cappedBuffer {
+	return &cappedBuffer{
+		max: max,
+	}
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if left := b.max - len(b.buf); left > 0 {
+		if left > len(p) {
+			left = len(p)
+		}
+		b.buf = append(b.buf, p[:left]...)
+	}
+	if len(b.buf) == b.max {
+		b.truncated = true
+	}
+
+	return len(p), nil
+}
+
+type dumpResponseWriter struct {
+	w    http.ResponseWriter
+	buf  *cappedBuffer
+	code int
+}
+
+func (w *dumpResponseWriter) Flush() {
+	if flusher, ok := w.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *dumpResponseWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+func (w *dumpResponseWriter) Write(bs []byte) (int, error) {
+	w.buf.Write(bs)
+	return w.w.Write(bs)
+}
+
+func (w *dumpResponseWriter) WriteHeader(code int) {
+	w.w.WriteHeader(code)
+	w.code = code
+}
+
+func redactJson(buf *cappedBuffer, fields map[string]lang.PlaceholderType) string {
+	if len(buf.buf) == 0 {
+		return ""
+	}
+	// a truncated body won't parse, log what we captured
+	if buf.truncated || len(fields) == 0 {
+		return string(buf.buf)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(buf.buf, &v); err != nil {
+		return string(buf.buf)
+	}
+
+	masked, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return string(buf.buf)
+	}
+
+	return string(masked)
+}
+
+func redactValue(v interface{}, fields map[string]lang.PlaceholderType) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, val := range vv {
+			if _, ok := fields[k]; ok {
+				vv[k] = redactedValue
+			} else {
+				vv[k] = redactValue(val, fields)
+			}
+		}
+		return vv
+	case []interface{}:
+		for i, val := range vv {
+			vv[i] = redactValue(val, fields)
+		}
+		return vv
+	default:
+		return v
+	}
+}
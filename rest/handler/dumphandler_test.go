@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+func TestDumpHandler(t *testing.T) {
+	handler := DumpHandler(1024, []string{"password"})(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body := make([]byte, r.ContentLength)
+			_, err := r.Body.Read(body)
+			if err != nil && err.Error() != "EOF" {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost",
+		strings.NewReader(`{"user":"kevin","password":"secret"}`))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, `{"ok":true}`, resp.Body.String())
+}
+
+func TestCappedBuffer(t *testing.T) {
+	buf := newCappedBuffer(4)
+	n, err := buf.Write([]byte("abcdefgh"))
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, "abcd", string(buf.buf))
+	assert.True(t, buf.truncated)
+}
+
+func TestRedactJson(t *testing.T) {
+	fields := map[string]lang.PlaceholderType{
+		"password": lang.Placeholder,
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "flat",
+			input:  `{"password":"secret"}`,
+			expect: `{"password":"******"}`,
+		},
+		{
+			name:   "nested",
+			input:  `{"user":{"password":"secret"}}`,
+			expect: `{"user":{"password":"******"}}`,
+		},
+		{
+			name:   "array",
+			input:  `[{"password":"secret"}]`,
+			expect: `[{"password":"******"}]`,
+		},
+		{
+			name:   "untouched",
+			input:  `{"user":"kevin"}`,
+			expect: `{"user":"kevin"}`,
+		},
+		{
+			name:   "not json",
+			input:  "plain text",
+			expect: "plain text",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := newCappedBuffer(1024)
+			_, err := buf.Write([]byte(test.input))
+			assert.Nil(t, err)
+			assert.Equal(t, test.expect, redactJson(buf, fields))
+		})
+	}
+}
+
+func TestRedactJsonTruncated(t *testing.T) {
+	fields := map[string]lang.PlaceholderType{
+		"password": lang.Placeholder,
+	}
+	buf := newCappedBuffer(8)
+	_, err := buf.Write([]byte(`{"password":"secret"}`))
+	assert.Nil(t, err)
+	// truncated body can't parse, logged as captured
+	assert.Equal(t, `{"passwo`, redactJson(buf, fields))
+}
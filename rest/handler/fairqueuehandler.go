@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tal-tech/go-zero/core/load"
+	"github.com/tal-tech/go-zero/core/stat"
+	"github.com/tal-tech/go-zero/rest/httpx"
+	"github.com/tal-tech/go-zero/rest/internal"
+)
+
+// FairQueueHandler returns a middleware that admits requests through fq,
+// sharing the concurrency budget fairly across tenants. The tenant is
+// taken from the request context via load.TenantFromContext, requests
+// without a tenant share one default bucket.
+func FairQueueHandler(fq *load.FairQueue, metrics *stat.Metrics) func(http.Handler) http.Handler {
+	if fq == nil {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant := load.TenantFromContext(r.Context())
+			if err := fq.Acquire(tenant); err != nil {
+				metrics.AddDrop()
+				internal.Errorf(r, "[http] dropped, tenant: %s, %s - %s - %s",
+					tenant, r.RequestURI, httpx.GetRemoteAddr(r), r.UserAgent())
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			defer fq.Release(tenant)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/load"
+	"github.com/tal-tech/go-zero/core/stat"
+)
+
+func TestFairQueueHandlerAccept(t *testing.T) {
+	metrics := stat.NewMetrics("unit-test")
+	fq := load.NewFairQueue(2)
+	handler := FairQueueHandler(fq, metrics)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req = req.WithContext(load.ContextWithTenant(req.Context(), "acme"))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestFairQueueHandlerShed(t *testing.T) {
+	metrics := stat.NewMetrics("unit-test")
+	fq := load.NewFairQueue(1, load.WithFairQueueLen(0), load.WithFairTimeout(time.Millisecond*10))
+	assert.Nil(t, fq.Acquire("acme"))
+	defer fq.Release("acme")
+
+	handler := FairQueueHandler(fq, metrics)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req = req.WithContext(load.ContextWithTenant(req.Context(), "acme"))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
+
+func TestFairQueueHandlerNil(t *testing.T) {
+	handler := FairQueueHandler(nil, nil)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	pendingPlaceholder   = "pending"
+)
+
+type (
+	// A CachedResponse is a response replayed for a repeated idempotency key.
+	CachedResponse struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header"`
+		Body   []byte      `json:"body"`
+	}
+
+	// An IdempotencyStore tracks responses by idempotency key.
+	IdempotencyStore interface {
+		// Claim claims key for this request. It returns claimed true when
+		// this request should run the handler, otherwise the cached response
+		// to replay, nil while the first request is still in flight.
+		Claim(key string) (claimed bool, cached *CachedResponse, err error)
+		// Save stores the response for key.
+		Save(key string, resp *CachedResponse) error
+		// Release gives up a claim without a response, so retries may run.
+		Release(key string) error
+	}
+
+	redisIdempotencyStore struct {
+		store *redis.Redis
+		ttl   time.Duration
+	}
+)
+
+// NewRedisIdempotencyStore returns an IdempotencyStore backed by store,
+// responses expire after ttl.
+func NewRedisIdempotencyStore(store *redis.Redis, ttl time.Duration) IdempotencyStore {
+	return &redisIdempotencyStore{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// IdempotencyHandler returns a middleware that caches responses by the
+// Idempotency-Key request header and replays them on retries. A retry
+// arriving while the first request is still in flight gets 409 Conflict.
+// Requests without the header pass through.
+func IdempotencyHandler(store IdempotencyStore) func(http.Handler) http.Handler {
+	if store == nil {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if len(key) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claimed, cached, err := store.Claim(key)
+			if err != nil {
+				// don't block writes on store trouble
+				logx.WithContext(r.Context()).Error(err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !claimed {
+				if cached == nil {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				replayResponse(w, cached)
+				return
+			}
+
+			var buf bytes.Buffer
+			iw := &idempotentResponseWriter{
+				w:    w,
+				buf:  &buf,
+				code: http.StatusOK,
+			}
+			next.ServeHTTP(iw, r)
+
+			if err := store.Save(key, &CachedResponse{
+				Status: iw.code,
+				Header: w.Header(),
+				Body:   buf.Bytes(),
+			}); err != nil {
+				logx.WithContext(r.Context()).Error(err)
+				if err := store.Release(key); err != nil {
+					logx.WithContext(r.Context()).Error(err)
+				}
+			}
+		})
+	}
+}
+
+func replayResponse(w http.ResponseWriter, cached *CachedResponse) {
+	for key, values := range cached.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(cached.Status)
+	if _, err := w.Write(cached.Body); err != nil {
+		logx.Error(err)
+	}
+}
+
+type idempotentResponseWriter struct {
+	w    http.ResponseWriter
+	buf  *bytes.Buffer
+	code int
+}
+
+func (w *idempotentResponseWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+func (w *idempotentResponseWriter) Write(bs []byte) (int, error) {
+	w.buf.Write(bs)
+	return w.w.Write(bs)
+}
+
+func (w *idempotentResponseWriter) WriteHeader(code int) {
+	w.w.WriteHeader(code)
+	w.code = code
+}
+
+func (s *redisIdempotencyStore) Claim(key string) (bool, *CachedResponse, error) {
+	claimed, err := s.store.SetnxEx(key, pendingPlaceholder, s.seconds())
+	if err != nil {
+		return false, nil, err
+	}
+	if claimed {
+		return true, nil, nil
+	}
+
+	val, err := s.store.Get(key)
+	if err != nil {
+		return false, nil, err
+	}
+	if val == pendingPlaceholder {
+		return false, nil, nil
+	}
+
+	var cached CachedResponse
+	if err := json.Unmarshal([]byte(val), &cached); err != nil {
+		return false, nil, err
+	}
+
+	return false, &cached, nil
+}
+
+func (s *redisIdempotencyStore) Save(key string, resp *CachedResponse) error {
+	bs, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Setex(key, string(bs), s.seconds())
+}
+
+func (s *redisIdempotencyStore) Release(key string) error {
+	_, err := s.store.Del(key)
+	return err
+}
+
+func (s *redisIdempotencyStore) seconds() int {
+	seconds := int(s.ttl / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return seconds
+}
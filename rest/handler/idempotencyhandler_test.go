@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+type memIdempotencyStore struct {
+	lock    sync.Mutex
+	pending map[string]bool
+	saved   map[string]*CachedResponse
+}
+
+func newMemIdempotencyStore() *memIdempotencyStore {
+	return &memIdempotencyStore{
+		pending: make(map[string]bool),
+		saved:   make(map[string]*CachedResponse),
+	}
+}
+
+func (s *memIdempotencyStore) Claim(key string) (bool, *CachedResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if cached, ok := s.saved[key]; ok {
+		return false, cached, nil
+	}
+	if s.pending[key] {
+		return false, nil, nil
+	}
+
+	s.pending[key] = true
+	return true, nil, nil
+}
+
+func (s *memIdempotencyStore) Save(key string, resp *CachedResponse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pending, key)
+	s.saved[key] = resp
+	return nil
+}
+
+func (s *memIdempotencyStore) Release(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pending, key)
+	return nil
+}
+
+func TestIdempotencyHandlerReplay(t *testing.T) {
+	store := newMemIdempotencyStore()
+	var calls int
+	handler := IdempotencyHandler(store)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"order":1}`))
+		}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+		req.Header.Set(idempotencyKeyHeader, "key-1")
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, `{"order":1}`, resp.Body.String())
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyHandlerInflightConflict(t *testing.T) {
+	store := newMemIdempotencyStore()
+	store.pending["key-1"] = true
+
+	handler := IdempotencyHandler(store)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+	req.Header.Set(idempotencyKeyHeader, "key-1")
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestIdempotencyHandlerNoHeader(t *testing.T) {
+	store := newMemIdempotencyStore()
+	var calls int
+	handler := IdempotencyHandler(store)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyHandlerNilStore(t *testing.T) {
+	handler := IdempotencyHandler(nil)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestRedisIdempotencyStore(t *testing.T) {
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	store := NewRedisIdempotencyStore(r, time.Minute)
+
+	claimed, cached, err := store.Claim("key-1")
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+	assert.Nil(t, cached)
+
+	// in flight, not replayable yet
+	claimed, cached, err = store.Claim("key-1")
+	assert.Nil(t, err)
+	assert.False(t, claimed)
+	assert.Nil(t, cached)
+
+	assert.Nil(t, store.Save("key-1", &CachedResponse{
+		Status: http.StatusCreated,
+		Body:   []byte("done"),
+	}))
+
+	claimed, cached, err = store.Claim("key-1")
+	assert.Nil(t, err)
+	assert.False(t, claimed)
+	assert.NotNil(t, cached)
+	assert.Equal(t, http.StatusCreated, cached.Status)
+	assert.Equal(t, "done", string(cached.Body))
+
+	assert.Nil(t, store.Release("key-1"))
+	claimed, _, err = store.Claim("key-1")
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+}
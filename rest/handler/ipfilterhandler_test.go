@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runIPFilter(t *testing.T, handler http.Handler, remoteAddr, xff string) int {
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.RemoteAddr = remoteAddr
+	if len(xff) > 0 {
+		req.Header.Set("X-Forwarded-For", xff)
+	}
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	return resp.Code
+}
+
+func TestIPFilterHandlerAllowList(t *testing.T) {
+	handler := IPFilterHandler([]string{"10.0.0.0/8"}, nil)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	assert.Equal(t, http.StatusOK, runIPFilter(t, handler, "10.1.2.3:1234", ""))
+	assert.Equal(t, http.StatusForbidden, runIPFilter(t, handler, "8.8.8.8:1234", ""))
+}
+
+func TestIPFilterHandlerDenyList(t *testing.T) {
+	handler := IPFilterHandler(nil, []string{"192.168.1.1"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	assert.Equal(t, http.StatusForbidden, runIPFilter(t, handler, "192.168.1.1:1234", ""))
+	assert.Equal(t, http.StatusOK, runIPFilter(t, handler, "192.168.1.2:1234", ""))
+}
+
+func TestIPFilterHandlerSpoofedForwardedFor(t *testing.T) {
+	handler := IPFilterHandler([]string{"10.0.0.0/8"}, nil)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	// the peer isn't a trusted proxy, its forwarded header is ignored
+	assert.Equal(t, http.StatusForbidden,
+		runIPFilter(t, handler, "8.8.8.8:1234", "10.1.2.3"))
+}
+
+func TestIPFilterHandlerTrustedProxy(t *testing.T) {
+	handler := IPFilterHandler([]string{"10.0.0.0/8"}, nil,
+		WithTrustedProxies([]string{"172.16.0.1"}))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	assert.Equal(t, http.StatusOK,
+		runIPFilter(t, handler, "172.16.0.1:1234", "10.1.2.3"))
+	assert.Equal(t, http.StatusForbidden,
+		runIPFilter(t, handler, "172.16.0.1:1234", "8.8.8.8"))
+}
+
+func TestIPFilterHandlerBadEntry(t *testing.T) {
+	assert.Panics(t, func() {
+		IPFilterHandler([]string{"not-a-cidr"}, nil)
+	})
+}
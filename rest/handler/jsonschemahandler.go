@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/tal-tech/go-zero/rest/httpx"
+	"github.com/tal-tech/go-zero/rest/internal/jsonschema"
+)
+
+// JsonSchemaHandler returns a middleware that validates the raw request
+// body against the given JSON Schema document before the handler runs,
+// rejecting invalid bodies with 400 and the list of violations.
+// The schema is compiled once at registration, an invalid schema panics.
+func JsonSchemaHandler(schema []byte) func(http.Handler) http.Handler {
+	compiled, err := jsonschema.Compile(schema)
+	if err != nil {
+		panic(fmt.Sprintf("JsonSchemaHandler: %v", err))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				httpx.Error(w, err)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			var value interface{}
+			if err := json.Unmarshal(body, &value); err != nil {
+				httpx.Error(w, errors.New("invalid json body"))
+				return
+			}
+
+			if violations := compiled.Validate(value); len(violations) > 0 {
+				httpx.Error(w, errors.New(strings.Join(violations, "; ")))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["name", "age"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 0, "maximum": 150},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 3}
+	}
+}`
+
+func TestJsonSchemaHandlerValid(t *testing.T) {
+	var bodySeen string
+	handler := JsonSchemaHandler([]byte(testSchema))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			bodySeen = string(body)
+		}))
+
+	body := `{"name":"kevin","age":20,"tags":["a","b"]}`
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/users",
+		strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	// the body is still readable by the handler
+	assert.Equal(t, body, bodySeen)
+}
+
+func TestJsonSchemaHandlerViolations(t *testing.T) {
+	handler := JsonSchemaHandler([]byte(testSchema))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run")
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/users",
+		strings.NewReader(`{"name":"","extra":true}`)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	violations := resp.Body.String()
+	assert.Contains(t, violations, "age: required property missing")
+	assert.Contains(t, violations, "name: expected at least 1 characters")
+	assert.Contains(t, violations, "extra: additional property not allowed")
+}
+
+func TestJsonSchemaHandlerInvalidJson(t *testing.T) {
+	handler := JsonSchemaHandler([]byte(testSchema))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run")
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/users",
+		strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestJsonSchemaHandlerBadSchema(t *testing.T) {
+	assert.Panics(t, func() {
+		JsonSchemaHandler([]byte(`{"properties": {"name": {"pattern": "["}}}`))
+	})
+}
+
+func TestJsonSchemaHandlerTypeMismatch(t *testing.T) {
+	handler := JsonSchemaHandler([]byte(testSchema))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run")
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/users",
+		strings.NewReader(`{"name":"kevin","age":20.5}`)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "age: expected type integer")
+}
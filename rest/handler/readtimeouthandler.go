@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrBodyReadTimeout is returned from request body reads that exceed
+// the read timeout.
+var ErrBodyReadTimeout = errors.New("reading request body timed out")
+
+// ReadTimeoutHandler returns a middleware that bounds the time spent
+// reading the request body, independent of the processing timeout.
+// Clients sending the body too slowly get 408, which protects against
+// slowloris-style attacks the overall timeout doesn't.
+func ReadTimeoutHandler(duration time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if duration <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := &timedBody{
+				body:     r.Body,
+				deadline: time.Now().Add(duration),
+			}
+			r.Body = body
+			cw := &checkedResponseWriter{
+				ResponseWriter: w,
+			}
+			next.ServeHTTP(cw, r)
+
+			if body.timedOut && !cw.wrote {
+				http.Error(w, reason, http.StatusRequestTimeout)
+			}
+		})
+	}
+}
+
+type checkedResponseWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *checkedResponseWriter) Write(data []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *checkedResponseWriter) WriteHeader(code int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// timedBody fails reads that don't complete before the deadline,
+// the deadline covers the whole body, not each read.
+type timedBody struct {
+	body     io.ReadCloser
+	deadline time.Time
+	timedOut bool
+}
+
+func (b *timedBody) Read(p []byte) (int, error) {
+	remain := time.Until(b.deadline)
+	if remain <= 0 {
+		b.timedOut = true
+		return 0, ErrBodyReadTimeout
+	}
+
+	done := make(chan readResult, 1)
+	go func() {
+		// read into an owned buffer, the caller may reuse p after a timeout
+		buf := make([]byte, len(p))
+		n, err := b.body.Read(buf)
+		done <- readResult{
+			data: buf[:n],
+			err:  err,
+		}
+	}()
+
+	timer := time.NewTimer(remain)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return copy(p, result.data), result.err
+	case <-timer.C:
+		b.timedOut = true
+		return 0, ErrBodyReadTimeout
+	}
+}
+
+func (b *timedBody) Close() error {
+	return b.body.Close()
+}
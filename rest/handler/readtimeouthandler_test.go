@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowReader struct {
+	delay time.Duration
+	data  io.Reader
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return r.data.Read(p)
+}
+
+func (r *slowReader) Close() error {
+	return nil
+}
+
+func TestReadTimeoutHandlerFastBody(t *testing.T) {
+	handler := ReadTimeoutHandler(time.Second)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			w.Write(body)
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader("payload")))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "payload", resp.Body.String())
+}
+
+func TestReadTimeoutHandlerSlowBody(t *testing.T) {
+	handler := ReadTimeoutHandler(time.Millisecond * 50)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := ioutil.ReadAll(r.Body)
+			assert.Equal(t, ErrBodyReadTimeout, err)
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", &slowReader{
+		delay: time.Second,
+		data:  strings.NewReader("payload"),
+	})
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusRequestTimeout, resp.Code)
+}
+
+func TestReadTimeoutHandlerSlowBodyHandlerWrote(t *testing.T) {
+	handler := ReadTimeoutHandler(time.Millisecond * 50)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := ioutil.ReadAll(r.Body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", &slowReader{
+		delay: time.Second,
+		data:  strings.NewReader("payload"),
+	})
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	// the handler already responded, the middleware doesn't override it
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestReadTimeoutHandlerNoBody(t *testing.T) {
+	handler := ReadTimeoutHandler(time.Millisecond)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestReadTimeoutHandlerDisabled(t *testing.T) {
+	handler := ReadTimeoutHandler(0)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
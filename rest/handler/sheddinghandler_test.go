@@ -94,6 +94,10 @@ func (s mockShedder) Allow() (load.Promise, error) {
 	return nil, load.ErrServiceOverloaded
 }
 
+func (s mockShedder) Stat() load.ShedderStats {
+	return load.ShedderStats{}
+}
+
 type mockPromise struct{}
 
 func (p mockPromise) Pass() {
@@ -3,17 +3,29 @@ package handler
 import (
 	"net/http"
 	"time"
+
+	"github.com/tal-tech/go-zero/rest/httpx"
 )
 
 const reason = "Request Timeout"
 
 // TimeoutHandler returns the handler with given timeout.
+// Event stream requests bypass the timeout, long-lived SSE responses
+// need flushing that the timeout writer doesn't support.
 func TimeoutHandler(duration time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		if duration > 0 {
-			return http.TimeoutHandler(next, duration, reason)
+		if duration <= 0 {
+			return next
 		}
 
-		return next
+		timeoutHandler := http.TimeoutHandler(next, duration, reason)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if httpx.IsEventStreamRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timeoutHandler.ServeHTTP(w, r)
+		})
 	}
 }
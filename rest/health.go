@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+const (
+	healthUp       = "up"
+	healthDown     = "down"
+	healthDegraded = "degraded"
+
+	defaultHealthCacheTtl = time.Second * 5
+)
+
+type (
+	// A Checker checks one dependency, returning nil when it's healthy.
+	Checker func() error
+
+	// A ComponentHealth is the check result of one dependency.
+	ComponentHealth struct {
+		Status    string  `json:"status"`
+		LatencyMs float64 `json:"latency_ms"`
+		Critical  bool    `json:"critical"`
+		Error     string  `json:"error,omitempty"`
+	}
+
+	// A HealthReport aggregates the component checks,
+	// Status is down when a critical component is down,
+	// degraded when only non-critical ones are.
+	HealthReport struct {
+		Status     string                     `json:"status"`
+		Components map[string]ComponentHealth `json:"components"`
+	}
+
+	// HealthOption defines the method to customize a HealthManager.
+	HealthOption func(m *HealthManager)
+
+	healthChecker struct {
+		check    Checker
+		critical bool
+	}
+
+	// A HealthManager aggregates named dependency checkers behind
+	// liveness and readiness handlers, check results are cached so
+	// frequent probes don't hammer the dependencies.
+	HealthManager struct {
+		cacheTtl time.Duration
+		lock     sync.Mutex
+		checkers map[string]healthChecker
+		cached   *HealthReport
+		cachedAt time.Time
+	}
+)
+
+// NewHealthManager returns a HealthManager.
+func NewHealthManager(opts ...HealthOption) *HealthManager {
+	m := &HealthManager{
+		cacheTtl: defaultHealthCacheTtl,
+		checkers: make(map[string]healthChecker),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// WithHealthCacheTtl customizes a HealthManager with the time that
+// check results are served from cache, 0 disables caching.
+func WithHealthCacheTtl(ttl time.Duration) HealthOption {
+	return func(m *HealthManager) {
+		m.cacheTtl = ttl
+	}
+}
+
+// Register registers a critical dependency checker,
+// readiness fails while it reports an error.
+func (m *HealthManager) Register(name string, check Checker) {
+	m.register(name, check, true)
+}
+
+// RegisterNonCritical registers an informational dependency checker,
+// its failures degrade the report but don't fail readiness.
+func (m *HealthManager) RegisterNonCritical(name string, check Checker) {
+	m.register(name, check, false)
+}
+
+// Check runs the registered checkers and returns the aggregated report,
+// a cached report is returned within the cache ttl.
+func (m *HealthManager) Check() *HealthReport {
+	m.lock.Lock()
+	if m.cached != nil && time.Since(m.cachedAt) < m.cacheTtl {
+		report := m.cached
+		m.lock.Unlock()
+		return report
+	}
+
+	checkers := make(map[string]healthChecker, len(m.checkers))
+	for name, checker := range m.checkers {
+		checkers[name] = checker
+	}
+	m.lock.Unlock()
+
+	report := runChecks(checkers)
+
+	m.lock.Lock()
+	m.cached = report
+	m.cachedAt = time.Now()
+	m.lock.Unlock()
+
+	return report
+}
+
+// LivenessHandler returns a handler that reports 200 as long as the
+// process is up, regardless of dependency health.
+func (m *HealthManager) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ReadinessHandler returns a handler that runs the registered checkers
+// and reports per-component detail, 503 when a critical one is down.
+func (m *HealthManager) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := m.Check()
+		code := http.StatusOK
+		if report.Status == healthDown {
+			code = http.StatusServiceUnavailable
+		}
+		httpx.WriteJson(w, code, report)
+	}
+}
+
+func (m *HealthManager) register(name string, check Checker, critical bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.checkers[name] = healthChecker{
+		check:    check,
+		critical: critical,
+	}
+	// invalidate the cache so the new component shows up right away
+	m.cached = nil
+}
+
+func runChecks(checkers map[string]healthChecker) *HealthReport {
+	report := &HealthReport{
+		Status:     healthUp,
+		Components: make(map[string]ComponentHealth, len(checkers)),
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker healthChecker) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.check()
+			component := ComponentHealth{
+				Status:    healthUp,
+				LatencyMs: float64(time.Since(start)) / float64(time.Millisecond),
+				Critical:  checker.critical,
+			}
+			if err != nil {
+				component.Status = healthDown
+				component.Error = err.Error()
+			}
+
+			lock.Lock()
+			report.Components[name] = component
+			if err != nil {
+				if checker.critical {
+					report.Status = healthDown
+				} else if report.Status == healthUp {
+					report.Status = healthDegraded
+				}
+			}
+			lock.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return report
+}
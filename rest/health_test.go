@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthManagerAllUp(t *testing.T) {
+	manager := NewHealthManager()
+	manager.Register("redis", func() error {
+		return nil
+	})
+	manager.RegisterNonCritical("metrics", func() error {
+		return nil
+	})
+
+	report := manager.Check()
+	assert.Equal(t, healthUp, report.Status)
+	assert.Equal(t, 2, len(report.Components))
+	assert.True(t, report.Components["redis"].Critical)
+	assert.False(t, report.Components["metrics"].Critical)
+}
+
+func TestHealthManagerCriticalDown(t *testing.T) {
+	manager := NewHealthManager(WithHealthCacheTtl(0))
+	manager.Register("db", func() error {
+		return errors.New("connection refused")
+	})
+
+	w := httptest.NewRecorder()
+	manager.ReadinessHandler()(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report HealthReport
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, healthDown, report.Status)
+	assert.Equal(t, "connection refused", report.Components["db"].Error)
+}
+
+func TestHealthManagerDegraded(t *testing.T) {
+	manager := NewHealthManager(WithHealthCacheTtl(0))
+	manager.Register("db", func() error {
+		return nil
+	})
+	manager.RegisterNonCritical("tracing", func() error {
+		return errors.New("collector down")
+	})
+
+	w := httptest.NewRecorder()
+	manager.ReadinessHandler()(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report HealthReport
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, healthDegraded, report.Status)
+}
+
+func TestHealthManagerCache(t *testing.T) {
+	var calls int
+	manager := NewHealthManager(WithHealthCacheTtl(time.Minute))
+	manager.Register("db", func() error {
+		calls++
+		return nil
+	})
+
+	manager.Check()
+	manager.Check()
+	assert.Equal(t, 1, calls)
+
+	// registering invalidates the cache
+	manager.Register("redis", func() error {
+		return nil
+	})
+	report := manager.Check()
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, len(report.Components))
+}
+
+func TestHealthManagerLiveness(t *testing.T) {
+	manager := NewHealthManager()
+	manager.Register("db", func() error {
+		return errors.New("down")
+	})
+
+	w := httptest.NewRecorder()
+	manager.LivenessHandler()(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
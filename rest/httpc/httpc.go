@@ -0,0 +1,71 @@
+package httpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// errNoRewindableBody means the request body cannot be replayed for a retry.
+var errNoRewindableBody = errors.New("request body is not rewindable")
+
+type (
+	// Option defines the method to customize a Service.
+	Option func(s *Service)
+
+	// A Service is a named http client for outbound calls.
+	Service struct {
+		name string
+		cli  *http.Client
+	}
+)
+
+// NewService returns a Service with the given name.
+func NewService(name string, opts ...Option) *Service {
+	s := &Service{
+		name: name,
+		cli:  &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithHttpClient customizes the Service with the given http client.
+func WithHttpClient(cli *http.Client) Option {
+	return func(s *Service) {
+		s.cli = cli
+	}
+}
+
+// WithTransport customizes the Service with the given transport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(s *Service) {
+		s.cli.Transport = rt
+	}
+}
+
+// Do sends a http request and returns the response,
+// guarded by a circuit breaker keyed on the target host.
+func (s *Service) Do(r *http.Request, opts ...CallOption) (*http.Response, error) {
+	return s.doWithResilience(r, opts...)
+}
+
+// DoRequest builds a request with given method, url and body, and sends it.
+func (s *Service) DoRequest(ctx context.Context, method, url string, body io.Reader,
+	opts ...CallOption) (*http.Response, error) {
+	r, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Do(r, opts...)
+}
+
+// Name returns the name of the Service.
+func (s *Service) Name() string {
+	return s.name
+}
@@ -0,0 +1,195 @@
+package httpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/breaker"
+)
+
+const defaultBackoff = time.Millisecond * 100
+
+type (
+	// CallOption defines the method to customize a single outbound call.
+	CallOption func(o *callOptions)
+
+	callOptions struct {
+		attempts int
+		backoff  time.Duration
+		timeout  time.Duration
+		reporter func(attempts int)
+	}
+
+	cancelBody struct {
+		io.ReadCloser
+		cancel context.CancelFunc
+	}
+)
+
+// WithCallTimeout sets the overall timeout of the call, covering all retries.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallRetry retries failed idempotent calls up to attempts times in total.
+func WithCallRetry(attempts int) CallOption {
+	return func(o *callOptions) {
+		if attempts > 0 {
+			o.attempts = attempts
+		}
+	}
+}
+
+// WithCallBackoff sets the base backoff between retries, doubling each retry.
+func WithCallBackoff(backoff time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.backoff = backoff
+	}
+}
+
+// WithAttemptReporter reports how many attempts the call took.
+func WithAttemptReporter(reporter func(attempts int)) CallOption {
+	return func(o *callOptions) {
+		o.reporter = reporter
+	}
+}
+
+func (s *Service) doWithResilience(r *http.Request, opts ...CallOption) (*http.Response, error) {
+	o := callOptions{
+		attempts: 1,
+		backoff:  defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx := r.Context()
+	var cancel context.CancelFunc
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		r = r.WithContext(ctx)
+	}
+
+	brk := breaker.GetBreaker("httpc/" + r.URL.Host)
+	var attempts int
+	var resp *http.Response
+	var err error
+
+	for i := 0; i < o.attempts; i++ {
+		if i > 0 {
+			if !idempotentMethod(r.Method) {
+				break
+			}
+			if r, err = rewindRequest(r); err != nil {
+				break
+			}
+			if err = sleepWithContext(ctx, o.backoff<<uint(i-1)); err != nil {
+				break
+			}
+		}
+
+		attempts++
+		var promise breaker.Promise
+		if promise, err = brk.Allow(); err != nil {
+			break
+		}
+
+		resp, err = s.cli.Do(r)
+		if err != nil {
+			promise.Reject(err.Error())
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			promise.Reject(resp.Status)
+			err = nil
+			if i < o.attempts-1 && idempotentMethod(r.Method) {
+				io.Copy(ioutil.Discard, resp.Body)
+				resp.Body.Close()
+				continue
+			}
+		} else {
+			promise.Accept()
+			err = nil
+		}
+
+		break
+	}
+
+	if o.reporter != nil {
+		o.reporter(attempts)
+	}
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	if resp == nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, ctx.Err()
+	}
+
+	if cancel != nil {
+		// keep the context alive until the body is consumed
+		resp.Body = cancelBody{
+			ReadCloser: resp.Body,
+			cancel:     cancel,
+		}
+	}
+
+	return resp, nil
+}
+
+func (b cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func rewindRequest(r *http.Request) (*http.Request, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+	if r.GetBody == nil {
+		return r, errNoRewindableBody
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return r, err
+	}
+
+	clone := r.Clone(r.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package httpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallRetrySucceeds(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	var attempts int
+	svc := NewService("test")
+	resp, err := svc.DoRequest(context.Background(), http.MethodGet, svr.URL, nil,
+		WithCallRetry(3), WithCallBackoff(time.Millisecond),
+		WithAttemptReporter(func(n int) {
+			attempts = n
+		}))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 3, hits)
+}
+
+func TestCallRetrySkipsNonIdempotent(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	svc := NewService("test")
+	resp, err := svc.DoRequest(context.Background(), http.MethodPost, svr.URL,
+		strings.NewReader("data"), WithCallRetry(3), WithCallBackoff(time.Millisecond))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 1, hits)
+}
+
+func TestCallTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+	}))
+	defer svr.Close()
+
+	svc := NewService("test")
+	_, err := svc.DoRequest(context.Background(), http.MethodGet, svr.URL, nil,
+		WithCallTimeout(time.Millisecond*50))
+	assert.NotNil(t, err)
+}
+
+func TestCallBreaker(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svr.Close()
+
+	svc := NewService("test")
+	var tripped bool
+	for i := 0; i < 1000; i++ {
+		resp, err := svc.DoRequest(context.Background(), http.MethodGet, svr.URL, nil)
+		if err != nil {
+			tripped = true
+			break
+		}
+		resp.Body.Close()
+	}
+	assert.True(t, tripped)
+}
+
+func TestIdempotentMethod(t *testing.T) {
+	assert.True(t, idempotentMethod(http.MethodGet))
+	assert.True(t, idempotentMethod(http.MethodPut))
+	assert.True(t, idempotentMethod(http.MethodDelete))
+	assert.False(t, idempotentMethod(http.MethodPost))
+	assert.False(t, idempotentMethod(http.MethodPatch))
+}
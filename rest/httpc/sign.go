@@ -0,0 +1,131 @@
+package httpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the request signature.
+	SignatureHeader = "X-Signature"
+	// TimestampHeader carries the signing timestamp.
+	TimestampHeader = "X-Date"
+
+	signTimeFormat = "20060102T150405Z"
+)
+
+type (
+	// Canonicalizer builds the string to sign from a request,
+	// the signing timestamp and the request body.
+	Canonicalizer func(r *http.Request, timestamp string, body []byte) string
+
+	// SignOption defines the method to customize a SignTransport.
+	SignOption func(t *SignTransport)
+
+	// A SignTransport is a http.RoundTripper that HMAC-signs outgoing
+	// requests before delegating to the underlying transport.
+	SignTransport struct {
+		keyId        string
+		secret       []byte
+		canonicalize Canonicalizer
+		transport    http.RoundTripper
+		now          func() time.Time
+	}
+)
+
+// NewSignTransport returns a SignTransport signing with given key id and secret.
+func NewSignTransport(keyId, secret string, opts ...SignOption) *SignTransport {
+	t := &SignTransport{
+		keyId:        keyId,
+		secret:       []byte(secret),
+		canonicalize: DefaultCanonicalizer,
+		transport:    http.DefaultTransport,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// WithSignBase customizes the underlying transport.
+func WithSignBase(transport http.RoundTripper) SignOption {
+	return func(t *SignTransport) {
+		t.transport = transport
+	}
+}
+
+// WithSignCanonicalizer customizes how the string to sign is built.
+func WithSignCanonicalizer(canonicalize Canonicalizer) SignOption {
+	return func(t *SignTransport) {
+		t.canonicalize = canonicalize
+	}
+}
+
+// WithSignClock customizes the clock, mostly for tests.
+func WithSignClock(now func() time.Time) SignOption {
+	return func(t *SignTransport) {
+		t.now = now
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *SignTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := requestBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := t.now().UTC().Format(signTimeFormat)
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(t.canonicalize(r, timestamp, []byte(body))))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	r.Header.Set(TimestampHeader, timestamp)
+	r.Header.Set(SignatureHeader, fmt.Sprintf("HMAC-SHA256 Credential=%s,Signature=%s",
+		t.keyId, signature))
+
+	return t.transport.RoundTrip(r)
+}
+
+// DefaultCanonicalizer builds an AWS-style string to sign:
+// method, path, sorted query, host, timestamp and the hex-encoded
+// sha256 of the body, joined by newlines.
+func DefaultCanonicalizer(r *http.Request, timestamp string, body []byte) string {
+	bodySum := sha256.Sum256(body)
+	return strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		canonicalQuery(r.URL.Query()),
+		r.URL.Host,
+		timestamp,
+		hex.EncodeToString(bodySum[:]),
+	}, "\n")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
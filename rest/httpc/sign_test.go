@@ -0,0 +1,68 @@
+package httpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignTransport(t *testing.T) {
+	fixed := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var gotSignature, gotTimestamp string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+	}))
+	defer svr.Close()
+
+	svc := NewService("test", WithTransport(NewSignTransport("key-1", "secret",
+		WithSignClock(func() time.Time {
+			return fixed
+		}))))
+	resp, err := svc.DoRequest(context.Background(), http.MethodPost,
+		svr.URL+"/orders?b=2&a=1", strings.NewReader("payload"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "20210301T120000Z", gotTimestamp)
+	r, err := http.NewRequest(http.MethodPost, svr.URL+"/orders?b=2&a=1", nil)
+	assert.Nil(t, err)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(DefaultCanonicalizer(r, gotTimestamp, []byte("payload"))))
+	expect := "HMAC-SHA256 Credential=key-1,Signature=" + hex.EncodeToString(mac.Sum(nil))
+	assert.Equal(t, expect, gotSignature)
+}
+
+func TestSignTransportCustomCanonicalizer(t *testing.T) {
+	var gotSignature string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+	}))
+	defer svr.Close()
+
+	svc := NewService("test", WithTransport(NewSignTransport("key-1", "secret",
+		WithSignCanonicalizer(func(r *http.Request, timestamp string, body []byte) string {
+			return r.Method
+		}))))
+	resp, err := svc.DoRequest(context.Background(), http.MethodGet, svr.URL, nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(http.MethodGet))
+	assert.True(t, strings.HasSuffix(gotSignature, hex.EncodeToString(mac.Sum(nil))))
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/p?b=2&a=1&a=0", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "a=0&a=1&b=2", canonicalQuery(r.URL.Query()))
+}
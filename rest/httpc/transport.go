@@ -0,0 +1,168 @@
+package httpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxIdleConns        = 256
+	defaultMaxIdleConnsPerHost = 100
+	defaultIdleConnTimeout     = time.Second * 90
+	defaultDialTimeout         = time.Second * 5
+	defaultKeepAlive           = time.Second * 30
+	defaultTlsHandshakeTimeout = time.Second * 10
+	defaultDnsCacheTtl         = time.Minute
+)
+
+type (
+	// TransportOption defines the method to customize a pooled transport.
+	TransportOption func(o *transportOptions)
+
+	transportOptions struct {
+		maxIdleConnsPerHost int
+		idleConnTimeout     time.Duration
+		http2               bool
+		dnsCacheTtl         time.Duration
+	}
+
+	dnsCacheEntry struct {
+		addrs    []string
+		expireAt time.Time
+	}
+
+	cachedDialer struct {
+		dialer *net.Dialer
+		ttl    time.Duration
+		lookup func(ctx context.Context, host string) ([]string, error)
+		lock   sync.RWMutex
+		cache  map[string]dnsCacheEntry
+	}
+)
+
+// NewPooledTransport returns a http.Transport tuned for service-to-service
+// calls, with connection pooling, HTTP/2 and DNS caching enabled by default.
+func NewPooledTransport(opts ...TransportOption) *http.Transport {
+	options := transportOptions{
+		maxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		idleConnTimeout:     defaultIdleConnTimeout,
+		http2:               true,
+		dnsCacheTtl:         defaultDnsCacheTtl,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   defaultDialTimeout,
+		KeepAlive: defaultKeepAlive,
+	}
+	dialContext := dialer.DialContext
+	if options.dnsCacheTtl > 0 {
+		dialContext = newCachedDialer(dialer, options.dnsCacheTtl).dialContext
+	}
+
+	return &http.Transport{
+		DialContext:         dialContext,
+		ForceAttemptHTTP2:   options.http2,
+		MaxIdleConns:        defaultMaxIdleConns,
+		MaxIdleConnsPerHost: options.maxIdleConnsPerHost,
+		IdleConnTimeout:     options.idleConnTimeout,
+		TLSHandshakeTimeout: defaultTlsHandshakeTimeout,
+	}
+}
+
+// WithMaxIdleConnsPerHost customizes the idle connection pool size per host.
+func WithMaxIdleConnsPerHost(count int) TransportOption {
+	return func(o *transportOptions) {
+		o.maxIdleConnsPerHost = count
+	}
+}
+
+// WithIdleConnTimeout customizes how long idle connections are kept.
+func WithIdleConnTimeout(timeout time.Duration) TransportOption {
+	return func(o *transportOptions) {
+		o.idleConnTimeout = timeout
+	}
+}
+
+// WithHttp2 enables or disables HTTP/2 upgrade attempts.
+func WithHttp2(enabled bool) TransportOption {
+	return func(o *transportOptions) {
+		o.http2 = enabled
+	}
+}
+
+// WithDnsCacheTtl customizes how long DNS lookups are cached, 0 disables caching.
+func WithDnsCacheTtl(ttl time.Duration) TransportOption {
+	return func(o *transportOptions) {
+		o.dnsCacheTtl = ttl
+	}
+}
+
+func newCachedDialer(dialer *net.Dialer, ttl time.Duration) *cachedDialer {
+	return &cachedDialer{
+		dialer: dialer,
+		ttl:    ttl,
+		lookup: func(ctx context.Context, host string) ([]string, error) {
+			return net.DefaultResolver.LookupHost(ctx, host)
+		},
+		cache: make(map[string]dnsCacheEntry),
+	}
+}
+
+func (d *cachedDialer) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+	if net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, resolved := range addrs {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(resolved, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (d *cachedDialer) resolve(ctx context.Context, host string) ([]string, error) {
+	d.lock.RLock()
+	entry, ok := d.cache[host]
+	d.lock.RUnlock()
+	if ok && time.Now().Before(entry.expireAt) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := d.lookup(ctx, host)
+	if err != nil {
+		// serve stale entries on resolver failures
+		if ok {
+			return entry.addrs, nil
+		}
+		return nil, err
+	}
+
+	d.lock.Lock()
+	d.cache[host] = dnsCacheEntry{
+		addrs:    addrs,
+		expireAt: time.Now().Add(d.ttl),
+	}
+	d.lock.Unlock()
+
+	return addrs, nil
+}
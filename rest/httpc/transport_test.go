@@ -0,0 +1,86 @@
+package httpc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPooledTransportDefaults(t *testing.T) {
+	transport := NewPooledTransport()
+	assert.Equal(t, defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, defaultIdleConnTimeout, transport.IdleConnTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.DialContext)
+}
+
+func TestNewPooledTransportOptions(t *testing.T) {
+	transport := NewPooledTransport(
+		WithMaxIdleConnsPerHost(10),
+		WithIdleConnTimeout(time.Second),
+		WithHttp2(false),
+		WithDnsCacheTtl(0))
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Second, transport.IdleConnTimeout)
+	assert.False(t, transport.ForceAttemptHTTP2)
+}
+
+func TestPooledTransportServesRequests(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+	defer svr.Close()
+
+	svc := NewService("test", WithTransport(NewPooledTransport()))
+	resp, err := svc.DoRequest(context.Background(), http.MethodGet, svr.URL, nil)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "pong", string(content))
+}
+
+func TestCachedDialerResolve(t *testing.T) {
+	var lookups int
+	dialer := newCachedDialer(&net.Dialer{}, time.Minute)
+	dialer.lookup = func(ctx context.Context, host string) ([]string, error) {
+		lookups++
+		return []string{"127.0.0.1"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		addrs, err := dialer.resolve(context.Background(), "svc.local")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"127.0.0.1"}, addrs)
+	}
+	assert.Equal(t, 1, lookups)
+}
+
+func TestCachedDialerStaleOnFailure(t *testing.T) {
+	var fail bool
+	dialer := newCachedDialer(&net.Dialer{}, -time.Second)
+	dialer.lookup = func(ctx context.Context, host string) ([]string, error) {
+		if fail {
+			return nil, errors.New("resolver down")
+		}
+		return []string{"127.0.0.1"}, nil
+	}
+
+	_, err := dialer.resolve(context.Background(), "svc.local")
+	assert.Nil(t, err)
+
+	fail = true
+	addrs, err := dialer.resolve(context.Background(), "svc.local")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, addrs)
+
+	_, err = dialer.resolve(context.Background(), "other.local")
+	assert.NotNil(t, err)
+}
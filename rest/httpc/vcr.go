@@ -0,0 +1,214 @@
+package httpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// VcrMode controls whether a VcrTransport records or replays.
+type VcrMode int
+
+const (
+	// VcrReplay serves responses from the cassette, the default.
+	VcrReplay VcrMode = iota
+	// VcrRecord proxies requests and saves them to the cassette.
+	VcrRecord
+)
+
+// ErrInteractionNotFound is returned in replay mode when no recorded
+// interaction matches the request.
+var ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+
+type (
+	// VcrOption defines the method to customize a VcrTransport.
+	VcrOption func(t *VcrTransport)
+
+	// A VcrTransport is a http.RoundTripper that records interactions to a
+	// cassette file or replays them from it, matching on method, url and body.
+	VcrTransport struct {
+		cassette  string
+		mode      VcrMode
+		transport http.RoundTripper
+		lock      sync.Mutex
+		loaded    bool
+		tracks    []*interaction
+	}
+
+	interaction struct {
+		Request  recordedRequest  `json:"request"`
+		Response recordedResponse `json:"response"`
+		played   bool
+	}
+
+	recordedRequest struct {
+		Method string `json:"method"`
+		Url    string `json:"url"`
+		Body   string `json:"body"`
+	}
+
+	recordedResponse struct {
+		Status int                 `json:"status"`
+		Header map[string][]string `json:"header,omitempty"`
+		Body   string              `json:"body"`
+	}
+)
+
+// NewVcrTransport returns a VcrTransport backed by the given cassette file.
+func NewVcrTransport(cassette string, opts ...VcrOption) *VcrTransport {
+	t := &VcrTransport{
+		cassette:  cassette,
+		transport: http.DefaultTransport,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// WithVcrMode customizes the VcrTransport with the given mode.
+func WithVcrMode(mode VcrMode) VcrOption {
+	return func(t *VcrTransport) {
+		t.mode = mode
+	}
+}
+
+// WithVcrTransport customizes the transport used to proxy requests
+// in record mode.
+func WithVcrTransport(transport http.RoundTripper) VcrOption {
+	return func(t *VcrTransport) {
+		t.transport = transport
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *VcrTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := requestBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.mode == VcrRecord {
+		return t.record(r, body)
+	}
+
+	return t.replay(r, body)
+}
+
+func (t *VcrTransport) record(r *http.Request, reqBody string) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	t.tracks = append(t.tracks, &interaction{
+		Request: recordedRequest{
+			Method: r.Method,
+			Url:    r.URL.String(),
+			Body:   reqBody,
+		},
+		Response: recordedResponse{
+			Status: resp.StatusCode,
+			Header: resp.Header,
+			Body:   string(respBody),
+		},
+	})
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *VcrTransport) replay(r *http.Request, reqBody string) (*http.Response, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	for _, track := range t.tracks {
+		if track.played || track.Request.Method != r.Method ||
+			track.Request.Url != r.URL.String() || track.Request.Body != reqBody {
+			continue
+		}
+
+		track.played = true
+		header := make(http.Header, len(track.Response.Header))
+		for k, vals := range track.Response.Header {
+			header[k] = vals
+		}
+
+		return &http.Response{
+			StatusCode: track.Response.Status,
+			Status:     http.StatusText(track.Response.Status),
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(track.Response.Body))),
+			Request:    r,
+		}, nil
+	}
+
+	return nil, ErrInteractionNotFound
+}
+
+func (t *VcrTransport) load() error {
+	if t.loaded {
+		return nil
+	}
+
+	t.loaded = true
+	content, err := ioutil.ReadFile(t.cassette)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(content, &t.tracks)
+}
+
+func (t *VcrTransport) save() error {
+	content, err := json.MarshalIndent(t.tracks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(t.cassette, content, 0644)
+}
+
+func requestBody(r *http.Request) (string, error) {
+	if r.Body == nil {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return "", err
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
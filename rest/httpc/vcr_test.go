@@ -0,0 +1,86 @@
+package httpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVcrRecordThenReplay(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello " + r.Method))
+	}))
+	defer svr.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := NewService("test", WithTransport(NewVcrTransport(cassette,
+		WithVcrMode(VcrRecord))))
+	resp, err := recorder.DoRequest(context.Background(), http.MethodPost,
+		svr.URL+"/greet", strings.NewReader("body"))
+	assert.Nil(t, err)
+	content, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "hello POST", string(content))
+	assert.Equal(t, 1, hits)
+
+	// replay never touches the real server
+	replayer := NewService("test", WithTransport(NewVcrTransport(cassette)))
+	resp, err = replayer.DoRequest(context.Background(), http.MethodPost,
+		svr.URL+"/greet", strings.NewReader("body"))
+	assert.Nil(t, err)
+	content, err = ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "yes", resp.Header.Get("X-Test"))
+	assert.Equal(t, "hello POST", string(content))
+	assert.Equal(t, 1, hits)
+}
+
+func TestVcrReplayNoMatch(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	replayer := NewService("test", WithTransport(NewVcrTransport(cassette)))
+	_, err := replayer.DoRequest(context.Background(), http.MethodGet,
+		"http://localhost/none", nil)
+	assert.True(t, strings.Contains(err.Error(), ErrInteractionNotFound.Error()))
+}
+
+func TestVcrReplayMatchesBody(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		w.Write([]byte("echo:" + string(content)))
+	}))
+	defer svr.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := NewService("test", WithTransport(NewVcrTransport(cassette,
+		WithVcrMode(VcrRecord))))
+	for _, body := range []string{"one", "two"} {
+		resp, err := recorder.DoRequest(context.Background(), http.MethodPost,
+			svr.URL, strings.NewReader(body))
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+
+	replayer := NewService("test", WithTransport(NewVcrTransport(cassette)))
+	resp, err := replayer.DoRequest(context.Background(), http.MethodPost,
+		svr.URL, strings.NewReader("two"))
+	assert.Nil(t, err)
+	content, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "echo:two", string(content))
+
+	_, err = replayer.DoRequest(context.Background(), http.MethodPost,
+		svr.URL, strings.NewReader("three"))
+	assert.NotNil(t, err)
+}
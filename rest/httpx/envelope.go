@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/tal-tech/go-zero/core/syncx"
+)
+
+const okMessage = "ok"
+
+var envelopeEnabled = syncx.NewAtomicBool()
+
+// An Envelope is the standard response wrapper, written around payloads
+// and errors when enveloping is enabled.
+type Envelope struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// SetResponseEnvelope enables or disables response enveloping, usually
+// through rest.WithResponseEnvelope. Only OkData and Error wrap their
+// payloads, raw writes are never touched.
+func SetResponseEnvelope(enabled bool) {
+	envelopeEnabled.Set(enabled)
+}
+
+// OkData writes v into w with 200 OK, wrapped in the standard envelope
+// when enveloping is enabled.
+func OkData(w http.ResponseWriter, v interface{}) {
+	if envelopeEnabled.True() {
+		WriteJson(w, http.StatusOK, Envelope{
+			Message: okMessage,
+			Data:    v,
+		})
+		return
+	}
+
+	WriteJson(w, http.StatusOK, v)
+}
@@ -0,0 +1,73 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOkDataPlain(t *testing.T) {
+	SetResponseEnvelope(false)
+	w := httptest.NewRecorder()
+	OkData(w, map[string]string{"name": "kevin"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"name":"kevin"}`, w.Body.String())
+}
+
+func TestOkDataEnveloped(t *testing.T) {
+	SetResponseEnvelope(true)
+	defer SetResponseEnvelope(false)
+
+	w := httptest.NewRecorder()
+	OkData(w, map[string]string{"name": "kevin"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"code":0,"message":"ok","data":{"name":"kevin"}}`, w.Body.String())
+}
+
+func TestErrorEnveloped(t *testing.T) {
+	SetResponseEnvelope(true)
+	defer SetResponseEnvelope(false)
+
+	w := httptest.NewRecorder()
+	Error(w, errors.New("boom"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"code":400,"message":"boom"}`, w.Body.String())
+}
+
+func TestErrorEnvelopedCustomHandler(t *testing.T) {
+	SetResponseEnvelope(true)
+	defer func() {
+		SetResponseEnvelope(false)
+		SetErrorHandler(nil)
+	}()
+
+	SetErrorHandler(func(err error) (int, interface{}) {
+		return http.StatusConflict, errors.New("conflict")
+	})
+	w := httptest.NewRecorder()
+	Error(w, errors.New("boom"))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, `{"code":409,"message":"conflict"}`, w.Body.String())
+}
+
+func TestErrorEnvelopedNoDoubleWrap(t *testing.T) {
+	SetResponseEnvelope(true)
+	defer func() {
+		SetResponseEnvelope(false)
+		SetErrorHandler(nil)
+	}()
+
+	SetErrorHandler(func(err error) (int, interface{}) {
+		return http.StatusTeapot, Envelope{
+			Code:    1001,
+			Message: err.Error(),
+		}
+	})
+	w := httptest.NewRecorder()
+	Error(w, errors.New("boom"))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	assert.Equal(t, `{"code":1001,"message":"boom"}`, w.Body.String())
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/tal-tech/go-zero/core/mapping"
 	"github.com/tal-tech/go-zero/rest/internal/context"
@@ -24,8 +25,14 @@ var (
 	formUnmarshaler   = mapping.NewUnmarshaler(formKey, mapping.WithStringValues())
 	pathUnmarshaler   = mapping.NewUnmarshaler(pathKey, mapping.WithStringValues())
 	headerUnmarshaler = mapping.NewUnmarshaler(headerKey, mapping.WithStringValues())
+
+	decoderLock  sync.RWMutex
+	bodyDecoders = make(map[string]BodyDecoder)
 )
 
+// A BodyDecoder decodes a request body read from r into v.
+type BodyDecoder func(r io.Reader, v interface{}) error
+
 // Parse parses the request.
 func Parse(r *http.Request, v interface{}) error {
 	if err := ParsePath(r, v); err != nil {
@@ -40,7 +47,33 @@ func Parse(r *http.Request, v interface{}) error {
 		return err
 	}
 
-	return ParseJsonBody(r, v)
+	return ParseBody(r, v)
+}
+
+// RegisterBodyDecoder registers fn to decode request bodies with the
+// given content type, like "application/xml". Path, query and header
+// binding stay the same, json remains the default body format.
+func RegisterBodyDecoder(contentType string, fn BodyDecoder) {
+	decoderLock.Lock()
+	bodyDecoders[normalizeContentType(contentType)] = fn
+	decoderLock.Unlock()
+}
+
+// ParseBody parses the request body into v according to the Content-Type
+// header, falling back to json when no decoder is registered for it.
+func ParseBody(r *http.Request, v interface{}) error {
+	decoderLock.RLock()
+	decoder, ok := bodyDecoders[normalizeContentType(r.Header.Get(ContentType))]
+	decoderLock.RUnlock()
+
+	if !ok {
+		return ParseJsonBody(r, v)
+	}
+	if r.ContentLength <= 0 {
+		return nil
+	}
+
+	return decoder(io.LimitReader(r.Body, maxBodyLen), v)
 }
 
 // ParseHeaders parses the headers request.
@@ -127,6 +160,10 @@ func ParsePath(r *http.Request, v interface{}) error {
 	return pathUnmarshaler.Unmarshal(m, v)
 }
 
+func normalizeContentType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, separator)[0]))
+}
+
 func withJsonBody(r *http.Request) bool {
 	return r.ContentLength > 0 && strings.Contains(r.Header.Get(ContentType), ApplicationJson)
 }
@@ -1,6 +1,9 @@
 package httpx
 
 import (
+	"encoding/xml"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -224,3 +227,41 @@ func TestParseHeaders(t *testing.T) {
 	assert.Equal(t, "1", v.Percent)
 	assert.Equal(t, []string{"addr1", "addr2"}, v.Addrs)
 }
+
+func TestParseBodyWithRegisteredDecoder(t *testing.T) {
+	RegisterBodyDecoder("application/xml", func(r io.Reader, v interface{}) error {
+		return xml.NewDecoder(r).Decode(v)
+	})
+
+	var v struct {
+		XMLName xml.Name `xml:"user" json:"-"`
+		Name    string   `xml:"name" json:"name"`
+	}
+	body := "<user><name>kevin</name></user>"
+	r := httptest.NewRequest(http.MethodPost, "http://localhost/a", strings.NewReader(body))
+	r.Header.Set(ContentType, "application/xml; charset=utf-8")
+	assert.Nil(t, ParseBody(r, &v))
+	assert.Equal(t, "kevin", v.Name)
+}
+
+func TestParseBodyDefaultsToJson(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	body := `{"name": "kevin"}`
+	r := httptest.NewRequest(http.MethodPost, "http://localhost/a", strings.NewReader(body))
+	r.Header.Set(ContentType, ApplicationJson)
+	assert.Nil(t, ParseBody(r, &v))
+	assert.Equal(t, "kevin", v.Name)
+}
+
+func TestParseBodyEmptyWithDecoder(t *testing.T) {
+	RegisterBodyDecoder("application/x-empty", func(r io.Reader, v interface{}) error {
+		return errors.New("should not be called")
+	})
+
+	var v struct{}
+	r := httptest.NewRequest(http.MethodPost, "http://localhost/a", nil)
+	r.Header.Set(ContentType, "application/x-empty")
+	assert.Nil(t, ParseBody(r, &v))
+}
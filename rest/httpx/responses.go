@@ -2,17 +2,26 @@ package httpx
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"sync"
 
+	"github.com/tal-tech/go-zero/core/errorx"
 	"github.com/tal-tech/go-zero/core/logx"
 )
 
 var (
 	errorHandler func(error) (int, interface{})
 	lock         sync.RWMutex
+
+	encoderLock  sync.RWMutex
+	bodyEncoders = make(map[string]BodyEncoder)
 )
 
+// A BodyEncoder encodes v into w as a response body.
+type BodyEncoder func(w io.Writer, v interface{}) error
+
 // Error writes err into w.
 func Error(w http.ResponseWriter, err error) {
 	lock.RLock()
@@ -20,14 +29,47 @@ func Error(w http.ResponseWriter, err error) {
 	lock.RUnlock()
 
 	if handler == nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if annotated, ok := errorx.HTTPStatus(err); ok {
+			status = annotated
+		}
+
+		if envelopeEnabled.True() {
+			WriteJson(w, status, Envelope{
+				Code:    status,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		http.Error(w, err.Error(), status)
 		return
 	}
 
 	code, body := errorHandler(err)
 	e, ok := body.(error)
 	if ok {
+		if envelopeEnabled.True() {
+			WriteJson(w, code, Envelope{
+				Code:    code,
+				Message: e.Error(),
+			})
+			return
+		}
+
 		http.Error(w, e.Error(), code)
+	} else if envelopeEnabled.True() {
+		// don't double-wrap an already enveloped body
+		if env, isEnv := body.(Envelope); isEnv {
+			WriteJson(w, code, env)
+			return
+		}
+
+		WriteJson(w, code, Envelope{
+			Code:    code,
+			Message: err.Error(),
+			Data:    body,
+		})
 	} else {
 		WriteJson(w, code, body)
 	}
@@ -43,6 +85,37 @@ func OkJson(w http.ResponseWriter, v interface{}) {
 	WriteJson(w, http.StatusOK, v)
 }
 
+// RegisterEncoder registers fn to encode responses as the given content
+// type when the request asks for it in the Accept header,
+// json remains the default response format.
+func RegisterEncoder(contentType string, fn BodyEncoder) {
+	encoderLock.Lock()
+	bodyEncoders[normalizeContentType(contentType)] = fn
+	encoderLock.Unlock()
+}
+
+// Respond writes v into w with 200 OK, encoded with the first registered
+// encoder the request's Accept header matches, falling back to json.
+func Respond(w http.ResponseWriter, r *http.Request, v interface{}) {
+	RespondWithCode(w, r, http.StatusOK, v)
+}
+
+// RespondWithCode writes v into w with code, negotiating the encoding
+// like Respond does.
+func RespondWithCode(w http.ResponseWriter, r *http.Request, code int, v interface{}) {
+	contentType, encoder := negotiateEncoder(r.Header.Get(Accept))
+	if encoder == nil {
+		WriteJson(w, code, v)
+		return
+	}
+
+	w.Header().Set(ContentType, contentType)
+	w.WriteHeader(code)
+	if err := encoder(w, v); err != nil {
+		logx.Errorf("write response failed, error: %s", err)
+	}
+}
+
 // SetErrorHandler sets the error handler, which is called on calling Error.
 func SetErrorHandler(handler func(error) (int, interface{})) {
 	lock.Lock()
@@ -50,6 +123,20 @@ func SetErrorHandler(handler func(error) (int, interface{})) {
 	errorHandler = handler
 }
 
+func negotiateEncoder(acceptHeader string) (string, BodyEncoder) {
+	encoderLock.RLock()
+	defer encoderLock.RUnlock()
+
+	for _, part := range strings.Split(acceptHeader, ",") {
+		contentType := normalizeContentType(part)
+		if encoder, ok := bodyEncoders[contentType]; ok {
+			return contentType, encoder
+		}
+	}
+
+	return "", nil
+}
+
 // WriteJson writes v as json string into w with code.
 func WriteJson(w http.ResponseWriter, code int, v interface{}) {
 	w.Header().Set(ContentType, ApplicationJson)
@@ -1,13 +1,18 @@
 package httpx
 
 import (
+	"encoding/xml"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/errorx"
 	"github.com/tal-tech/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
 )
 
 type message struct {
@@ -80,6 +85,15 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestErrorWithCode(t *testing.T) {
+	w := tracedResponseWriter{
+		headers: make(map[string][]string),
+	}
+	Error(&w, errorx.WithCode(errors.New("not found"), http.StatusNotFound, codes.NotFound))
+	assert.Equal(t, http.StatusNotFound, w.code)
+	assert.Equal(t, "not found", strings.TrimSpace(w.builder.String()))
+}
+
 func TestOk(t *testing.T) {
 	w := tracedResponseWriter{
 		headers: make(map[string][]string),
@@ -146,3 +160,34 @@ func (w *tracedResponseWriter) Write(bytes []byte) (n int, err error) {
 func (w *tracedResponseWriter) WriteHeader(code int) {
 	w.code = code
 }
+
+func TestRespondNegotiated(t *testing.T) {
+	RegisterEncoder("application/xml", func(w io.Writer, v interface{}) error {
+		return xml.NewEncoder(w).Encode(v)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/a", nil)
+	r.Header.Set(Accept, "application/xml;q=0.9, application/json;q=0.8")
+	Respond(w, r, message{Name: "anyone"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get(ContentType))
+	assert.Contains(t, w.Body.String(), "<Name>anyone</Name>")
+}
+
+func TestRespondFallsBackToJson(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/a", nil)
+	r.Header.Set(Accept, "text/unregistered")
+	Respond(w, r, message{Name: "anyone"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ApplicationJson, w.Header().Get(ContentType))
+	assert.Equal(t, `{"name":"anyone"}`, w.Body.String())
+}
+
+func TestRespondWithCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/a", nil)
+	RespondWithCode(w, r, http.StatusCreated, message{Name: "anyone"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
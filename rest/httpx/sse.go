@@ -0,0 +1,120 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// EventStreamContentType means the response is a server-sent event stream.
+	EventStreamContentType = "text/event-stream"
+
+	defaultHeartbeat = time.Second * 15
+)
+
+// ErrStreamingUnsupported is returned by ServeSSE when the ResponseWriter
+// doesn't support flushing, like under http.TimeoutHandler.
+var ErrStreamingUnsupported = errors.New("streaming unsupported by response writer")
+
+type (
+	// An SSEvent is one server-sent event.
+	SSEvent struct {
+		// ID is the optional event id, written as the id: field.
+		ID string
+		// Event is the optional event type, written as the event: field.
+		Event string
+		// Data is the event payload, multi-line data is framed
+		// as multiple data: fields.
+		Data string
+	}
+
+	// SSEOption defines the method to customize ServeSSE.
+	SSEOption func(opts *sseOptions)
+
+	sseOptions struct {
+		heartbeat time.Duration
+	}
+)
+
+// IsEventStreamRequest reports whether r asks for server-sent events.
+func IsEventStreamRequest(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), EventStreamContentType)
+}
+
+// WithSSEHeartbeat customizes ServeSSE with the heartbeat interval,
+// heartbeats are comment lines that keep proxies from closing idle streams.
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(opts *sseOptions) {
+		opts.heartbeat = interval
+	}
+}
+
+// ServeSSE streams events to w as server-sent events, flushing after each,
+// until events is closed or the request context is canceled.
+func ServeSSE(w http.ResponseWriter, r *http.Request, events <-chan SSEvent, opts ...SSEOption) error {
+	options := sseOptions{
+		heartbeat: defaultHeartbeat,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+
+	w.Header().Set(ContentType, EventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(options.heartbeat)
+	defer ticker.Stop()
+
+	done := r.Context().Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(w, event); err != nil {
+				return err
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event SSEvent) error {
+	if len(event.ID) > 0 {
+		if _, err := fmt.Fprintf(w, "id: %s\n", event.ID); err != nil {
+			return err
+		}
+	}
+	if len(event.Event) > 0 {
+		if _, err := fmt.Fprintf(w, "event: %s\n", event.Event); err != nil {
+			return err
+		}
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		if _, err := fmt.Fprintf(w, "data: %s\n", line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
+
+	return err
+}
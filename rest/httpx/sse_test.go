@@ -0,0 +1,87 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEventStreamRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	assert.False(t, IsEventStreamRequest(req))
+	req.Header.Set("Accept", EventStreamContentType)
+	assert.True(t, IsEventStreamRequest(req))
+}
+
+func TestServeSSE(t *testing.T) {
+	events := make(chan SSEvent, 3)
+	events <- SSEvent{Data: "first"}
+	events <- SSEvent{ID: "2", Event: "update", Data: "second"}
+	events <- SSEvent{Data: "multi\nline"}
+	close(events)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	resp := httptest.NewRecorder()
+	assert.Nil(t, ServeSSE(resp, req, events))
+
+	assert.Equal(t, EventStreamContentType, resp.Header().Get(ContentType))
+	assert.Equal(t, "no-cache", resp.Header().Get("Cache-Control"))
+	body := resp.Body.String()
+	assert.Contains(t, body, "data: first\n\n")
+	assert.Contains(t, body, "id: 2\nevent: update\ndata: second\n\n")
+	assert.Contains(t, body, "data: multi\ndata: line\n\n")
+}
+
+func TestServeSSEContextCancel(t *testing.T) {
+	events := make(chan SSEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil).WithContext(ctx)
+	resp := httptest.NewRecorder()
+
+	done := make(chan error)
+	go func() {
+		done <- ServeSSE(resp, req, events)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ServeSSE didn't return on context cancel")
+	}
+}
+
+func TestServeSSEHeartbeat(t *testing.T) {
+	events := make(chan SSEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil).WithContext(ctx)
+	resp := httptest.NewRecorder()
+
+	done := make(chan error)
+	go func() {
+		done <- ServeSSE(resp, req, events, WithSSEHeartbeat(time.Millisecond*10))
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	assert.Nil(t, <-done)
+	assert.Contains(t, resp.Body.String(), ": heartbeat\n\n")
+}
+
+func TestServeSSEStreamingUnsupported(t *testing.T) {
+	events := make(chan SSEvent)
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	assert.Equal(t, ErrStreamingUnsupported, ServeSSE(nonFlusher{}, req, events))
+}
+
+type nonFlusher struct{}
+
+func (nonFlusher) Header() http.Header         { return http.Header{} }
+func (nonFlusher) Write(p []byte) (int, error) { return len(p), nil }
+func (nonFlusher) WriteHeader(statusCode int)  {}
@@ -1,8 +1,49 @@
 package httpx
 
-import "net/http"
+import (
+	"net"
+	"net/http"
+	"strings"
+)
 
-const xForwardedFor = "X-Forwarded-For"
+const (
+	forwarded     = "Forwarded"
+	xForwardedFor = "X-Forwarded-For"
+	xRealIP       = "X-Real-Ip"
+)
+
+// ClientIP returns the real client IP of r, honoring the forwarding
+// headers only when the peer is in trustedProxies, given as IPs or CIDRs.
+// X-Forwarded-For is preferred, then the RFC 7239 Forwarded header,
+// then X-Real-Ip. The forwarded entries are walked right to left
+// skipping trusted hops, the first untrusted address is the client.
+// When the peer itself isn't a trusted proxy the forwarding headers are
+// ignored entirely, so a spoofed X-Forwarded-For can't forge the client IP.
+func ClientIP(r *http.Request, trustedProxies []string) string {
+	remote := remoteIP(r)
+	if len(trustedProxies) == 0 {
+		return remote
+	}
+
+	trusted, err := ParseCidrs(trustedProxies)
+	if err != nil || !containsIP(trusted, remote) {
+		return remote
+	}
+
+	hops := forwardedHops(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !containsIP(trusted, hops[i]) {
+			return hops[i]
+		}
+	}
+
+	if len(hops) > 0 {
+		// every hop is a trusted proxy, the leftmost is the origin
+		return hops[0]
+	}
+
+	return remote
+}
 
 // GetRemoteAddr returns the peer address, supports X-Forward-For.
 func GetRemoteAddr(r *http.Request) string {
@@ -12,3 +53,96 @@ func GetRemoteAddr(r *http.Request) string {
 	}
 	return r.RemoteAddr
 }
+
+func containsIP(nets []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range nets {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseCidrs parses entries given as CIDRs or plain IPs into networks.
+func ParseCidrs(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := len(ip) * 8
+				if v4 := ip.To4(); v4 != nil {
+					ip = v4
+					bits = len(v4) * 8
+				}
+				nets = append(nets, &net.IPNet{
+					IP:   ip,
+					Mask: net.CIDRMask(bits, bits),
+				})
+				continue
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, network)
+	}
+
+	return nets, nil
+}
+
+// forwardedHops returns the proxy hops of r, leftmost is the origin.
+func forwardedHops(r *http.Request) []string {
+	var hops []string
+	appendHop := func(hop string) {
+		if hop = normalizeHop(hop); len(hop) > 0 {
+			hops = append(hops, hop)
+		}
+	}
+
+	if value := r.Header.Get(xForwardedFor); len(value) > 0 {
+		for _, part := range strings.Split(value, ",") {
+			appendHop(part)
+		}
+		return hops
+	}
+
+	if value := r.Header.Get(forwarded); len(value) > 0 {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+				if len(kv) == 2 && strings.EqualFold(kv[0], "for") {
+					appendHop(kv[1])
+				}
+			}
+		}
+		return hops
+	}
+
+	appendHop(r.Header.Get(xRealIP))
+	return hops
+}
+
+// normalizeHop strips the quotes, brackets and port a forwarded entry
+// may carry, like "[2001:db8::1]:8080".
+func normalizeHop(hop string) string {
+	hop = strings.Trim(strings.TrimSpace(hop), `"`)
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	}
+	return strings.Trim(hop, "[]")
+}
+
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -2,6 +2,7 @@ package httpx
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -16,3 +17,56 @@ func TestGetRemoteAddr(t *testing.T) {
 	r.Header.Set(xForwardedFor, host)
 	assert.Equal(t, host, GetRemoteAddr(r))
 }
+
+func TestClientIPUntrustedPeer(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "8.8.8.8:1234"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4")
+	assert.Equal(t, "8.8.8.8", ClientIP(r, []string{"10.0.0.0/8"}))
+	// no trusted proxies configured at all
+	assert.Equal(t, "8.8.8.8", ClientIP(r, nil))
+}
+
+func TestClientIPWalksForwardedFor(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4, 5.6.7.8, 10.0.0.2")
+	// both 10.x hops are trusted, the rightmost untrusted entry wins
+	assert.Equal(t, "5.6.7.8", ClientIP(r, []string{"10.0.0.0/8"}))
+}
+
+func TestClientIPAllHopsTrusted(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "10.0.0.9, 10.0.0.2")
+	assert.Equal(t, "10.0.0.9", ClientIP(r, []string{"10.0.0.0/8"}))
+}
+
+func TestClientIPForwardedHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("Forwarded", `for="[2001:db8::1]:8080";proto=https, for=10.0.0.2`)
+	assert.Equal(t, "2001:db8::1", ClientIP(r, []string{"10.0.0.0/8"}))
+}
+
+func TestClientIPRealIPHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Real-Ip", "1.2.3.4")
+	assert.Equal(t, "1.2.3.4", ClientIP(r, []string{"10.0.0.0/8"}))
+}
+
+func TestClientIPNoForwardingHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "10.0.0.1", ClientIP(r, []string{"10.0.0.0/8"}))
+}
+
+func TestParseCidrs(t *testing.T) {
+	nets, err := ParseCidrs([]string{"10.0.0.0/8", "192.168.1.1", "2001:db8::1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(nets))
+
+	_, err = ParseCidrs([]string{"bad"})
+	assert.NotNil(t, err)
+}
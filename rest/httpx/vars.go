@@ -1,6 +1,8 @@
 package httpx
 
 const (
+	// Accept means Accept.
+	Accept = "Accept"
 	// ApplicationJson means application/json.
 	ApplicationJson = "application/json"
 	// ContentEncoding means Content-Encoding.
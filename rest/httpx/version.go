@@ -0,0 +1,48 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+type versionKey struct{}
+
+// ContextWithVersion returns a new context carrying the negotiated api version.
+func ContextWithVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, versionKey{}, version)
+}
+
+// VersionFromContext returns the negotiated api version in ctx,
+// ok false when the route isn't versioned.
+func VersionFromContext(ctx context.Context) (string, bool) {
+	version, ok := ctx.Value(versionKey{}).(string)
+	return version, ok
+}
+
+// ParseVersion returns the api version asked by r, like "v2", taken from
+// the first path segment, or from the version parameter of the Accept
+// header like "application/vnd.api+json;version=2". An empty string
+// means no version was asked.
+func ParseVersion(r *http.Request) string {
+	segments := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(segments) > 0 && versionSegment.MatchString(segments[0]) {
+		return segments[0]
+	}
+
+	// the Accept header holds comma separated media ranges,
+	// each with its own semicolon separated parameters
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		for _, part := range strings.Split(mediaRange, ";") {
+			part = strings.TrimSpace(part)
+			if value := strings.TrimPrefix(part, "version="); value != part && len(value) > 0 {
+				return "v" + value
+			}
+		}
+	}
+
+	return ""
+}
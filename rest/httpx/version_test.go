@@ -0,0 +1,86 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionContext(t *testing.T) {
+	_, ok := VersionFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithVersion(context.Background(), "v2")
+	version, ok := VersionFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "v2", version)
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		expect string
+	}{
+		{
+			name:   "path prefix",
+			url:    "http://localhost/v2/users",
+			expect: "v2",
+		},
+		{
+			name:   "accept header",
+			url:    "http://localhost/users",
+			accept: "application/vnd.api+json;version=2",
+			expect: "v2",
+		},
+		{
+			name:   "accept header spaced",
+			url:    "http://localhost/users",
+			accept: "application/json; version=10",
+			expect: "v10",
+		},
+		{
+			name:   "path wins over header",
+			url:    "http://localhost/v1/users",
+			accept: "application/json;version=2",
+			expect: "v1",
+		},
+		{
+			name:   "accept header with multiple media ranges",
+			url:    "http://localhost/users",
+			accept: "application/vnd.api+json;version=2, application/json",
+			expect: "v2",
+		},
+		{
+			name:   "accept header with version in later media range",
+			url:    "http://localhost/users",
+			accept: "application/json, application/vnd.api+json; version=3;q=0.9",
+			expect: "v3",
+		},
+		{
+			name:   "not a version segment",
+			url:    "http://localhost/view/users",
+			expect: "",
+		},
+		{
+			name:   "no version",
+			url:    "http://localhost/users",
+			accept: "application/json",
+			expect: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, test.url, nil)
+			if len(test.accept) > 0 {
+				r.Header.Set("Accept", test.accept)
+			}
+			assert.Equal(t, test.expect, ParseVersion(r))
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Package jsonschema implements the subset of JSON Schema used to
+// validate request bodies: type, enum, required, properties,
+// additionalProperties, items, string/number/array bounds and pattern.
+// Schemas are compiled once and safe for concurrent use.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A Schema is a compiled JSON Schema.
+type Schema struct {
+	types            []string
+	enum             []interface{}
+	required         []string
+	properties       map[string]*Schema
+	additionalProps  *bool
+	items            *Schema
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	minLength        *int
+	maxLength        *int
+	minItems         *int
+	maxItems         *int
+	pattern          *regexp.Regexp
+	patternSource    string
+}
+
+// Compile parses and compiles the given schema document.
+func Compile(data []byte) (*Schema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid schema document: %w", err)
+	}
+
+	doc, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema document must be an object")
+	}
+
+	return compile(doc, "#")
+}
+
+// Validate checks v, a value decoded with encoding/json, against s,
+// and returns the violations, empty when v is valid.
+func (s *Schema) Validate(v interface{}) []string {
+	var violations []string
+	s.validate(v, "", &violations)
+	return violations
+}
+
+func compile(doc map[string]interface{}, path string) (*Schema, error) {
+	s := new(Schema)
+
+	switch typ := doc["type"].(type) {
+	case nil:
+	case string:
+		s.types = []string{typ}
+	case []interface{}:
+		for _, one := range typ {
+			name, ok := one.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: type entries must be strings", path)
+			}
+			s.types = append(s.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%s: type must be a string or an array", path)
+	}
+
+	if enum, ok := doc["enum"].([]interface{}); ok {
+		s.enum = enum
+	}
+
+	if required, ok := doc["required"].([]interface{}); ok {
+		for _, one := range required {
+			name, ok := one.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: required entries must be strings", path)
+			}
+			s.required = append(s.required, name)
+		}
+	}
+
+	if props, ok := doc["properties"].(map[string]interface{}); ok {
+		s.properties = make(map[string]*Schema, len(props))
+		for name, prop := range props {
+			sub, ok := prop.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: property %q must be an object", path, name)
+			}
+			compiled, err := compile(sub, path+"/properties/"+name)
+			if err != nil {
+				return nil, err
+			}
+			s.properties[name] = compiled
+		}
+	}
+
+	if additional, ok := doc["additionalProperties"].(bool); ok {
+		s.additionalProps = &additional
+	}
+
+	if items, ok := doc["items"].(map[string]interface{}); ok {
+		compiled, err := compile(items, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		s.items = compiled
+	}
+
+	s.minimum = floatField(doc, "minimum")
+	s.maximum = floatField(doc, "maximum")
+	s.exclusiveMinimum = floatField(doc, "exclusiveMinimum")
+	s.exclusiveMaximum = floatField(doc, "exclusiveMaximum")
+	s.minLength = intField(doc, "minLength")
+	s.maxLength = intField(doc, "maxLength")
+	s.minItems = intField(doc, "minItems")
+	s.maxItems = intField(doc, "maxItems")
+
+	if pattern, ok := doc["pattern"].(string); ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+		s.pattern = compiled
+		s.patternSource = pattern
+	}
+
+	return s, nil
+}
+
+func (s *Schema) validate(v interface{}, path string, violations *[]string) {
+	if len(s.types) > 0 && !matchesType(v, s.types) {
+		add(violations, path, "expected type %s, got %s",
+			strings.Join(s.types, " or "), typeName(v))
+		return
+	}
+
+	if len(s.enum) > 0 && !inEnum(v, s.enum) {
+		add(violations, path, "value not in enum")
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		s.validateObject(value, path, violations)
+	case []interface{}:
+		s.validateArray(value, path, violations)
+	case string:
+		s.validateString(value, path, violations)
+	case float64:
+		s.validateNumber(value, path, violations)
+	}
+}
+
+func (s *Schema) validateArray(value []interface{}, path string, violations *[]string) {
+	if s.minItems != nil && len(value) < *s.minItems {
+		add(violations, path, "expected at least %d items, got %d", *s.minItems, len(value))
+	}
+	if s.maxItems != nil && len(value) > *s.maxItems {
+		add(violations, path, "expected at most %d items, got %d", *s.maxItems, len(value))
+	}
+	if s.items != nil {
+		for i, item := range value {
+			s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	}
+}
+
+func (s *Schema) validateNumber(value float64, path string, violations *[]string) {
+	if s.minimum != nil && value < *s.minimum {
+		add(violations, path, "value %v below minimum %v", value, *s.minimum)
+	}
+	if s.maximum != nil && value > *s.maximum {
+		add(violations, path, "value %v above maximum %v", value, *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && value <= *s.exclusiveMinimum {
+		add(violations, path, "value %v not above exclusive minimum %v",
+			value, *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && value >= *s.exclusiveMaximum {
+		add(violations, path, "value %v not below exclusive maximum %v",
+			value, *s.exclusiveMaximum)
+	}
+}
+
+func (s *Schema) validateObject(value map[string]interface{}, path string,
+	violations *[]string) {
+	for _, name := range s.required {
+		if _, ok := value[name]; !ok {
+			add(violations, join(path, name), "required property missing")
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sub, ok := s.properties[name]
+		if !ok {
+			if s.additionalProps != nil && !*s.additionalProps {
+				add(violations, join(path, name), "additional property not allowed")
+			}
+			continue
+		}
+		sub.validate(value[name], join(path, name), violations)
+	}
+}
+
+func (s *Schema) validateString(value string, path string, violations *[]string) {
+	length := len([]rune(value))
+	if s.minLength != nil && length < *s.minLength {
+		add(violations, path, "expected at least %d characters, got %d",
+			*s.minLength, length)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		add(violations, path, "expected at most %d characters, got %d",
+			*s.maxLength, length)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		add(violations, path, "value doesn't match pattern %q", s.patternSource)
+	}
+}
+
+func add(violations *[]string, path, format string, args ...interface{}) {
+	if len(path) == 0 {
+		path = "body"
+	}
+	*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+}
+
+func floatField(doc map[string]interface{}, name string) *float64 {
+	if value, ok := doc[name].(float64); ok {
+		return &value
+	}
+	return nil
+}
+
+func inEnum(v interface{}, enum []interface{}) bool {
+	for _, one := range enum {
+		if equalValues(v, one) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalValues(a, b interface{}) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ab) == string(bb)
+}
+
+func intField(doc map[string]interface{}, name string) *int {
+	if value, ok := doc[name].(float64); ok {
+		n := int(value)
+		return &n
+	}
+	return nil
+}
+
+func join(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+func matchesType(v interface{}, types []string) bool {
+	for _, typ := range types {
+		switch typ {
+		case "object":
+			if _, ok := v.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := v.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := v.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "null":
+			if v == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case nil:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
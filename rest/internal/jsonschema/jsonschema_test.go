@@ -0,0 +1,53 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validateJson(t *testing.T, schema, body string) []string {
+	compiled, err := Compile([]byte(schema))
+	assert.Nil(t, err)
+	var v interface{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &v))
+	return compiled.Validate(v)
+}
+
+func TestValidateEnum(t *testing.T) {
+	schema := `{"type": "string", "enum": ["red", "green"]}`
+	assert.Empty(t, validateJson(t, schema, `"red"`))
+	assert.NotEmpty(t, validateJson(t, schema, `"blue"`))
+}
+
+func TestValidatePattern(t *testing.T) {
+	schema := `{"type": "string", "pattern": "^v\\d+$"}`
+	assert.Empty(t, validateJson(t, schema, `"v2"`))
+	assert.NotEmpty(t, validateJson(t, schema, `"two"`))
+}
+
+func TestValidateArrayItems(t *testing.T) {
+	schema := `{"type": "array", "minItems": 1, "items": {"type": "number", "maximum": 10}}`
+	assert.Empty(t, validateJson(t, schema, `[1, 2]`))
+	assert.Equal(t, []string{"body: expected at least 1 items, got 0"},
+		validateJson(t, schema, `[]`))
+	assert.Equal(t, []string{"[1]: value 11 above maximum 10"},
+		validateJson(t, schema, `[1, 11]`))
+}
+
+func TestValidateTypeList(t *testing.T) {
+	schema := `{"type": ["string", "null"]}`
+	assert.Empty(t, validateJson(t, schema, `null`))
+	assert.Empty(t, validateJson(t, schema, `"ok"`))
+	assert.NotEmpty(t, validateJson(t, schema, `1`))
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, err := Compile([]byte(`[]`))
+	assert.NotNil(t, err)
+	_, err = Compile([]byte(`{"type": 1}`))
+	assert.NotNil(t, err)
+	_, err = Compile([]byte(`not json`))
+	assert.NotNil(t, err)
+}
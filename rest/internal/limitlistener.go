@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+// A LimitedListener caps the simultaneous connections accepted from the
+// wrapped listener, Accept blocks while the cap is reached so connection
+// floods queue in the kernel backlog instead of reaching the server.
+type LimitedListener struct {
+	net.Listener
+	sema   chan lang.PlaceholderType
+	active int64
+}
+
+// NewLimitedListener returns a LimitedListener accepting at most n
+// simultaneous connections from l.
+func NewLimitedListener(l net.Listener, n int) *LimitedListener {
+	return &LimitedListener{
+		Listener: l,
+		sema:     make(chan lang.PlaceholderType, n),
+	}
+}
+
+// Accept implements net.Listener.
+func (l *LimitedListener) Accept() (net.Conn, error) {
+	l.sema <- lang.Placeholder
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sema
+		return nil, err
+	}
+
+	atomic.AddInt64(&l.active, 1)
+	return &limitedConn{
+		Conn:     conn,
+		listener: l,
+	}, nil
+}
+
+// ActiveConns returns the number of connections currently open.
+func (l *LimitedListener) ActiveConns() int64 {
+	return atomic.LoadInt64(&l.active)
+}
+
+type limitedConn struct {
+	net.Conn
+	listener *LimitedListener
+	once     sync.Once
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(func() {
+		atomic.AddInt64(&c.listener.active, -1)
+		<-c.listener.sema
+	})
+
+	return err
+}
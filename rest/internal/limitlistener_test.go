@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitedListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	limited := NewLimitedListener(ln, 1)
+	defer limited.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := limited.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	first, err := net.Dial("tcp", ln.Addr().String())
+	assert.Nil(t, err)
+	defer first.Close()
+
+	var firstConn net.Conn
+	select {
+	case firstConn = <-accepted:
+	case <-time.After(time.Second):
+		t.Fatal("first connection not accepted")
+	}
+	assert.Equal(t, int64(1), limited.ActiveConns())
+
+	// the second connection is not accepted until the first closes
+	second, err := net.Dial("tcp", ln.Addr().String())
+	assert.Nil(t, err)
+	defer second.Close()
+
+	select {
+	case <-accepted:
+		t.Fatal("second connection accepted beyond the cap")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	assert.Nil(t, firstConn.Close())
+	select {
+	case conn := <-accepted:
+		conn.Close()
+	case <-time.After(time.Second):
+		t.Fatal("second connection not accepted after release")
+	}
+}
+
+func TestLimitedConnDoubleClose(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	limited := NewLimitedListener(ln, 1)
+	defer limited.Close()
+
+	done := make(chan net.Conn, 1)
+	go func() {
+		conn, err := limited.Accept()
+		if err == nil {
+			done <- conn
+		}
+	}()
+
+	client, err := net.Dial("tcp", ln.Addr().String())
+	assert.Nil(t, err)
+	defer client.Close()
+
+	conn := <-done
+	assert.Nil(t, conn.Close())
+	// closing twice releases the semaphore only once
+	conn.Close()
+	assert.Equal(t, int64(0), limited.ActiveConns())
+}
@@ -3,29 +3,71 @@ package internal
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/tal-tech/go-zero/core/proc"
+	"github.com/tal-tech/go-zero/core/stat"
 )
 
+type (
+	// StartOption defines the method to customize http server starting.
+	StartOption func(o *startOptions)
+
+	startOptions struct {
+		maxConns int
+	}
+)
+
+// WithMaxConns returns a StartOption to cap the simultaneous connections
+// of the server listener, 0 means no limit.
+func WithMaxConns(n int) StartOption {
+	return func(o *startOptions) {
+		o.maxConns = n
+	}
+}
+
 // StartHttp starts a http server.
-func StartHttp(host string, port int, handler http.Handler) error {
-	return start(host, port, handler, func(srv *http.Server) error {
-		return srv.ListenAndServe()
+func StartHttp(host string, port int, handler http.Handler, opts ...StartOption) error {
+	return start(host, port, handler, opts, func(srv *http.Server, ln net.Listener) error {
+		return srv.Serve(ln)
 	})
 }
 
 // StartHttps starts a https server.
-func StartHttps(host string, port int, certFile, keyFile string, handler http.Handler) error {
-	return start(host, port, handler, func(srv *http.Server) error {
+func StartHttps(host string, port int, certFile, keyFile string, handler http.Handler,
+	opts ...StartOption) error {
+	return start(host, port, handler, opts, func(srv *http.Server, ln net.Listener) error {
 		// certFile and keyFile are set in buildHttpsServer
-		return srv.ListenAndServeTLS(certFile, keyFile)
+		return srv.ServeTLS(ln, certFile, keyFile)
 	})
 }
 
-func start(host string, port int, handler http.Handler, run func(srv *http.Server) error) error {
+func start(host string, port int, handler http.Handler, opts []StartOption,
+	run func(srv *http.Server, ln net.Listener) error) error {
+	var options startOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	addr := fmt.Sprintf("%s:%d", host, port)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if options.maxConns > 0 {
+		limited := NewLimitedListener(ln, options.maxConns)
+		ln = limited
+		gauge := "active_conns@" + addr
+		stat.RegisterGauge("http_server", gauge, func() float64 {
+			return float64(limited.ActiveConns())
+		})
+		defer stat.UnregisterGauge("http_server", gauge)
+	}
+
 	server := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", host, port),
+		Addr:    addr,
 		Handler: handler,
 	}
 	waitForCalled := proc.AddWrapUpListener(func() {
@@ -33,5 +75,5 @@ func start(host string, port int, handler http.Handler, run func(srv *http.Serve
 	})
 	defer waitForCalled()
 
-	return run(server)
+	return run(server, ln)
 }
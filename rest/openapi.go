@@ -0,0 +1,224 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+type (
+	// OpenApiOption defines the method to customize the generated document.
+	OpenApiOption func(o *openApiOptions)
+
+	openApiOptions struct {
+		title    string
+		version  string
+		requests map[string]interface{}
+	}
+)
+
+// WithOpenApiInfo customizes the document title and version.
+func WithOpenApiInfo(title, version string) OpenApiOption {
+	return func(o *openApiOptions) {
+		o.title = title
+		o.version = version
+	}
+}
+
+// WithOpenApiRequest enriches the operation of the given route with a
+// request schema reflected from v's struct tags.
+func WithOpenApiRequest(method, path string, v interface{}) OpenApiOption {
+	return func(o *openApiOptions) {
+		o.requests[method+" "+path] = v
+	}
+}
+
+// Routes returns the metadata of all registered routes,
+// handy for debugging route conflicts.
+func (e *Server) Routes() []RegisteredRoute {
+	var routes []RegisteredRoute
+	for _, fr := range e.ngin.routes {
+		for _, route := range fr.routes {
+			routes = append(routes, RegisteredRoute{
+				Method:  route.Method,
+				Path:    route.Path,
+				Handler: handlerName(route.Handler),
+			})
+		}
+	}
+
+	return routes
+}
+
+// OpenApiHandler returns an http.HandlerFunc serving a minimal OpenAPI 3
+// document reflecting the currently mounted routes, mount it on a route
+// like /swagger.json.
+func (e *Server) OpenApiHandler(opts ...OpenApiOption) http.HandlerFunc {
+	options := openApiOptions{
+		title:    "api",
+		version:  "1.0.0",
+		requests: make(map[string]interface{}),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		httpx.OkJson(w, buildOpenApi(e.Routes(), options))
+	}
+}
+
+func buildOpenApi(routes []RegisteredRoute, options openApiOptions) map[string]interface{} {
+	paths := make(map[string]map[string]interface{})
+	for _, route := range routes {
+		path, params := openApiPath(route.Path)
+		operations, ok := paths[path]
+		if !ok {
+			operations = make(map[string]interface{})
+			paths[path] = operations
+		}
+
+		operation := map[string]interface{}{
+			"operationId": route.Handler,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+				},
+			},
+		}
+
+		var parameters []map[string]interface{}
+		for _, param := range params {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     param,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if v, ok := options.requests[route.Method+" "+route.Path]; ok {
+			operation["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					httpx.ApplicationJson: map[string]interface{}{
+						"schema": schemaOf(reflect.TypeOf(v)),
+					},
+				},
+			}
+		}
+
+		operations[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   options.title,
+			"version": options.version,
+		},
+		"paths": paths,
+	}
+}
+
+func handlerName(handler http.HandlerFunc) string {
+	if handler == nil {
+		return ""
+	}
+
+	return runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+}
+
+// openApiPath converts the router's :param segments into OpenAPI {param}
+// style, returning the converted path and the parameter names.
+func openApiPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+func schemaOf(t reflect.Type) map[string]interface{} {
+	return schemaOfType(t, make(map[reflect.Type]lang.PlaceholderType))
+}
+
+func schemaOfType(t reflect.Type, seen map[reflect.Type]lang.PlaceholderType) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaOfType(t.Elem(), seen),
+		}
+	case reflect.Struct:
+		if _, ok := seen[t]; ok {
+			// break the cycle on self-referential types with a bare object
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = lang.Placeholder
+
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := fieldName(field)
+			if name == "-" {
+				continue
+			}
+			properties[name] = schemaOfType(field.Type, seen)
+		}
+
+		// only cycles get the placeholder, siblings of the same type expand fully
+		delete(seen, t)
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "path"} {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if len(name) > 0 {
+			return name
+		}
+	}
+
+	return field.Name
+}
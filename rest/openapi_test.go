@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/conf"
+)
+
+func newTestServer(t *testing.T) *Server {
+	const configYaml = `
+Name: openapi
+Port: 54321
+`
+	var cnf RestConf
+	assert.Nil(t, conf.LoadConfigFromYamlBytes([]byte(configYaml), &cnf))
+	server, err := NewServer(cnf)
+	assert.Nil(t, err)
+	return server
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+
+func TestServerRoutes(t *testing.T) {
+	server := newTestServer(t)
+	server.AddRoute(Route{
+		Method:  http.MethodGet,
+		Path:    "/users",
+		Handler: listUsers,
+	})
+
+	routes := server.Routes()
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	assert.Equal(t, "/users", routes[0].Path)
+	assert.Contains(t, routes[0].Handler, "listUsers")
+}
+
+func TestOpenApiHandler(t *testing.T) {
+	type createUserReq struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	server := newTestServer(t)
+	server.AddRoute(Route{
+		Method:  http.MethodGet,
+		Path:    "/users/:id",
+		Handler: func(w http.ResponseWriter, r *http.Request) {},
+	})
+	server.AddRoute(Route{
+		Method:  http.MethodPost,
+		Path:    "/users",
+		Handler: func(w http.ResponseWriter, r *http.Request) {},
+	})
+
+	handler := server.OpenApiHandler(
+		WithOpenApiInfo("users api", "2.0.0"),
+		WithOpenApiRequest(http.MethodPost, "/users", createUserReq{}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/swagger.json", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.0", doc["openapi"])
+	info := doc["info"].(map[string]interface{})
+	assert.Equal(t, "users api", info["title"])
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/users/{id}")
+	assert.Contains(t, paths, "/users")
+
+	get := paths["/users/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	params := get["parameters"].([]interface{})
+	assert.Equal(t, 1, len(params))
+	assert.Equal(t, "id", params[0].(map[string]interface{})["name"])
+
+	post := paths["/users"].(map[string]interface{})["post"].(map[string]interface{})
+	body := post["requestBody"].(map[string]interface{})
+	schema := body["content"].(map[string]interface{})["application/json"]
+	props := schema.(map[string]interface{})["schema"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Contains(t, props, "name")
+	assert.Contains(t, props, "age")
+}
+
+func TestOpenApiPath(t *testing.T) {
+	path, params := openApiPath("/users/:id/orders/:oid")
+	assert.Equal(t, "/users/{id}/orders/{oid}", path)
+	assert.Equal(t, []string{"id", "oid"}, params)
+
+	path, params = openApiPath("/users")
+	assert.Equal(t, "/users", path)
+	assert.Empty(t, params)
+}
+
+func TestSchemaOf(t *testing.T) {
+	type inner struct {
+		Flag bool `json:"flag"`
+	}
+	type sample struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Score   float64  `json:"score"`
+		Tags    []string `json:"tags"`
+		Nested  inner    `json:"nested"`
+		Skipped string   `json:"-"`
+		private string
+	}
+
+	schema := schemaOf(reflect.TypeOf(&sample{}))
+	assert.Equal(t, "object", schema["type"])
+	props := schema["properties"].(map[string]interface{})
+	assert.Equal(t, "string", props["name"].(map[string]interface{})["type"])
+	assert.Equal(t, "integer", props["age"].(map[string]interface{})["type"])
+	assert.Equal(t, "number", props["score"].(map[string]interface{})["type"])
+	assert.Equal(t, "array", props["tags"].(map[string]interface{})["type"])
+	assert.Contains(t, props["nested"].(map[string]interface{})["properties"], "flag")
+	assert.NotContains(t, props, "-")
+	assert.NotContains(t, props, "Skipped")
+	assert.NotContains(t, props, "private")
+}
+
+func TestSchemaOfSelfReferentialType(t *testing.T) {
+	type node struct {
+		Value    string  `json:"value"`
+		Next     *node   `json:"next"`
+		Children []*node `json:"children"`
+	}
+
+	schema := schemaOf(reflect.TypeOf(node{}))
+	assert.Equal(t, "object", schema["type"])
+	props := schema["properties"].(map[string]interface{})
+	assert.Equal(t, "string", props["value"].(map[string]interface{})["type"])
+
+	// cyclic references collapse into a bare object instead of recursing
+	next := props["next"].(map[string]interface{})
+	assert.Equal(t, "object", next["type"])
+	assert.NotContains(t, next, "properties")
+
+	children := props["children"].(map[string]interface{})
+	assert.Equal(t, "array", children["type"])
+	items := children["items"].(map[string]interface{})
+	assert.Equal(t, "object", items["type"])
+	assert.NotContains(t, items, "properties")
+}
+
+func TestSchemaOfRepeatedSiblingType(t *testing.T) {
+	type point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	type box struct {
+		Min point `json:"min"`
+		Max point `json:"max"`
+	}
+
+	// the same type used twice without a cycle keeps its full schema
+	schema := schemaOf(reflect.TypeOf(box{}))
+	props := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"min", "max"} {
+		sub := props[name].(map[string]interface{})
+		assert.Contains(t, sub["properties"], "x")
+		assert.Contains(t, sub["properties"], "y")
+	}
+}
@@ -23,17 +23,48 @@ var (
 	ErrInvalidPath = errors.New("path must begin with '/'")
 )
 
-type patRouter struct {
-	trees      map[string]*search.Tree
-	notFound   http.Handler
-	notAllowed http.Handler
-}
+type (
+	// SlashMode controls how a trailing slash in the request path is matched.
+	SlashMode int
+
+	// RouterOption defines the method to customize the router.
+	RouterOption func(pr *patRouter)
+
+	patRouter struct {
+		trees      map[string]*search.Tree
+		notFound   http.Handler
+		notAllowed http.Handler
+		slashMode  SlashMode
+	}
+)
+
+const (
+	// SlashMerge treats /users/ and /users as the same route, the default.
+	SlashMerge SlashMode = iota
+	// SlashRedirect redirects /users/ to /users,
+	// 301 for GET and HEAD, 308 for the other methods.
+	SlashRedirect
+	// SlashStrict matches registered paths exactly, /users/ is not /users.
+	SlashStrict
+)
 
 // NewRouter returns a httpx.Router.
-func NewRouter() httpx.Router {
-	return &patRouter{
+func NewRouter(opts ...RouterOption) httpx.Router {
+	router := &patRouter{
 		trees: make(map[string]*search.Tree),
 	}
+	for _, opt := range opts {
+		opt(router)
+	}
+
+	return router
+}
+
+// WithSlashMode customizes the router with the trailing slash behavior.
+func WithSlashMode(mode SlashMode) RouterOption {
+	return func(pr *patRouter) {
+		pr.slashMode = mode
+	}
 }
 
 func (pr *patRouter) Handle(method, reqPath string, handler http.Handler) error {
@@ -58,6 +89,26 @@ func (pr *patRouter) Handle(method, reqPath string, handler http.Handler) error
 
 func (pr *patRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	reqPath := path.Clean(r.URL.Path)
+	if hasTrailingSlash(r.URL.Path) {
+		switch pr.slashMode {
+		case SlashRedirect:
+			redirect := reqPath
+			if len(r.URL.RawQuery) > 0 {
+				redirect += "?" + r.URL.RawQuery
+			}
+			code := http.StatusPermanentRedirect
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				code = http.StatusMovedPermanently
+			}
+			http.Redirect(w, r, redirect, code)
+			return
+		case SlashStrict:
+			// registered paths never keep a trailing slash
+			pr.handleNotFound(w, r)
+			return
+		}
+	}
+
 	if tree, ok := pr.trees[r.Method]; ok {
 		if result, ok := tree.Search(reqPath); ok {
 			if len(result.Params) > 0 {
@@ -119,6 +170,10 @@ func (pr *patRouter) methodsAllowed(method, path string) (string, bool) {
 	return "", false
 }
 
+func hasTrailingSlash(path string) bool {
+	return len(path) > 1 && strings.HasSuffix(path, "/")
+}
+
 func validMethod(method string) bool {
 	return method == http.MethodDelete || method == http.MethodGet ||
 		method == http.MethodHead || method == http.MethodOptions ||
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestSlashMergeDefault(t *testing.T) {
+	router := NewRouter()
+	assert.Nil(t, router.Handle(http.MethodGet, "/users", okHandler()))
+
+	for _, url := range []string{"http://localhost/users", "http://localhost/users/"} {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestSlashRedirect(t *testing.T) {
+	router := NewRouter(WithSlashMode(SlashRedirect))
+	assert.Nil(t, router.Handle(http.MethodGet, "/users", okHandler()))
+	assert.Nil(t, router.Handle(http.MethodPost, "/users", okHandler()))
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/users/", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/users", w.Header().Get("Location"))
+
+	// non-GET keeps the method across the redirect
+	r = httptest.NewRequest(http.MethodPost, "http://localhost/users/?a=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+	assert.Equal(t, "/users?a=1", w.Header().Get("Location"))
+
+	// the canonical path still serves directly
+	r = httptest.NewRequest(http.MethodGet, "http://localhost/users", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSlashStrict(t *testing.T) {
+	router := NewRouter(WithSlashMode(SlashStrict))
+	assert.Nil(t, router.Handle(http.MethodGet, "/users", okHandler()))
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/users/", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, "http://localhost/users", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSlashRootUntouched(t *testing.T) {
+	for _, mode := range []SlashMode{SlashMerge, SlashRedirect, SlashStrict} {
+		router := NewRouter(WithSlashMode(mode))
+		assert.Nil(t, router.Handle(http.MethodGet, "/", okHandler()))
+
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
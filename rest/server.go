@@ -76,6 +76,28 @@ func (e *Server) AddRoute(r Route, opts ...RouteOption) {
 	e.AddRoutes([]Route{r}, opts...)
 }
 
+// AddVersionedRoute registers path once for multiple api versions, with
+// handlers keyed by version like "v1". Each version is mounted under its
+// prefix, like /v1/users, and the bare path negotiates the version from
+// the Accept header, answering 406 when no version matches. The picked
+// version is available via httpx.VersionFromContext.
+func (e *Server) AddVersionedRoute(method, path string, handlers map[string]http.HandlerFunc,
+	opts ...RouteOption) {
+	for version, handler := range handlers {
+		e.AddRoute(Route{
+			Method:  method,
+			Path:    "/" + version + path,
+			Handler: versionedHandler(version, handler),
+		}, opts...)
+	}
+
+	e.AddRoute(Route{
+		Method:  method,
+		Path:    path,
+		Handler: negotiateVersion(handlers),
+	}, opts...)
+}
+
 // Start starts the Server.
 // Graceful shutdown is enabled by default.
 // Use proc.SetTimeToForceQuit to customize the graceful shutdown period.
@@ -100,6 +122,35 @@ func ToMiddleware(handler func(next http.Handler) http.Handler) Middleware {
 	}
 }
 
+// WithAuthenticators returns a func to try the given authenticators in
+// order on given route, the first that succeeds sets the caller identity.
+// All failing means 401.
+func WithAuthenticators(authenticators ...handler.Authenticator) RouteOption {
+	return func(r *featuredRoutes) {
+		r.authenticators = authenticators
+	}
+}
+
+// WithResponseEnvelope returns a RunOption to wrap all payloads written
+// via httpx.OkData and httpx.Error in the standard {code, message, data}
+// envelope. Handlers writing raw bytes are left untouched.
+func WithResponseEnvelope() RunOption {
+	return func(server *Server) {
+		httpx.SetResponseEnvelope(true)
+	}
+}
+
+// WithDump returns a func to enable request and response body logging
+// in given route, bodies capped at maxBytes, the named json fields
+// masked before logging.
+func WithDump(maxBytes int, redactFields ...string) RouteOption {
+	return func(r *featuredRoutes) {
+		r.dump.enabled = true
+		r.dump.maxBytes = maxBytes
+		r.dump.redactFields = redactFields
+	}
+}
+
 // WithJwt returns a func to enable jwt authentication in given route.
 func WithJwt(secret string) RouteOption {
 	return func(r *featuredRoutes) {
@@ -146,6 +197,17 @@ func WithMiddleware(middleware Middleware, rs ...Route) []Route {
 	return routes
 }
 
+// WithMaxConns returns a RunOption to cap the simultaneous connections
+// of the server listener, 0 means no limit. Unlike the per-request
+// MaxConns middleware, excess connections are held off before any
+// request processing happens, and the open connection count is reported
+// through the stat gauges.
+func WithMaxConns(n int) RunOption {
+	return func(server *Server) {
+		server.ngin.maxListenerConns = n
+	}
+}
+
 // WithNotFoundHandler returns a RunOption with not found handler set to given handler.
 func WithNotFoundHandler(handler http.Handler) RunOption {
 	rt := router.NewRouter()
@@ -200,6 +262,25 @@ func WithUnsignedCallback(callback handler.UnsignedCallback) RunOption {
 	}
 }
 
+func negotiateVersion(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version := httpx.ParseVersion(r)
+		handler, ok := handlers[version]
+		if !ok {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+
+		versionedHandler(version, handler)(w, r)
+	}
+}
+
+func versionedHandler(version string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(httpx.ContextWithVersion(r.Context(), version)))
+	}
+}
+
 func handleError(err error) {
 	// ErrServerClosed means the server is closed manually
 	if err == nil || err == http.ErrServerClosed {
@@ -0,0 +1,254 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const (
+	defaultJwksRefreshInterval = time.Hour
+	defaultJwksRefreshJitter   = time.Minute * 5
+	unknownKidCooldown         = time.Second * 10
+)
+
+var (
+	// ErrNoKid indicates a token without a kid header.
+	ErrNoKid = errors.New("token has no kid header")
+	// ErrUnknownKid indicates a kid not present in the JWKS document.
+	ErrUnknownKid = errors.New("unknown kid")
+)
+
+type (
+	// JwksOption defines the method to customize a JwksParser.
+	JwksOption func(p *JwksParser)
+
+	// A JwksParser validates RS256/ES256 tokens against a JWKS endpoint,
+	// caching the keys, refreshing them periodically with jitter and on
+	// unknown kid, so IdP key rotation just works.
+	JwksParser struct {
+		url             string
+		refreshInterval time.Duration
+		refreshJitter   time.Duration
+		client          *http.Client
+		lock            sync.RWMutex
+		keys            map[string]interface{}
+		nextRefresh     time.Duration
+		lastMiss        time.Duration
+	}
+
+	jwksDocument struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+)
+
+// NewJwksParser returns a JwksParser fetching keys from url.
+func NewJwksParser(url string, opts ...JwksOption) *JwksParser {
+	parser := &JwksParser{
+		url:             url,
+		refreshInterval: defaultJwksRefreshInterval,
+		refreshJitter:   defaultJwksRefreshJitter,
+		client:          http.DefaultClient,
+		keys:            make(map[string]interface{}),
+	}
+
+	for _, opt := range opts {
+		opt(parser)
+	}
+
+	return parser
+}
+
+// WithJwksHttpClient customizes a JwksParser with the http client.
+func WithJwksHttpClient(client *http.Client) JwksOption {
+	return func(p *JwksParser) {
+		p.client = client
+	}
+}
+
+// WithJwksRefreshInterval customizes a JwksParser with the key refresh interval.
+func WithJwksRefreshInterval(interval time.Duration) JwksOption {
+	return func(p *JwksParser) {
+		p.refreshInterval = interval
+	}
+}
+
+// WithJwksRefreshJitter customizes a JwksParser with the max random
+// delay added to each refresh, spreading fetches across instances.
+func WithJwksRefreshJitter(jitter time.Duration) JwksOption {
+	return func(p *JwksParser) {
+		p.refreshJitter = jitter
+	}
+}
+
+// Keyfunc looks up the verification key for tok, usable as a jwt.Keyfunc.
+func (p *JwksParser) Keyfunc(tok *jwt.Token) (interface{}, error) {
+	switch tok.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", tok.Header["alg"])
+	}
+
+	kid, ok := tok.Header["kid"].(string)
+	if !ok || len(kid) == 0 {
+		return nil, ErrNoKid
+	}
+
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+
+	// maybe the IdP rotated keys, refetch, but not in a tight loop
+	if err := p.refreshOnMiss(); err != nil {
+		return nil, err
+	}
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownKid
+}
+
+// ParseToken parses and verifies tokenString against the cached keys.
+func (p *JwksParser) ParseToken(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, p.Keyfunc)
+}
+
+func (p *JwksParser) lookup(kid string) (interface{}, bool) {
+	p.lock.RLock()
+	due := timex.Now() > p.nextRefresh
+	key, ok := p.keys[kid]
+	p.lock.RUnlock()
+
+	if due {
+		if err := p.refresh(); err != nil {
+			// stale keys beat no keys
+			return key, ok
+		}
+		p.lock.RLock()
+		key, ok = p.keys[kid]
+		p.lock.RUnlock()
+	}
+
+	return key, ok
+}
+
+func (p *JwksParser) refresh() error {
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks fetch: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return err
+	}
+
+	keys := make(map[string]interface{}, len(doc.Keys))
+	for _, each := range doc.Keys {
+		key, err := each.publicKey()
+		if err != nil {
+			return err
+		}
+		keys[each.Kid] = key
+	}
+
+	p.lock.Lock()
+	p.keys = keys
+	p.nextRefresh = timex.Now() + p.refreshInterval + p.jitter()
+	p.lock.Unlock()
+
+	return nil
+}
+
+func (p *JwksParser) refreshOnMiss() error {
+	p.lock.Lock()
+	if p.lastMiss > 0 && timex.Since(p.lastMiss) < unknownKidCooldown {
+		p.lock.Unlock()
+		return nil
+	}
+	p.lastMiss = timex.Now()
+	p.lock.Unlock()
+
+	return p.refresh()
+}
+
+func (p *JwksParser) jitter() time.Duration {
+	if p.refreshJitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(p.refreshJitter)))
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
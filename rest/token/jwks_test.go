@@ -0,0 +1,164 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type jwksServer struct {
+	doc jwksDocument
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(s.doc)
+}
+
+func rsaJwk(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJwk(kid string, key *ecdsa.PublicKey) jwk {
+	return jwk{
+		Kty: "EC",
+		Kid: kid,
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.Bytes()),
+	}
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string) string {
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "u1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	tok.Header["kid"] = kid
+	signed, err := tok.SignedString(key)
+	assert.Nil(t, err)
+	return signed
+}
+
+func TestJwksParserRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	backend := &jwksServer{doc: jwksDocument{Keys: []jwk{rsaJwk("k1", &key.PublicKey)}}}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	parser := NewJwksParser(srv.URL)
+	tok, err := parser.ParseToken(signRS256(t, key, "k1"))
+	assert.Nil(t, err)
+	assert.True(t, tok.Valid)
+	assert.Equal(t, "u1", tok.Claims.(jwt.MapClaims)["sub"])
+}
+
+func TestJwksParserES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	backend := &jwksServer{doc: jwksDocument{Keys: []jwk{ecJwk("k1", &key.PublicKey)}}}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"sub": "u1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	tok.Header["kid"] = "k1"
+	signed, err := tok.SignedString(key)
+	assert.Nil(t, err)
+
+	parser := NewJwksParser(srv.URL)
+	parsed, err := parser.ParseToken(signed)
+	assert.Nil(t, err)
+	assert.True(t, parsed.Valid)
+}
+
+func TestJwksParserKeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	backend := &jwksServer{doc: jwksDocument{Keys: []jwk{rsaJwk("old", &oldKey.PublicKey)}}}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	parser := NewJwksParser(srv.URL)
+	_, err = parser.ParseToken(signRS256(t, oldKey, "old"))
+	assert.Nil(t, err)
+
+	// the IdP rotates, the unknown kid triggers a refetch
+	backend.doc = jwksDocument{Keys: []jwk{rsaJwk("new", &newKey.PublicKey)}}
+	tok, err := parser.ParseToken(signRS256(t, newKey, "new"))
+	assert.Nil(t, err)
+	assert.True(t, tok.Valid)
+}
+
+func TestJwksParserUnknownKidCooldown(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	backend := &jwksServer{doc: jwksDocument{Keys: []jwk{rsaJwk("k1", &key.PublicKey)}}}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	parser := NewJwksParser(srv.URL)
+	_, err = parser.ParseToken(signRS256(t, key, "k1"))
+	assert.Nil(t, err)
+
+	// unknown kid fails without hammering the endpoint
+	_, err = parser.ParseToken(signRS256(t, key, "nope"))
+	assert.NotNil(t, err)
+	_, err = parser.ParseToken(signRS256(t, key, "nope"))
+	assert.NotNil(t, err)
+}
+
+func TestJwksParserRejectsHS256(t *testing.T) {
+	parser := NewJwksParser("http://localhost")
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"})
+	tok.Header["kid"] = "k1"
+	signed, err := tok.SignedString([]byte("secret"))
+	assert.Nil(t, err)
+
+	_, err = parser.ParseToken(signed)
+	assert.NotNil(t, err)
+}
+
+func TestJwksParserNoKid(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	backend := &jwksServer{doc: jwksDocument{Keys: []jwk{rsaJwk("k1", &key.PublicKey)}}}
+	srv := httptest.NewServer(backend)
+	defer srv.Close()
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "u1"})
+	signed, err := tok.SignedString(key)
+	assert.Nil(t, err)
+
+	parser := NewJwksParser(srv.URL)
+	_, err = parser.ParseToken(signed)
+	assert.NotNil(t, err)
+}
+
+func TestJwkUnsupported(t *testing.T) {
+	_, err := jwk{Kty: "oct"}.publicKey()
+	assert.NotNil(t, err)
+	_, err = jwk{Kty: "EC", Crv: "P-128"}.publicKey()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,214 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/stringx"
+)
+
+const (
+	refreshTokenType = "refresh"
+
+	claimChain     = "chain"
+	claimExpire    = "exp"
+	claimId        = "jti"
+	claimTokenType = "typ"
+
+	defaultAccessExpire  = time.Hour
+	defaultRefreshExpire = time.Hour * 24 * 30
+	defaultChainPrefix   = "token:chain:"
+)
+
+var (
+	// ErrInvalidRefreshToken indicates a malformed, expired or revoked refresh token.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused indicates an already-rotated refresh token was
+	// presented again, a theft signal, the whole chain is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused, chain revoked")
+)
+
+type (
+	// A TokenPair is an access token with its refresh token.
+	TokenPair struct {
+		AccessToken  string `json:"accessToken"`
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	// RotatorOption defines the method to customize a Rotator.
+	RotatorOption func(r *Rotator)
+
+	// A Rotator issues access+refresh token pairs and rotates refresh
+	// tokens on use. Each refresh token belongs to a chain, the store
+	// remembers the chain's current token, so a rotated token presented
+	// again reveals theft and revokes the chain.
+	Rotator struct {
+		secret        string
+		store         *redis.Redis
+		accessExpire  time.Duration
+		refreshExpire time.Duration
+		chainPrefix   string
+	}
+)
+
+// NewRotator returns a Rotator signing with secret, tracking chains in store.
+func NewRotator(secret string, store *redis.Redis, opts ...RotatorOption) *Rotator {
+	rotator := &Rotator{
+		secret:        secret,
+		store:         store,
+		accessExpire:  defaultAccessExpire,
+		refreshExpire: defaultRefreshExpire,
+		chainPrefix:   defaultChainPrefix,
+	}
+
+	for _, opt := range opts {
+		opt(rotator)
+	}
+
+	return rotator
+}
+
+// WithAccessExpire customizes a Rotator with the access token lifetime.
+func WithAccessExpire(expire time.Duration) RotatorOption {
+	return func(r *Rotator) {
+		r.accessExpire = expire
+	}
+}
+
+// WithRefreshExpire customizes a Rotator with the refresh token lifetime.
+func WithRefreshExpire(expire time.Duration) RotatorOption {
+	return func(r *Rotator) {
+		r.refreshExpire = expire
+	}
+}
+
+// IssuePair issues a fresh token pair carrying claims, starting a new chain.
+func (r *Rotator) IssuePair(claims map[string]interface{}) (TokenPair, error) {
+	return r.issue(claims, stringx.RandId())
+}
+
+// Rotate validates refreshToken, invalidates it and issues a new pair on
+// the same chain. Presenting an already-rotated token returns
+// ErrRefreshTokenReused and revokes the chain.
+func (r *Rotator) Rotate(refreshToken string) (TokenPair, error) {
+	claims, err := r.parseRefresh(refreshToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	chain, _ := claims[claimChain].(string)
+	id, _ := claims[claimId].(string)
+	if len(chain) == 0 || len(id) == 0 {
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	current, err := r.store.Get(r.chainPrefix + chain)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if len(current) == 0 {
+		// chain revoked or expired
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+	if current != id {
+		if _, err := r.store.Del(r.chainPrefix + chain); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, ErrRefreshTokenReused
+	}
+
+	return r.issue(userClaims(claims), chain)
+}
+
+// Revoke invalidates refreshToken's whole chain.
+func (r *Rotator) Revoke(refreshToken string) error {
+	claims, err := r.parseRefresh(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	chain, _ := claims[claimChain].(string)
+	if len(chain) == 0 {
+		return ErrInvalidRefreshToken
+	}
+
+	_, err = r.store.Del(r.chainPrefix + chain)
+	return err
+}
+
+func (r *Rotator) issue(claims map[string]interface{}, chain string) (TokenPair, error) {
+	now := time.Now()
+	accessClaims := make(jwt.MapClaims, len(claims)+1)
+	for k, v := range claims {
+		accessClaims[k] = v
+	}
+	accessClaims[claimExpire] = now.Add(r.accessExpire).Unix()
+
+	accessToken, err := r.sign(accessClaims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	id := stringx.RandId()
+	refreshClaims := make(jwt.MapClaims, len(claims)+4)
+	for k, v := range claims {
+		refreshClaims[k] = v
+	}
+	refreshClaims[claimExpire] = now.Add(r.refreshExpire).Unix()
+	refreshClaims[claimId] = id
+	refreshClaims[claimChain] = chain
+	refreshClaims[claimTokenType] = refreshTokenType
+
+	refreshToken, err := r.sign(refreshClaims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	seconds := int(r.refreshExpire / time.Second)
+	if err := r.store.Setex(r.chainPrefix+chain, id, seconds); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (r *Rotator) sign(claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(r.secret))
+}
+
+func (r *Rotator) parseRefresh(refreshToken string) (jwt.MapClaims, error) {
+	tok, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte(r.secret), nil
+	})
+	if err != nil || !tok.Valid {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	claims, ok := tok.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	if typ, _ := claims[claimTokenType].(string); typ != refreshTokenType {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return claims, nil
+}
+
+func userClaims(claims jwt.MapClaims) map[string]interface{} {
+	user := make(map[string]interface{}, len(claims))
+	for k, v := range claims {
+		switch k {
+		case claimChain, claimExpire, claimId, claimTokenType:
+		default:
+			user[k] = v
+		}
+	}
+
+	return user
+}
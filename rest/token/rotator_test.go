@@ -0,0 +1,125 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+const rotatorSecret = "the-rotator-secret"
+
+func TestRotatorIssuePair(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store)
+	pair, err := rotator.IssuePair(map[string]interface{}{
+		"uid": "u1",
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+
+	tok, err := jwt.Parse(pair.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte(rotatorSecret), nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, tok.Valid)
+	claims := tok.Claims.(jwt.MapClaims)
+	assert.Equal(t, "u1", claims["uid"])
+	// the access token isn't usable as a refresh token
+	_, err = rotator.Rotate(pair.AccessToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestRotatorRotate(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store)
+	pair, err := rotator.IssuePair(map[string]interface{}{
+		"uid": "u1",
+	})
+	assert.Nil(t, err)
+
+	next, err := rotator.Rotate(pair.RefreshToken)
+	assert.Nil(t, err)
+	assert.NotEqual(t, pair.RefreshToken, next.RefreshToken)
+
+	// the claims ride along
+	tok, err := jwt.Parse(next.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte(rotatorSecret), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "u1", tok.Claims.(jwt.MapClaims)["uid"])
+
+	// the new token keeps working
+	_, err = rotator.Rotate(next.RefreshToken)
+	assert.Nil(t, err)
+}
+
+func TestRotatorReuseRevokesChain(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store)
+	pair, err := rotator.IssuePair(nil)
+	assert.Nil(t, err)
+
+	next, err := rotator.Rotate(pair.RefreshToken)
+	assert.Nil(t, err)
+
+	// replaying the rotated token reveals theft
+	_, err = rotator.Rotate(pair.RefreshToken)
+	assert.Equal(t, ErrRefreshTokenReused, err)
+
+	// the whole chain is dead, the newest token included
+	_, err = rotator.Rotate(next.RefreshToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestRotatorRevoke(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store)
+	pair, err := rotator.IssuePair(nil)
+	assert.Nil(t, err)
+
+	assert.Nil(t, rotator.Revoke(pair.RefreshToken))
+	_, err = rotator.Rotate(pair.RefreshToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestRotatorExpiredRefresh(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store,
+		WithAccessExpire(time.Minute),
+		WithRefreshExpire(-time.Minute))
+	pair, err := rotator.IssuePair(nil)
+	assert.Nil(t, err)
+
+	_, err = rotator.Rotate(pair.RefreshToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestRotatorBadToken(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	rotator := NewRotator(rotatorSecret, store)
+	_, err = rotator.Rotate("not-a-token")
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+	assert.Equal(t, ErrInvalidRefreshToken, rotator.Revoke("not-a-token"))
+}
@@ -1,6 +1,10 @@
 package rest
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/tal-tech/go-zero/rest/handler"
+)
 
 type (
 	// Middleware defines the middleware method.
@@ -13,6 +17,13 @@ type (
 		Handler http.HandlerFunc
 	}
 
+	// A RegisteredRoute describes a route mounted on a Server.
+	RegisteredRoute struct {
+		Method  string `json:"method"`
+		Path    string `json:"path"`
+		Handler string `json:"handler"`
+	}
+
 	// RouteOption defines the method to customize a featured route.
 	RouteOption func(r *featuredRoutes)
 
@@ -27,10 +38,18 @@ type (
 		enabled bool
 	}
 
+	dumpSetting struct {
+		enabled      bool
+		maxBytes     int
+		redactFields []string
+	}
+
 	featuredRoutes struct {
-		priority  bool
-		jwt       jwtSetting
-		signature signatureSetting
-		routes    []Route
+		priority       bool
+		jwt            jwtSetting
+		signature      signatureSetting
+		dump           dumpSetting
+		authenticators []handler.Authenticator
+		routes         []Route
 	}
 )
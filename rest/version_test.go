@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/conf"
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+func TestVersionedHandler(t *testing.T) {
+	handler := versionedHandler("v2", func(w http.ResponseWriter, r *http.Request) {
+		version, ok := httpx.VersionFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "v2", version)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/v2/users", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"v1": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("one"))
+		},
+		"v2": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("two"))
+		},
+	}
+	handler := negotiateVersion(handlers)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/users", nil)
+	req.Header.Set("Accept", "application/vnd.api+json;version=2")
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "two", resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost/users", nil)
+	req.Header.Set("Accept", "application/vnd.api+json;version=3")
+	resp = httptest.NewRecorder()
+	handler(resp, req)
+	assert.Equal(t, http.StatusNotAcceptable, resp.Code)
+}
+
+func TestAddVersionedRoute(t *testing.T) {
+	const configYaml = `
+Name: ver
+Port: 54321
+`
+	var cnf RestConf
+	assert.Nil(t, conf.LoadConfigFromYamlBytes([]byte(configYaml), &cnf))
+	server, err := NewServer(cnf)
+	assert.Nil(t, err)
+	server.AddVersionedRoute(http.MethodGet, "/users", map[string]http.HandlerFunc{
+		"v1": func(w http.ResponseWriter, r *http.Request) {},
+		"v2": func(w http.ResponseWriter, r *http.Request) {},
+	})
+
+	var paths []string
+	for _, fr := range server.ngin.routes {
+		for _, route := range fr.routes {
+			paths = append(paths, route.Path)
+		}
+	}
+	assert.ElementsMatch(t, []string{"/v1/users", "/v2/users", "/users"}, paths)
+}
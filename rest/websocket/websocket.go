@@ -0,0 +1,219 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/proc"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultPongWait  = time.Minute
+	defaultWriteWait = time.Second * 10
+)
+
+var (
+	activeConns     sync.Map
+	shutdownWatcher sync.Once
+)
+
+type (
+	// A Handler serves one websocket connection, returning when done.
+	// The context is canceled when the connection or the server closes.
+	Handler func(ctx context.Context, conn *Conn)
+
+	// Option defines the method to customize a websocket handler.
+	Option func(opts *wsOptions)
+
+	wsOptions struct {
+		pongWait    time.Duration
+		writeWait   time.Duration
+		readLimit   int64
+		checkOrigin func(r *http.Request) bool
+	}
+
+	// A Conn is a websocket connection with synchronized writes.
+	Conn struct {
+		raw       *websocket.Conn
+		writeWait time.Duration
+		writeLock sync.Mutex
+		closeOnce sync.Once
+	}
+)
+
+// NewHandler returns an http.HandlerFunc that upgrades the request and
+// hands the connection to handler. Register it as a regular route, so
+// the server's middleware (auth, tracing, logging) runs before the
+// upgrade. The connection is kept alive with ping/pong and closed
+// gracefully on server shutdown.
+func NewHandler(handler Handler, opts ...Option) http.HandlerFunc {
+	options := wsOptions{
+		pongWait:  defaultPongWait,
+		writeWait: defaultWriteWait,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: options.checkOrigin,
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		rawConn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// Upgrade already replied with an error status
+			logx.Errorf("websocket upgrade: %s", err.Error())
+			return
+		}
+
+		conn := &Conn{
+			raw:       rawConn,
+			writeWait: options.writeWait,
+		}
+		watchShutdown()
+		activeConns.Store(conn, struct{}{})
+		defer activeConns.Delete(conn)
+		defer conn.Close()
+
+		if options.readLimit > 0 {
+			rawConn.SetReadLimit(options.readLimit)
+		}
+		if err := rawConn.SetReadDeadline(time.Now().Add(options.pongWait)); err != nil {
+			logx.Error(err)
+			return
+		}
+		rawConn.SetPongHandler(func(string) error {
+			return rawConn.SetReadDeadline(time.Now().Add(options.pongWait))
+		})
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go keepAlive(ctx, conn, options.pongWait*9/10)
+
+		threading.RunSafe(func() {
+			handler(ctx, conn)
+		})
+	}
+}
+
+// WithCheckOrigin customizes a handler with the origin check,
+// by default same-origin requests are required.
+func WithCheckOrigin(fn func(r *http.Request) bool) Option {
+	return func(opts *wsOptions) {
+		opts.checkOrigin = fn
+	}
+}
+
+// WithPongWait customizes a handler with the max interval between pongs
+// before the connection is considered dead.
+func WithPongWait(d time.Duration) Option {
+	return func(opts *wsOptions) {
+		opts.pongWait = d
+	}
+}
+
+// WithReadLimit customizes a handler with the max message size in bytes.
+func WithReadLimit(limit int64) Option {
+	return func(opts *wsOptions) {
+		opts.readLimit = limit
+	}
+}
+
+// Close closes the connection gracefully, sending a close frame first.
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		deadline := time.Now().Add(c.writeWait)
+		c.writeLock.Lock()
+		if e := c.raw.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			deadline); e != nil {
+			logx.Error(e)
+		}
+		c.writeLock.Unlock()
+		err = c.raw.Close()
+	})
+
+	return err
+}
+
+// ReadMessage reads the next message from the connection.
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	return c.raw.ReadMessage()
+}
+
+// ReadJSON reads the next message and unmarshals it into v.
+func (c *Conn) ReadJSON(v interface{}) error {
+	return c.raw.ReadJSON(v)
+}
+
+// RemoteAddr returns the remote network address.
+func (c *Conn) RemoteAddr() string {
+	return c.raw.RemoteAddr().String()
+}
+
+// WriteMessage writes a message to the connection, safe for concurrent use.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if err := c.raw.SetWriteDeadline(time.Now().Add(c.writeWait)); err != nil {
+		return err
+	}
+
+	return c.raw.WriteMessage(messageType, data)
+}
+
+// WriteJSON writes v as a json message, safe for concurrent use.
+func (c *Conn) WriteJSON(v interface{}) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if err := c.raw.SetWriteDeadline(time.Now().Add(c.writeWait)); err != nil {
+		return err
+	}
+
+	return c.raw.WriteJSON(v)
+}
+
+func (c *Conn) ping() error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return c.raw.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.writeWait))
+}
+
+func keepAlive(ctx context.Context, conn *Conn, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.ping(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func watchShutdown() {
+	shutdownWatcher.Do(func() {
+		proc.AddWrapUpListener(func() {
+			activeConns.Range(func(key, value interface{}) bool {
+				if err := key.(*Conn).Close(); err != nil {
+					logx.Error(err)
+				}
+				return true
+			})
+		})
+	})
+}
@@ -0,0 +1,165 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+func init() {
+	logx.Disable()
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(url, "http", "ws", 1), nil)
+	assert.Nil(t, err)
+	return conn
+}
+
+func TestHandlerEcho(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		for {
+			typ, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(typ, msg); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	conn := dial(t, srv.URL)
+	defer conn.Close()
+
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	_, msg, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg))
+}
+
+func TestHandlerJSON(t *testing.T) {
+	type message struct {
+		Name string `json:"name"`
+	}
+
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		var msg message
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		_ = conn.WriteJSON(msg)
+	}))
+	defer srv.Close()
+
+	conn := dial(t, srv.URL)
+	defer conn.Close()
+
+	assert.Nil(t, conn.WriteJSON(message{Name: "kevin"}))
+	var msg message
+	assert.Nil(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "kevin", msg.Name)
+}
+
+func TestHandlerKeepAlive(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		<-ctx.Done()
+	}, WithPongWait(time.Millisecond*100)))
+	defer srv.Close()
+
+	conn := dial(t, srv.URL)
+	defer conn.Close()
+
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, nil, time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("no ping received")
+	}
+}
+
+func TestHandlerPanicRecovery(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		panic("boom")
+	}))
+	defer srv.Close()
+
+	conn := dial(t, srv.URL)
+	defer conn.Close()
+
+	// the panic is recovered and the connection closed gracefully
+	_, _, err := conn.ReadMessage()
+	assert.NotNil(t, err)
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
+
+func TestHandlerReadLimit(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}, WithReadLimit(4)))
+	defer srv.Close()
+
+	conn := dial(t, srv.URL)
+	defer conn.Close()
+
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte("way over the limit")))
+	_, _, err := conn.ReadMessage()
+	assert.NotNil(t, err)
+}
+
+func TestHandlerCheckOrigin(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+	}, WithCheckOrigin(func(r *http.Request) bool {
+		return false
+	})))
+	defer srv.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(strings.Replace(srv.URL, "http", "ws", 1), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestConnCloseTwice(t *testing.T) {
+	done := make(chan *Conn, 1)
+	srv := httptest.NewServer(NewHandler(func(ctx context.Context, conn *Conn) {
+		done <- conn
+		<-ctx.Done()
+	}))
+	defer srv.Close()
+
+	cli := dial(t, srv.URL)
+	conn := <-done
+	assert.NotPanics(t, func() {
+		conn.Close()
+		conn.Close()
+	})
+	cli.Close()
+}
@@ -13,6 +13,10 @@ import (
 var (
 	// WithDialOption is an alias of internal.WithDialOption.
 	WithDialOption = internal.WithDialOption
+	// WithHedging is an alias of internal.WithHedging.
+	WithHedging = internal.WithHedging
+	// WithKeepalive is an alias of internal.WithKeepalive.
+	WithKeepalive = internal.WithKeepalive
 	// WithTimeout is an alias of internal.WithTimeout.
 	WithTimeout = internal.WithTimeout
 	// WithUnaryClientInterceptor is an alias of internal.WithUnaryClientInterceptor.
@@ -18,6 +18,11 @@ type (
 		// setting 0 means no timeout
 		Timeout      int64 `json:",default=2000"`
 		CpuThreshold int64 `json:",default=900,range=[0:1000]"`
+		// drain window in milliseconds on shutdown, 0 waits for all rpcs to finish
+		DrainTimeout int64 `json:",default=0,optional"`
+		// max age of a connection in milliseconds before the server sends GOAWAY,
+		// forcing clients to reconnect and re-resolve, 0 disables it
+		MaxConnectionAge int64 `json:",default=0,optional"`
 	}
 
 	// A RpcClientConf is a rpc client config.
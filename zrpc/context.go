@@ -0,0 +1,42 @@
+package zrpc
+
+import "context"
+
+type (
+	requestIDKey struct{}
+	tenantKey    struct{}
+	userKey      struct{}
+)
+
+// ContextWithRequestID returns a new context carrying the request id.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// ContextWithTenant returns a new context carrying the tenant.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// ContextWithUser returns a new context carrying the user.
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// RequestIDFromContext returns the request id in ctx, ok false when not set.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
+
+// TenantFromContext returns the tenant in ctx, ok false when not set.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// UserFromContext returns the user in ctx, ok false when not set.
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userKey{}).(string)
+	return user, ok
+}
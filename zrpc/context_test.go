@@ -0,0 +1,32 @@
+package zrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextValues(t *testing.T) {
+	ctx := context.Background()
+	_, ok := RequestIDFromContext(ctx)
+	assert.False(t, ok)
+	_, ok = TenantFromContext(ctx)
+	assert.False(t, ok)
+	_, ok = UserFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = ContextWithRequestID(ctx, "req-1")
+	ctx = ContextWithTenant(ctx, "acme")
+	ctx = ContextWithUser(ctx, "alice")
+
+	requestID, ok := RequestIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", requestID)
+	tenant, ok := TenantFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	user, ok := UserFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", user)
+}
@@ -0,0 +1,355 @@
+// Package gateway transcodes HTTP/JSON requests to gRPC calls based on
+// service descriptors, the in-process equivalent of grpc-gateway.
+package gateway
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/search"
+	"github.com/tal-tech/go-zero/rest/httpx"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type (
+	// TranscoderOption defines the method to customize a Transcoder.
+	TranscoderOption func(t *Transcoder)
+
+	// A Transcoder is a http.Handler that maps REST requests to gRPC
+	// methods and marshals JSON to protobuf and back.
+	Transcoder struct {
+		conn  *grpc.ClientConn
+		trees map[string]*search.Tree
+	}
+
+	transcodeRoute struct {
+		fullMethod string
+		method     protoreflect.MethodDescriptor
+		hasBody    bool
+	}
+
+	errorResponse struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// NewTranscoder returns a Transcoder serving the services in the given
+// descriptor set through the given connection.
+func NewTranscoder(set *descriptorpb.FileDescriptorSet, conn *grpc.ClientConn,
+	opts ...TranscoderOption) (*Transcoder, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transcoder{
+		conn:  conn,
+		trees: make(map[string]*search.Tree),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			methods := service.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				if err = t.addMethod(service, methods.Get(j)); err != nil {
+					return false
+				}
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (t *Transcoder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tree, ok := t.trees[r.Method]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	result, ok := tree.Search(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	route := result.Item.(*transcodeRoute)
+	in := dynamicpb.NewMessage(route.method.Input())
+	if route.hasBody {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, in); err != nil {
+				writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+		}
+	} else {
+		for key, values := range r.URL.Query() {
+			if len(values) > 0 {
+				if err := setField(in, key, values[0]); err != nil {
+					writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+					return
+				}
+			}
+		}
+	}
+	for key, value := range result.Params {
+		if err := setField(in, key, value); err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+	}
+
+	out := dynamicpb.NewMessage(route.method.Output())
+	if err := t.conn.Invoke(r.Context(), route.fullMethod, in, out,
+		grpc.ForceCodec(protoCodec{})); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	content, err := protojson.Marshal(out)
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set(httpx.ContentType, httpx.ApplicationJson)
+	w.Write(content)
+}
+
+func (t *Transcoder) addMethod(service protoreflect.ServiceDescriptor,
+	method protoreflect.MethodDescriptor) error {
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil
+	}
+
+	route := &transcodeRoute{
+		fullMethod: fmt.Sprintf("/%s/%s", service.FullName(), method.Name()),
+		method:     method,
+		hasBody:    true,
+	}
+	if err := t.addRoute(http.MethodPost, route.fullMethod, route); err != nil {
+		return err
+	}
+
+	rule := httpRule(method)
+	if rule == nil {
+		return nil
+	}
+
+	httpMethod, pattern := rulePattern(rule)
+	if len(pattern) == 0 {
+		return nil
+	}
+
+	return t.addRoute(httpMethod, convertPattern(pattern), &transcodeRoute{
+		fullMethod: route.fullMethod,
+		method:     method,
+		hasBody:    bodyAllowed(httpMethod),
+	})
+}
+
+func (t *Transcoder) addRoute(method, path string, route *transcodeRoute) error {
+	tree, ok := t.trees[method]
+	if !ok {
+		tree = search.NewTree()
+		t.trees[method] = tree
+	}
+
+	return tree.Add(path, route)
+}
+
+func bodyAllowed(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// convertPattern converts annotation patterns like /v1/users/{id}
+// into router patterns like /v1/users/:id.
+func convertPattern(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func httpRule(method protoreflect.MethodDescriptor) *annotations.HttpRule {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok {
+		return nil
+	}
+
+	rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+	if !ok {
+		return nil
+	}
+
+	return rule
+}
+
+func rulePattern(rule *annotations.HttpRule) (method, pattern string) {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, p.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, p.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, p.Put
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, p.Patch
+	default:
+		return "", ""
+	}
+}
+
+func setField(msg *dynamicpb.Message, name, value string) error {
+	fields := msg.Descriptor().Fields()
+	field := fields.ByJSONName(name)
+	if field == nil {
+		field = fields.ByName(protoreflect.Name(name))
+	}
+	if field == nil {
+		return fmt.Errorf("unknown field %q", name)
+	}
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		msg.Set(field, protoreflect.ValueOfString(value))
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfBool(b))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfInt32(int32(v)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfInt64(v))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfUint32(uint32(v)))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfUint64(v))
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfFloat32(float32(v)))
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		msg.Set(field, protoreflect.ValueOfFloat64(v))
+	default:
+		return fmt.Errorf("unsupported kind %s for field %q", field.Kind(), name)
+	}
+
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	httpx.WriteJson(w, httpStatus(st.Code()), errorResponse{
+		Code:    int(st.Code()),
+		Message: st.Message(),
+	})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Marshal(v interface{}) ([]byte, error) {
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (protoCodec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+func (protoCodec) Name() string {
+	return "proto"
+}
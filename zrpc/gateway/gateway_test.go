@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/zrpc/internal/mock"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func depositDescriptorSet(t *testing.T) *descriptorpb.FileDescriptorSet {
+	fd, err := protoregistry.GlobalFiles.FindFileByPath("deposit.proto")
+	assert.Nil(t, err)
+	return &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(fd)},
+	}
+}
+
+func TestTranscoderDeposit(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	mock.RegisterDepositServiceServer(server, &mock.DepositServer{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	transcoder, err := NewTranscoder(depositDescriptorSet(t), conn)
+	assert.Nil(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/mock.DepositService/Deposit",
+		strings.NewReader(`{"amount": 100}`))
+	w := httptest.NewRecorder()
+	transcoder.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ok":true`)
+
+	// the grpc error code maps to a http status
+	r = httptest.NewRequest(http.MethodPost, "/mock.DepositService/Deposit",
+		strings.NewReader(`{"amount": -1}`))
+	w = httptest.NewRecorder()
+	transcoder.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cannot deposit")
+
+	r = httptest.NewRequest(http.MethodPost, "/mock.DepositService/Unknown", nil)
+	w = httptest.NewRecorder()
+	transcoder.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTranscoderHttpRule(t *testing.T) {
+	methodOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(methodOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{
+			Get: "/v1/things/{name}",
+		},
+	})
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("things.proto"),
+				Package: proto.String("things"),
+				Syntax:  proto.String("proto3"),
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("GetThingRequest"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{
+								Name:     proto.String("name"),
+								Number:   proto.Int32(1),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								JsonName: proto.String("name"),
+							},
+						},
+					},
+					{
+						Name: proto.String("Thing"),
+					},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name: proto.String("ThingService"),
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{
+								Name:       proto.String("GetThing"),
+								InputType:  proto.String(".things.GetThingRequest"),
+								OutputType: proto.String(".things.Thing"),
+								Options:    methodOptions,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	transcoder, err := NewTranscoder(set, nil)
+	assert.Nil(t, err)
+
+	result, ok := transcoder.trees[http.MethodGet].Search("/v1/things/abc")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"name": "abc"}, result.Params)
+	route := result.Item.(*transcodeRoute)
+	assert.Equal(t, "/things.ThingService/GetThing", route.fullMethod)
+	assert.False(t, route.hasBody)
+
+	_, ok = transcoder.trees[http.MethodPost].Search("/things.ThingService/GetThing")
+	assert.True(t, ok)
+}
+
+func TestConvertPattern(t *testing.T) {
+	assert.Equal(t, "/v1/users/:id", convertPattern("/v1/users/{id}"))
+	assert.Equal(t, "/v1/users/:id/posts/:pid", convertPattern("/v1/users/{id}/posts/{pid}"))
+	assert.Equal(t, "/v1/users", convertPattern("/v1/users"))
+}
+
+func TestHttpStatus(t *testing.T) {
+	assert.Equal(t, http.StatusOK, httpStatus(codes.OK))
+	assert.Equal(t, http.StatusBadRequest, httpStatus(codes.InvalidArgument))
+	assert.Equal(t, http.StatusNotFound, httpStatus(codes.NotFound))
+	assert.Equal(t, http.StatusServiceUnavailable, httpStatus(codes.Unavailable))
+	assert.Equal(t, http.StatusInternalServerError, httpStatus(codes.Unknown))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/tal-tech/go-zero/zrpc/internal/clientinterceptors"
 	"github.com/tal-tech/go-zero/zrpc/internal/resolver"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
@@ -107,6 +108,31 @@ func WithDialOption(opt grpc.DialOption) ClientOption {
 	}
 }
 
+// WithHedging returns a func to customize a ClientOptions with hedged requests,
+// firing another attempt when the previous one hasn't returned within delay,
+// restricted to the given idempotent methods.
+func WithHedging(delay time.Duration, maxAttempts int, methods ...string) ClientOption {
+	return func(options *ClientOptions) {
+		options.DialOptions = append(options.DialOptions, WithUnaryClientInterceptors(
+			clientinterceptors.HedgingInterceptor(delay, maxAttempts, methods...)))
+	}
+}
+
+// WithKeepalive returns a func to customize a ClientOptions with grpc keepalive,
+// pinging the server every keepaliveTime of inactivity and dropping the
+// connection if a ping is not acked within keepaliveTimeout,
+// so connections to dead peers are detected and re-resolved.
+func WithKeepalive(keepaliveTime, keepaliveTimeout time.Duration, permitWithoutStream bool) ClientOption {
+	return func(options *ClientOptions) {
+		options.DialOptions = append(options.DialOptions, grpc.WithKeepaliveParams(
+			keepalive.ClientParameters{
+				Time:                keepaliveTime,
+				Timeout:             keepaliveTimeout,
+				PermitWithoutStream: permitWithoutStream,
+			}))
+	}
+}
+
 // WithTimeout returns a func to customize a ClientOptions with given timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(options *ClientOptions) {
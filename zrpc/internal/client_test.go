@@ -40,3 +40,10 @@ func TestBuildDialOptions(t *testing.T) {
 	opts := c.buildDialOptions(WithDialOption(agent))
 	assert.Contains(t, opts, agent)
 }
+
+func TestWithKeepalive(t *testing.T) {
+	var options ClientOptions
+	opt := WithKeepalive(time.Minute, time.Second*20, true)
+	opt(&options)
+	assert.Equal(t, 1, len(options.DialOptions))
+}
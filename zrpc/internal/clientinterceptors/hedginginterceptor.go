@@ -0,0 +1,93 @@
+package clientinterceptors
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"google.golang.org/grpc"
+)
+
+// HedgingInterceptor returns an interceptor that fires another attempt when
+// the previous one hasn't returned within delay, up to maxAttempts in total,
+// taking the first successful reply and canceling the losers.
+// Only the given fully-qualified methods are hedged, all methods if none given,
+// so restrict it to idempotent methods.
+func HedgingInterceptor(delay time.Duration, maxAttempts int,
+	methods ...string) grpc.UnaryClientInterceptor {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var hedged map[string]lang.PlaceholderType
+	if len(methods) > 0 {
+		hedged = make(map[string]lang.PlaceholderType, len(methods))
+		for _, method := range methods {
+			hedged[method] = lang.Placeholder
+		}
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if hedged != nil {
+			if _, ok := hedged[method]; !ok {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+		}
+
+		type hedgeResult struct {
+			reply interface{}
+			err   error
+		}
+
+		hedgeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan hedgeResult, maxAttempts)
+		var launched int
+		launch := func() {
+			launched++
+			go func() {
+				// each attempt gets its own reply to avoid concurrent writes
+				attemptReply := reflect.New(reflect.TypeOf(reply).Elem()).Interface()
+				err := invoker(hedgeCtx, method, req, attemptReply, cc, opts...)
+				results <- hedgeResult{
+					reply: attemptReply,
+					err:   err,
+				}
+			}()
+		}
+		launch()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		var finished int
+		var lastErr error
+		for {
+			select {
+			case result := <-results:
+				finished++
+				if result.err == nil {
+					reflect.ValueOf(reply).Elem().Set(reflect.ValueOf(result.reply).Elem())
+					return nil
+				}
+
+				lastErr = result.err
+				if launched < maxAttempts {
+					launch()
+				} else if finished == launched {
+					return lastErr
+				}
+			case <-timer.C:
+				if launched < maxAttempts {
+					launch()
+					timer.Reset(delay)
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
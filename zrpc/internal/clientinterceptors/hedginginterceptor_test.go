@@ -0,0 +1,87 @@
+package clientinterceptors
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type hedgeReply struct {
+	value string
+}
+
+func TestHedgingInterceptorFirstWins(t *testing.T) {
+	interceptor := HedgingInterceptor(time.Millisecond*50, 2)
+	var calls int32
+	var reply hedgeReply
+	err := interceptor(context.Background(), "/foo", nil, &reply, nil,
+		func(ctx context.Context, method string, req, reply interface{},
+			cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			atomic.AddInt32(&calls, 1)
+			reply.(*hedgeReply).value = "fast"
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, "fast", reply.value)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgingInterceptorSecondWins(t *testing.T) {
+	interceptor := HedgingInterceptor(time.Millisecond*20, 2)
+	var calls int32
+	var reply hedgeReply
+	started := time.Now()
+	err := interceptor(context.Background(), "/foo", nil, &reply, nil,
+		func(ctx context.Context, method string, req, reply interface{},
+			cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				// the first attempt is slow, the hedge overtakes it
+				select {
+				case <-time.After(time.Second):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return nil
+			}
+
+			reply.(*hedgeReply).value = "hedge"
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, "hedge", reply.value)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.True(t, time.Since(started) < time.Second)
+}
+
+func TestHedgingInterceptorAllFail(t *testing.T) {
+	interceptor := HedgingInterceptor(time.Millisecond, 3)
+	var calls int32
+	var reply hedgeReply
+	err := interceptor(context.Background(), "/foo", nil, &reply, nil,
+		func(ctx context.Context, method string, req, reply interface{},
+			cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("unavailable")
+		})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestHedgingInterceptorMethodNotListed(t *testing.T) {
+	interceptor := HedgingInterceptor(time.Millisecond, 3, "/pkg.Service/Listed")
+	var calls int32
+	var reply hedgeReply
+	err := interceptor(context.Background(), "/pkg.Service/Other", nil, &reply, nil,
+		func(ctx context.Context, method string, req, reply interface{},
+			cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("unavailable")
+		})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -2,7 +2,10 @@ package internal
 
 import (
 	"net"
+	"time"
 
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/proc"
 	"github.com/tal-tech/go-zero/core/stat"
 	"github.com/tal-tech/go-zero/zrpc/internal/serverinterceptors"
@@ -14,11 +17,13 @@ type (
 	ServerOption func(options *rpcServerOptions)
 
 	rpcServerOptions struct {
-		metrics *stat.Metrics
+		metrics      *stat.Metrics
+		drainTimeout time.Duration
 	}
 
 	rpcServer struct {
-		name string
+		name         string
+		drainTimeout time.Duration
 		*baseRpcServer
 	}
 )
@@ -38,6 +43,7 @@ func NewRpcServer(address string, opts ...ServerOption) Server {
 	}
 
 	return &rpcServer{
+		drainTimeout:  options.drainTimeout,
 		baseRpcServer: newBaseRpcServer(address, options.metrics),
 	}
 }
@@ -71,13 +77,44 @@ func (s *rpcServer) Start(register RegisterFn) error {
 	// we need to make sure all others are wrapped up
 	// so we do graceful stop at shutdown phase instead of wrap up phase
 	waitForCalled := proc.AddWrapUpListener(func() {
-		server.GracefulStop()
+		gracefulStop(server, s.drainTimeout)
 	})
 	defer waitForCalled()
 
 	return server.Serve(lis)
 }
 
+// gracefulStop sends GOAWAY and waits for in-flight rpcs to finish,
+// bounded by drainTimeout, then force-closes the remaining streams.
+// A non-positive drainTimeout waits until all rpcs are done.
+func gracefulStop(server *grpc.Server, drainTimeout time.Duration) {
+	if drainTimeout <= 0 {
+		server.GracefulStop()
+		return
+	}
+
+	done := make(chan lang.PlaceholderType)
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(drainTimeout):
+		logx.Infof("drain timeout %v exceeded, force stopping the rpc server", drainTimeout)
+		server.Stop()
+		<-done
+	}
+}
+
+// WithDrainTimeout returns a func that sets the graceful drain window of a Server.
+func WithDrainTimeout(drainTimeout time.Duration) ServerOption {
+	return func(options *rpcServerOptions) {
+		options.drainTimeout = drainTimeout
+	}
+}
+
 // WithMetrics returns a func that sets metrics to a Server.
 func WithMetrics(metrics *stat.Metrics) ServerOption {
 	return func(options *rpcServerOptions) {
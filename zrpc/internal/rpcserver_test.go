@@ -1,10 +1,15 @@
 package internal
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tal-tech/go-zero/core/stat"
+	"github.com/tal-tech/go-zero/zrpc/internal/mock"
+	"google.golang.org/grpc"
 )
 
 func TestWithMetrics(t *testing.T) {
@@ -14,3 +19,39 @@ func TestWithMetrics(t *testing.T) {
 	opt(&options)
 	assert.Equal(t, metrics, options.metrics)
 }
+
+func TestGracefulStopNoConnections(t *testing.T) {
+	server := grpc.NewServer()
+	started := time.Now()
+	gracefulStop(server, 0)
+	assert.True(t, time.Since(started) < time.Second)
+}
+
+func TestGracefulStopDrainTimeout(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	mock.RegisterDepositServiceServer(server, &slowDepositServer{})
+	go server.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	// keep an rpc in flight so GracefulStop has to wait
+	go mock.NewDepositServiceClient(conn).Deposit(context.Background(),
+		&mock.DepositRequest{Amount: 100})
+	time.Sleep(time.Millisecond * 100)
+
+	started := time.Now()
+	gracefulStop(server, time.Millisecond*200)
+	assert.True(t, time.Since(started) < time.Second*5)
+}
+
+type slowDepositServer struct{}
+
+func (*slowDepositServer) Deposit(ctx context.Context, req *mock.DepositRequest) (
+	*mock.DepositResponse, error) {
+	time.Sleep(time.Minute)
+	return &mock.DepositResponse{Ok: true}, nil
+}
@@ -0,0 +1,57 @@
+package serverinterceptors
+
+import (
+	"context"
+
+	"github.com/tal-tech/go-zero/core/errorx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorMapper defines the method to translate an application error
+// into a grpc status, returning nil leaves the error unmapped.
+type ErrorMapper func(err error) *status.Status
+
+// UnaryErrorInterceptor returns a func that maps application errors returned
+// by unary handlers to grpc status errors with the given mapper.
+func UnaryErrorInterceptor(mapper ErrorMapper) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, mapError(mapper, err)
+	}
+}
+
+// StreamErrorInterceptor returns a func that maps application errors returned
+// by stream handlers to grpc status errors with the given mapper.
+func StreamErrorInterceptor(mapper ErrorMapper) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return mapError(mapper, handler(srv, stream))
+	}
+}
+
+func mapError(mapper ErrorMapper, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// status errors are already mapped, pass them through
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if mapper != nil {
+		if st := mapper(err); st != nil {
+			return st.Err()
+		}
+	}
+
+	// errors annotated with errorx.WithCode carry their grpc code
+	if code, ok := errorx.GRPCCode(err); ok {
+		return status.Error(code, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
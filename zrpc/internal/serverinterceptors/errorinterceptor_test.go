@@ -0,0 +1,95 @@
+package serverinterceptors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/errorx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errNotFound = errors.New("resource not found")
+
+func notFoundMapper(err error) *status.Status {
+	if errors.Is(err, errNotFound) {
+		return status.New(codes.NotFound, err.Error())
+	}
+
+	return nil
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect codes.Code
+	}{
+		{
+			name:   "nil error",
+			err:    nil,
+			expect: codes.OK,
+		},
+		{
+			name:   "mapped error",
+			err:    errNotFound,
+			expect: codes.NotFound,
+		},
+		{
+			name:   "unmapped error",
+			err:    errors.New("boom"),
+			expect: codes.Internal,
+		},
+		{
+			name:   "status error passes through",
+			err:    status.Error(codes.PermissionDenied, "denied"),
+			expect: codes.PermissionDenied,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interceptor := UnaryErrorInterceptor(notFoundMapper)
+			_, err := interceptor(context.Background(), nil, nil,
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, test.err
+				})
+			assert.Equal(t, test.expect, status.Code(err))
+		})
+	}
+}
+
+func TestStreamErrorInterceptor(t *testing.T) {
+	interceptor := StreamErrorInterceptor(notFoundMapper)
+	err := interceptor(nil, nil, nil, func(srv interface{}, stream grpc.ServerStream) error {
+		return errNotFound
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = interceptor(nil, nil, nil, func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestUnaryErrorInterceptorNilMapper(t *testing.T) {
+	interceptor := UnaryErrorInterceptor(nil)
+	_, err := interceptor(context.Background(), nil, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("boom")
+		})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUnaryErrorInterceptorAnnotatedError(t *testing.T) {
+	interceptor := UnaryErrorInterceptor(nil)
+	_, err := interceptor(context.Background(), nil, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errorx.WithCode(errors.New("missing"), http.StatusNotFound, codes.NotFound)
+		})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -67,6 +67,10 @@ func (m mockedShedder) Allow() (load.Promise, error) {
 	return nil, load.ErrServiceOverloaded
 }
 
+func (m mockedShedder) Stat() load.ShedderStats {
+	return load.ShedderStats{}
+}
+
 type mockedPromise struct{}
 
 func (m mockedPromise) Pass() {
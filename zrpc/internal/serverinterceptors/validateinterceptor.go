@@ -0,0 +1,62 @@
+package serverinterceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validator describes request messages that can validate themselves,
+// as generated by protoc-gen-validate.
+type Validator interface {
+	Validate() error
+}
+
+// UnaryValidateInterceptor returns a func that validates incoming unary
+// requests implementing Validator, rejecting failures with InvalidArgument.
+func UnaryValidateInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateMessage(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidateInterceptor returns a func that validates every message
+// received on a stream implementing Validator.
+func StreamValidateInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return handler(srv, validatedStream{ServerStream: stream})
+	}
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s validatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validateMessage(m)
+}
+
+func validateMessage(m interface{}) error {
+	v, ok := m.(Validator)
+	if !ok {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package serverinterceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type validatedRequest struct {
+	err error
+}
+
+func (r validatedRequest) Validate() error {
+	return r.err
+}
+
+func TestUnaryValidateInterceptor(t *testing.T) {
+	interceptor := UnaryValidateInterceptor()
+
+	var handled bool
+	_, err := interceptor(context.Background(), validatedRequest{}, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		})
+	assert.Nil(t, err)
+	assert.True(t, handled)
+
+	handled = false
+	_, err = interceptor(context.Background(), validatedRequest{
+		err: errors.New("field amount must be positive"),
+	}, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return nil, nil
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "amount")
+	assert.False(t, handled)
+
+	// requests without a Validate method pass through
+	_, err = interceptor(context.Background(), "plain", nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	assert.Nil(t, err)
+}
+
+type mockedRecvStream struct {
+	grpc.ServerStream
+	err error
+}
+
+func (s mockedRecvStream) RecvMsg(m interface{}) error {
+	return nil
+}
+
+func TestStreamValidateInterceptor(t *testing.T) {
+	interceptor := StreamValidateInterceptor()
+	err := interceptor(nil, mockedRecvStream{}, nil,
+		func(srv interface{}, stream grpc.ServerStream) error {
+			var req validatedRequest
+			return stream.RecvMsg(&req)
+		})
+	assert.Nil(t, err)
+}
+
+func TestValidatedStreamRecvMsg(t *testing.T) {
+	stream := validatedStream{ServerStream: mockedRecvStream{}}
+	assert.Nil(t, stream.RecvMsg("plain"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(stream.RecvMsg(validatedRequest{
+		err: errors.New("bad"),
+	})))
+}
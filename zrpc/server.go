@@ -6,22 +6,37 @@ import (
 
 	"github.com/tal-tech/go-zero/core/load"
 	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/service"
 	"github.com/tal-tech/go-zero/core/stat"
 	"github.com/tal-tech/go-zero/zrpc/internal"
 	"github.com/tal-tech/go-zero/zrpc/internal/auth"
 	"github.com/tal-tech/go-zero/zrpc/internal/serverinterceptors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-// A RpcServer is a rpc server.
-type RpcServer struct {
-	server   internal.Server
-	register internal.RegisterFn
-}
+type (
+	// ServerOption defines the method to customize a RpcServer.
+	ServerOption func(options *serverOptions)
+
+	serverOptions struct {
+		reflection  bool
+		validation  bool
+		errorMapper serverinterceptors.ErrorMapper
+	}
+
+	// A RpcServer is a rpc server.
+	RpcServer struct {
+		server   internal.Server
+		register internal.RegisterFn
+	}
+)
 
 // MustNewServer returns a RpcSever, exits on any error.
-func MustNewServer(c RpcServerConf, register internal.RegisterFn) *RpcServer {
-	server, err := NewServer(c, register)
+func MustNewServer(c RpcServerConf, register internal.RegisterFn, opts ...ServerOption) *RpcServer {
+	server, err := NewServer(c, register, opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -30,27 +45,52 @@ func MustNewServer(c RpcServerConf, register internal.RegisterFn) *RpcServer {
 }
 
 // NewServer returns a RpcServer.
-func NewServer(c RpcServerConf, register internal.RegisterFn) (*RpcServer, error) {
+func NewServer(c RpcServerConf, register internal.RegisterFn, opts ...ServerOption) (*RpcServer, error) {
 	var err error
 	if err = c.Validate(); err != nil {
 		return nil, err
 	}
 
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.reflection && c.Mode != service.ProMode {
+		register = withReflection(register)
+	}
+
 	var server internal.Server
 	metrics := stat.NewMetrics(c.ListenOn)
+	serverOptions := []internal.ServerOption{
+		internal.WithMetrics(metrics),
+		internal.WithDrainTimeout(time.Duration(c.DrainTimeout) * time.Millisecond),
+	}
 	if c.HasEtcd() {
-		server, err = internal.NewRpcPubServer(c.Etcd.Hosts, c.Etcd.Key, c.ListenOn, internal.WithMetrics(metrics))
+		server, err = internal.NewRpcPubServer(c.Etcd.Hosts, c.Etcd.Key, c.ListenOn, serverOptions...)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		server = internal.NewRpcServer(c.ListenOn, internal.WithMetrics(metrics))
+		server = internal.NewRpcServer(c.ListenOn, serverOptions...)
 	}
 
 	server.SetName(c.Name)
+	if c.MaxConnectionAge > 0 {
+		server.AddOptions(grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge: time.Duration(c.MaxConnectionAge) * time.Millisecond,
+		}))
+	}
 	if err = setupInterceptors(server, c, metrics); err != nil {
 		return nil, err
 	}
+	if options.validation {
+		server.AddUnaryInterceptors(serverinterceptors.UnaryValidateInterceptor())
+		server.AddStreamInterceptors(serverinterceptors.StreamValidateInterceptor())
+	}
+	if options.errorMapper != nil {
+		server.AddUnaryInterceptors(serverinterceptors.UnaryErrorInterceptor(options.errorMapper))
+		server.AddStreamInterceptors(serverinterceptors.StreamErrorInterceptor(options.errorMapper))
+	}
 
 	rpcServer := &RpcServer{
 		server:   server,
@@ -63,6 +103,33 @@ func NewServer(c RpcServerConf, register internal.RegisterFn) (*RpcServer, error
 	return rpcServer, nil
 }
 
+// WithErrorMapper translates application errors returned by handlers into
+// grpc status errors with the given mapper, mirroring rest's SetErrorHandler.
+// Errors the mapper leaves unmapped are reported as Internal.
+func WithErrorMapper(mapper func(err error) *status.Status) ServerOption {
+	return func(options *serverOptions) {
+		options.errorMapper = mapper
+	}
+}
+
+// WithValidation runs the Validate method of request messages implementing
+// it, rejecting invalid requests with InvalidArgument before they reach
+// the handler.
+func WithValidation() ServerOption {
+	return func(options *serverOptions) {
+		options.validation = true
+	}
+}
+
+// WithReflection registers the gRPC reflection service on the server,
+// so tools like grpcurl can discover the services.
+// Ignored in pro mode to avoid exposing the api surface in production.
+func WithReflection() ServerOption {
+	return func(options *serverOptions) {
+		options.reflection = true
+	}
+}
+
 // AddOptions adds given options.
 func (rs *RpcServer) AddOptions(options ...grpc.ServerOption) {
 	rs.server.AddOptions(options...)
@@ -93,6 +160,13 @@ func (rs *RpcServer) Stop() {
 	logx.Close()
 }
 
+func withReflection(register internal.RegisterFn) internal.RegisterFn {
+	return func(server *grpc.Server) {
+		register(server)
+		reflection.Register(server)
+	}
+}
+
 func setupInterceptors(server internal.Server, c RpcServerConf, metrics *stat.Metrics) error {
 	if c.CpuThreshold > 0 {
 		shedder := load.NewAdaptiveShedder(load.WithCpuThreshold(c.CpuThreshold))
@@ -122,3 +122,27 @@ func (m *mockedServer) SetName(s string) {
 func (m *mockedServer) Start(register internal.RegisterFn) error {
 	return nil
 }
+
+func TestWithReflection(t *testing.T) {
+	register := withReflection(func(server *grpc.Server) {
+	})
+	server := grpc.NewServer()
+	register(server)
+	_, ok := server.GetServiceInfo()["grpc.reflection.v1alpha.ServerReflection"]
+	assert.True(t, ok)
+}
+
+func TestServerWithReflection(t *testing.T) {
+	srv := MustNewServer(RpcServerConf{
+		ServiceConf: service.ServiceConf{
+			Log: logx.LogConf{
+				ServiceName: "foo",
+				Mode:        "console",
+			},
+		},
+		ListenOn: ":8081",
+	}, func(server *grpc.Server) {
+	}, WithReflection())
+	go srv.Start()
+	srv.Stop()
+}
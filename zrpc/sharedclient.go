@@ -0,0 +1,61 @@
+package zrpc
+
+import (
+	"log"
+	"sync"
+
+	"github.com/tal-tech/go-zero/zrpc/internal"
+)
+
+var (
+	sharedClientsLock sync.Mutex
+	sharedClients     = make(map[string]Client)
+)
+
+// MustNewSharedClient returns a shared Client, exits on any error.
+func MustNewSharedClient(c RpcClientConf, options ...ClientOption) Client {
+	cli, err := NewSharedClient(c, options...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cli
+}
+
+// NewSharedClient returns a Client sharing one underlying connection per
+// target, so multiple generated service clients on the same backend reuse
+// a single grpc.ClientConn. The options only take effect on the first dial
+// of a target.
+func NewSharedClient(c RpcClientConf, options ...ClientOption) (Client, error) {
+	target, err := clientTarget(c)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedClientsLock.Lock()
+	defer sharedClientsLock.Unlock()
+
+	if cli, ok := sharedClients[target]; ok {
+		return cli, nil
+	}
+
+	cli, err := NewClient(c, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedClients[target] = cli
+	return cli, nil
+}
+
+func clientTarget(c RpcClientConf) (string, error) {
+	if len(c.Endpoints) > 0 {
+		return internal.BuildDirectTarget(c.Endpoints), nil
+	}
+
+	if err := c.Etcd.Validate(); err != nil {
+		return "", err
+	}
+
+	return internal.BuildDiscovTarget(c.Etcd.Hosts, c.Etcd.Key), nil
+}
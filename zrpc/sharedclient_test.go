@@ -0,0 +1,60 @@
+package zrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/discov"
+	"google.golang.org/grpc"
+)
+
+func TestNewSharedClient(t *testing.T) {
+	conf := RpcClientConf{
+		Endpoints: []string{"foo"},
+		Timeout:   1000,
+	}
+	opts := []ClientOption{
+		WithDialOption(grpc.WithInsecure()),
+		WithDialOption(grpc.WithContextDialer(dialer())),
+	}
+
+	first, err := NewSharedClient(conf, opts...)
+	assert.Nil(t, err)
+	second, err := NewSharedClient(conf, opts...)
+	assert.Nil(t, err)
+	// one connection serves both service clients
+	assert.True(t, first.Conn() == second.Conn())
+
+	other, err := NewSharedClient(RpcClientConf{
+		Endpoints: []string{"bar"},
+		Timeout:   1000,
+	}, opts...)
+	assert.Nil(t, err)
+	assert.False(t, first.Conn() == other.Conn())
+}
+
+func TestNewSharedClientBadConf(t *testing.T) {
+	_, err := NewSharedClient(RpcClientConf{
+		Etcd: discov.EtcdConf{
+			Hosts: []string{"localhost:2379"},
+		},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestClientTarget(t *testing.T) {
+	target, err := clientTarget(RpcClientConf{
+		Endpoints: []string{"a", "b"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "direct:///a,b", target)
+
+	target, err = clientTarget(RpcClientConf{
+		Etcd: discov.EtcdConf{
+			Hosts: []string{"localhost:2379"},
+			Key:   "svc",
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "discov://localhost:2379/svc", target)
+}
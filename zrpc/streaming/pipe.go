@@ -0,0 +1,187 @@
+// Package streaming wraps bidirectional grpc streams into typed channels
+// with bounded buffering and context-driven shutdown.
+package streaming
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const defaultPipeBuffer = 16
+
+type (
+	// Stream is the subset shared by grpc client and server streams.
+	Stream interface {
+		Context() context.Context
+		SendMsg(m interface{}) error
+		RecvMsg(m interface{}) error
+	}
+
+	// PipeOption defines the method to customize a Pipe.
+	PipeOption func(o *pipeOptions)
+
+	pipeOptions struct {
+		buffer int
+	}
+
+	// A Pipe wraps a bidi stream into typed send and receive channels.
+	// S is the outgoing message type, R the incoming one.
+	Pipe[S, R any] struct {
+		stream    Stream
+		sendCh    chan *S
+		recvCh    chan *R
+		done      chan lang.PlaceholderType
+		failOnce  sync.Once
+		closeOnce sync.Once
+		err       error
+		wg        sync.WaitGroup
+	}
+)
+
+// NewPipe returns a Pipe running send and receive loops on the given stream.
+// The receive channel is bounded, a slow consumer blocks the receive loop
+// and lets grpc flow control push back on the peer.
+func NewPipe[S, R any](stream Stream, opts ...PipeOption) *Pipe[S, R] {
+	options := pipeOptions{
+		buffer: defaultPipeBuffer,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	p := &Pipe[S, R]{
+		stream: stream,
+		sendCh: make(chan *S, options.buffer),
+		recvCh: make(chan *R, options.buffer),
+		done:   make(chan lang.PlaceholderType),
+	}
+	p.wg.Add(2)
+	threading.GoSafe(p.recvLoop)
+	threading.GoSafe(p.sendLoop)
+
+	return p
+}
+
+// WithPipeBuffer customizes the channel buffer size of a Pipe.
+func WithPipeBuffer(buffer int) PipeOption {
+	return func(o *pipeOptions) {
+		if buffer > 0 {
+			o.buffer = buffer
+		}
+	}
+}
+
+// Recv returns the channel of incoming messages,
+// closed when the peer closes the stream or an error occurs.
+func (p *Pipe[S, R]) Recv() <-chan *R {
+	return p.recvCh
+}
+
+// Send sends msg on the stream, blocking when the send buffer is full.
+func (p *Pipe[S, R]) Send(msg *S) error {
+	select {
+	case <-p.done:
+		return p.Err()
+	default:
+	}
+
+	ctx := p.stream.Context()
+	select {
+	case <-p.done:
+		return p.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.sendCh <- msg:
+		return nil
+	}
+}
+
+// CloseSend closes the sending side after the buffered messages are written,
+// calling CloseSend on client streams.
+func (p *Pipe[S, R]) CloseSend() {
+	p.closeOnce.Do(func() {
+		close(p.sendCh)
+	})
+}
+
+// Err returns the error that terminated the pipe, nil on a clean close.
+func (p *Pipe[S, R]) Err() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until both loops have finished and returns the pipe error.
+func (p *Pipe[S, R]) Wait() error {
+	p.wg.Wait()
+	return p.Err()
+}
+
+func (p *Pipe[S, R]) fail(err error) {
+	p.failOnce.Do(func() {
+		p.err = err
+		close(p.done)
+	})
+}
+
+func (p *Pipe[S, R]) recvLoop() {
+	defer func() {
+		close(p.recvCh)
+		p.wg.Done()
+	}()
+
+	ctx := p.stream.Context()
+	for {
+		msg := new(R)
+		if err := p.stream.RecvMsg(msg); err != nil {
+			// a half-close by the peer only ends the receive side, the send
+			// side keeps working until CloseSend or a real failure
+			if err != io.EOF {
+				p.fail(err)
+			}
+			return
+		}
+
+		select {
+		case p.recvCh <- msg:
+		case <-ctx.Done():
+			p.fail(ctx.Err())
+			return
+		}
+	}
+}
+
+func (p *Pipe[S, R]) sendLoop() {
+	defer p.wg.Done()
+
+	ctx := p.stream.Context()
+	for {
+		select {
+		case <-p.done:
+			return
+		case msg, ok := <-p.sendCh:
+			if !ok {
+				if cs, closable := p.stream.(interface{ CloseSend() error }); closable {
+					if err := cs.CloseSend(); err != nil {
+						p.fail(err)
+					}
+				}
+				return
+			}
+			if err := p.stream.SendMsg(msg); err != nil {
+				p.fail(err)
+				return
+			}
+		case <-ctx.Done():
+			p.fail(ctx.Err())
+			return
+		}
+	}
+}
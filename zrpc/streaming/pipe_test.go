@@ -0,0 +1,156 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStream struct {
+	ctx       context.Context
+	incoming  chan string
+	recvErr   error
+	lock      sync.Mutex
+	sent      []string
+	closeSent bool
+}
+
+func newMockStream() *mockStream {
+	return &mockStream{
+		ctx:      context.Background(),
+		incoming: make(chan string, 8),
+	}
+}
+
+func (s *mockStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockStream) SendMsg(m interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sent = append(s.sent, *m.(*string))
+	return nil
+}
+
+func (s *mockStream) RecvMsg(m interface{}) error {
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case msg, ok := <-s.incoming:
+		if !ok {
+			if s.recvErr != nil {
+				return s.recvErr
+			}
+			return io.EOF
+		}
+
+		*m.(*string) = msg
+		return nil
+	}
+}
+
+func (s *mockStream) CloseSend() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closeSent = true
+	return nil
+}
+
+func (s *mockStream) sentMessages() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.sent...)
+}
+
+func TestPipeReceive(t *testing.T) {
+	stream := newMockStream()
+	stream.incoming <- "a"
+	stream.incoming <- "b"
+	close(stream.incoming)
+
+	pipe := NewPipe[string, string](stream)
+	var got []string
+	for msg := range pipe.Recv() {
+		got = append(got, *msg)
+	}
+	assert.Equal(t, []string{"a", "b"}, got)
+	assert.Nil(t, pipe.Err())
+}
+
+func TestPipeSendAndClose(t *testing.T) {
+	stream := newMockStream()
+	close(stream.incoming)
+
+	pipe := NewPipe[string, string](stream)
+	one, two := "one", "two"
+	assert.Nil(t, pipe.Send(&one))
+	assert.Nil(t, pipe.Send(&two))
+	pipe.CloseSend()
+	assert.Nil(t, pipe.Wait())
+	assert.Equal(t, []string{"one", "two"}, stream.sentMessages())
+	assert.True(t, stream.closeSent)
+}
+
+func TestPipeSendAfterPeerHalfClose(t *testing.T) {
+	stream := newMockStream()
+	stream.incoming <- "req"
+	close(stream.incoming)
+
+	pipe := NewPipe[string, string](stream)
+	var got []string
+	for msg := range pipe.Recv() {
+		got = append(got, *msg)
+	}
+	assert.Equal(t, []string{"req"}, got)
+
+	// replies after the peer half-closed must still be delivered
+	reply := "reply"
+	assert.Nil(t, pipe.Send(&reply))
+	pipe.CloseSend()
+	assert.Nil(t, pipe.Wait())
+	assert.Equal(t, []string{"reply"}, stream.sentMessages())
+	assert.True(t, stream.closeSent)
+}
+
+func TestPipeRecvError(t *testing.T) {
+	stream := newMockStream()
+	stream.recvErr = errors.New("connection reset")
+	close(stream.incoming)
+
+	pipe := NewPipe[string, string](stream)
+	_, ok := <-pipe.Recv()
+	assert.False(t, ok)
+	assert.Equal(t, stream.recvErr, pipe.Err())
+
+	// senders observe the failure instead of blocking forever
+	msg := "x"
+	assert.Equal(t, stream.recvErr, pipe.Send(&msg))
+	pipe.CloseSend()
+}
+
+func TestPipeBufferOption(t *testing.T) {
+	stream := newMockStream()
+	close(stream.incoming)
+	pipe := NewPipe[string, string](stream, WithPipeBuffer(2))
+	assert.Equal(t, 2, cap(pipe.sendCh))
+	assert.Equal(t, 2, cap(pipe.recvCh))
+	pipe.CloseSend()
+	assert.Nil(t, pipe.Wait())
+}
+
+func TestPipeContextCancel(t *testing.T) {
+	stream := newMockStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream.ctx = ctx
+
+	pipe := NewPipe[string, string](stream)
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	assert.NotNil(t, pipe.Wait())
+}